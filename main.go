@@ -17,6 +17,10 @@ func main() {
 	// perform the post-migration for new databases
 	if database.Initialize(config.GetDatabasePath()) {
 		manager.GetInstance().PostMigrate()
+	} else if !database.NeedsMigration() {
+		// the configured credentials may have been changed while the
+		// server was stopped
+		manager.GetInstance().RefreshConfigUser()
 	}
 
 	api.Start()
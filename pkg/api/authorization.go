@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// mutationRoles are the roles required to run mutations. Mutations that are
+// not listed require the EDITOR role.
+var mutationRoles = map[string]models.UserRole{
+	"configureGeneral":          models.UserRoleAdmin,
+	"configureInterface":        models.UserRoleAdmin,
+	"generateAPIKey":            models.UserRoleAdmin,
+	"exportObjects":             models.UserRoleAdmin,
+	"importObjects":             models.UserRoleAdmin,
+	"metadataImport":            models.UserRoleAdmin,
+	"metadataExport":            models.UserRoleAdmin,
+	"metadataScan":              models.UserRoleAdmin,
+	"metadataGenerate":          models.UserRoleAdmin,
+	"metadataAutoTag":           models.UserRoleAdmin,
+	"metadataIdentify":          models.UserRoleAdmin,
+	"metadataClean":             models.UserRoleAdmin,
+	"migrateHashNaming":         models.UserRoleAdmin,
+	"reloadScrapers":            models.UserRoleAdmin,
+	"clearScraperCache":         models.UserRoleAdmin,
+	"runPluginTask":             models.UserRoleAdmin,
+	"reloadPlugins":             models.UserRoleAdmin,
+	"stopJob":                   models.UserRoleAdmin,
+	"stopAllJobs":               models.UserRoleAdmin,
+	"killStreamSession":         models.UserRoleAdmin,
+	"userCreate":                models.UserRoleAdmin,
+	"userDestroy":               models.UserRoleAdmin,
+	"scheduledTaskCreate":       models.UserRoleAdmin,
+	"scheduledTaskUpdate":       models.UserRoleAdmin,
+	"scheduledTaskDestroy":      models.UserRoleAdmin,
+	"stashBoxRefreshPerformers": models.UserRoleAdmin,
+	"backupDatabase":            models.UserRoleAdmin,
+
+	// users may change their own password and API key
+	"userUpdate":         models.UserRoleReadOnly,
+	"userGenerateAPIKey": models.UserRoleReadOnly,
+
+	// playback history is recorded for all users
+	"sceneIncrementPlayCount": models.UserRoleReadOnly,
+	"sceneSaveActivity":       models.UserRoleReadOnly,
+}
+
+// queryRoles are the roles required to run queries. Queries that are not
+// listed may be run by all users.
+var queryRoles = map[string]models.UserRole{
+	"users":          models.UserRoleAdmin,
+	"directory":      models.UserRoleAdmin,
+	"logs":           models.UserRoleAdmin,
+	"streamSessions": models.UserRoleAdmin,
+}
+
+// subscriptionRoles are the roles required to run subscriptions.
+// Subscriptions that are not listed may be run by all users.
+var subscriptionRoles = map[string]models.UserRole{
+	"loggingSubscribe": models.UserRoleAdmin,
+}
+
+func getRequiredRole(object string, field string) *models.UserRole {
+	var ret models.UserRole
+	var found bool
+	switch object {
+	case "Mutation":
+		ret, found = mutationRoles[field]
+		if !found {
+			ret = models.UserRoleEditor
+			found = true
+		}
+	case "Query":
+		ret, found = queryRoles[field]
+	case "Subscription":
+		ret, found = subscriptionRoles[field]
+	}
+
+	if !found {
+		return nil
+	}
+
+	return &ret
+}
+
+// authorizeField is a graphql resolver middleware that returns an error if
+// the current user does not have the role required to run the query,
+// mutation or subscription.
+func authorizeField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc != nil {
+		requiredRole := getRequiredRole(fc.Object, fc.Field.Name)
+		if err := authorizeRole(ctx, fc.Field.Name, requiredRole); err != nil {
+			return nil, err
+		}
+	}
+
+	return next(ctx)
+}
+
+func authorizeRole(ctx context.Context, operation string, requiredRole *models.UserRole) error {
+	if requiredRole == nil {
+		return nil
+	}
+
+	// all operations are permitted when authentication is not required
+	currentUser := getCurrentUser(ctx)
+	if currentUser != nil && !currentUser.HasRole(*requiredRole) {
+		return fmt.Errorf("%s requires the %s role", operation, requiredRole.String())
+	}
+
+	return nil
+}
+
+// isAdmin returns true if the current user has the admin role, or if
+// authentication is not required.
+func isAdmin(ctx context.Context) bool {
+	currentUser := getCurrentUser(ctx)
+	return currentUser == nil || currentUser.HasRole(models.UserRoleAdmin)
+}
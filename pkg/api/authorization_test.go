@@ -0,0 +1,30 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestGetRequiredRole(t *testing.T) {
+	assert.Equal(t, models.UserRoleAdmin, *getRequiredRole("Mutation", "configureGeneral"))
+	assert.Equal(t, models.UserRoleEditor, *getRequiredRole("Mutation", "sceneUpdate"))
+	assert.Equal(t, models.UserRoleAdmin, *getRequiredRole("Query", "logs"))
+	assert.Nil(t, getRequiredRole("Query", "findScenes"))
+	assert.Equal(t, models.UserRoleAdmin, *getRequiredRole("Subscription", "loggingSubscribe"))
+	assert.Nil(t, getRequiredRole("Subscription", "jobsSubscribe"))
+}
+
+func TestAuthorizeRole(t *testing.T) {
+	required := getRequiredRole("Subscription", "loggingSubscribe")
+
+	assert.NotNil(t, authorizeRole(getUserContext(models.UserRoleReadOnly), "loggingSubscribe", required))
+	assert.NotNil(t, authorizeRole(getUserContext(models.UserRoleEditor), "loggingSubscribe", required))
+	assert.Nil(t, authorizeRole(getUserContext(models.UserRoleAdmin), "loggingSubscribe", required))
+
+	// all operations are permitted when authentication is not required
+	assert.Nil(t, authorizeRole(context.TODO(), "loggingSubscribe", required))
+}
@@ -18,9 +18,13 @@ func getArgumentMap(ctx context.Context) map[string]interface{} {
 }
 
 func getUpdateInputMap(ctx context.Context) map[string]interface{} {
+	return getNamedUpdateInputMap(ctx, updateInputField)
+}
+
+func getNamedUpdateInputMap(ctx context.Context, field string) map[string]interface{} {
 	args := getArgumentMap(ctx)
 
-	input, _ := args[updateInputField]
+	input, _ := args[field]
 	var ret map[string]interface{}
 	if input != nil {
 		ret, _ = input.(map[string]interface{})
@@ -65,6 +69,15 @@ func (t changesetTranslator) hasField(field string) bool {
 	return found
 }
 
+func (t changesetTranslator) getFields() []string {
+	var ret []string
+	for k := range t.inputMap {
+		ret = append(ret, k)
+	}
+
+	return ret
+}
+
 func (t changesetTranslator) nullString(value *string, field string) *sql.NullString {
 	if !t.hasField(field) {
 		return nil
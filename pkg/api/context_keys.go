@@ -10,7 +10,6 @@ const (
 	sceneKey     key = 2
 	studioKey    key = 3
 	movieKey     key = 4
-	ContextUser  key = 5
 	tagKey       key = 6
 	downloadKey  key = 7
 	imageKey     key = 8
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var playHistoryLoaderCtxKey = &contextKey{"PlayHistoryLoader"}
+
+// playHistoryLoader loads the play history of each scene once per request,
+// rather than once for each of the play history fields of the scene. The
+// history is cached by scene object, so that a scene returned by a later
+// mutation in the same request is loaded again.
+type playHistoryLoader struct {
+	mutex   sync.Mutex
+	entries map[*models.Scene]*playHistoryEntry
+}
+
+type playHistoryEntry struct {
+	once    sync.Once
+	history *models.ScenePlayHistory
+	err     error
+}
+
+// load returns the play history of the scene, calling fetch if it has not
+// been loaded.
+func (l *playHistoryLoader) load(scene *models.Scene, fetch func() (*models.ScenePlayHistory, error)) (*models.ScenePlayHistory, error) {
+	l.mutex.Lock()
+	e := l.entries[scene]
+	if e == nil {
+		e = &playHistoryEntry{}
+		l.entries[scene] = e
+	}
+	l.mutex.Unlock()
+
+	// the fields of a scene are resolved concurrently
+	e.once.Do(func() {
+		e.history, e.err = fetch()
+	})
+
+	return e.history, e.err
+}
+
+// PlayHistoryLoaderMiddleware adds a play history loader to the context of
+// each request.
+func PlayHistoryLoaderMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		loader := &playHistoryLoader{
+			entries: make(map[*models.Scene]*playHistoryEntry),
+		}
+
+		r = r.WithContext(context.WithValue(r.Context(), playHistoryLoaderCtxKey, loader))
+		next.ServeHTTP(w, r)
+	})
+}
@@ -5,12 +5,20 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 )
 
+type hookExecutor interface {
+	ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string)
+}
+
 type Resolver struct {
-	txnManager models.TransactionManager
+	txnManager   models.TransactionManager
+	hookExecutor hookExecutor
+	jobManager   *job.Manager
 }
 
 func (r *Resolver) Gallery() models.GalleryResolver {
@@ -54,6 +62,13 @@ func (r *Resolver) ScrapedSceneTag() models.ScrapedSceneTagResolver {
 func (r *Resolver) ScrapedSceneMovie() models.ScrapedSceneMovieResolver {
 	return &scrapedSceneMovieResolver{r}
 }
+func (r *Resolver) ScheduledTask() models.ScheduledTaskResolver {
+	return &scheduledTaskResolver{r}
+}
+
+func (r *Resolver) User() models.UserResolver {
+	return &userResolver{r}
+}
 
 func (r *Resolver) ScrapedScenePerformer() models.ScrapedScenePerformerResolver {
 	return &scrapedScenePerformerResolver{r}
@@ -79,6 +94,8 @@ type scrapedSceneTagResolver struct{ *Resolver }
 type scrapedSceneMovieResolver struct{ *Resolver }
 type scrapedScenePerformerResolver struct{ *Resolver }
 type scrapedSceneStudioResolver struct{ *Resolver }
+type scheduledTaskResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
 
 func (r *Resolver) withTxn(ctx context.Context, fn func(r models.Repository) error) error {
 	return r.txnManager.WithTxn(ctx, fn)
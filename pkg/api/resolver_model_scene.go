@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/pkg/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/models"
@@ -22,6 +24,14 @@ func (r *sceneResolver) Oshash(ctx context.Context, obj *models.Scene) (*string,
 	return nil, nil
 }
 
+func (r *sceneResolver) Phash(ctx context.Context, obj *models.Scene) (*string, error) {
+	if obj.Phash.Valid {
+		hexval := utils.PhashToString(obj.Phash.Int64)
+		return &hexval, nil
+	}
+	return nil, nil
+}
+
 func (r *sceneResolver) Title(ctx context.Context, obj *models.Scene) (*string, error) {
 	if obj.Title.Valid {
 		return &obj.Title.String, nil
@@ -59,6 +69,76 @@ func (r *sceneResolver) Rating(ctx context.Context, obj *models.Scene) (*int, er
 	return nil, nil
 }
 
+// getPlayHistory returns the play history of the scene for the current user.
+// An empty history is returned if the user has not played the scene. The
+// history is loaded once per request if the context has a play history
+// loader.
+func (r *sceneResolver) getPlayHistory(ctx context.Context, obj *models.Scene) (*models.ScenePlayHistory, error) {
+	if loader, ok := ctx.Value(playHistoryLoaderCtxKey).(*playHistoryLoader); ok {
+		return loader.load(obj, func() (*models.ScenePlayHistory, error) {
+			return r.fetchPlayHistory(ctx, obj)
+		})
+	}
+
+	return r.fetchPlayHistory(ctx, obj)
+}
+
+func (r *sceneResolver) fetchPlayHistory(ctx context.Context, obj *models.Scene) (*models.ScenePlayHistory, error) {
+	var ret *models.ScenePlayHistory
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		var err error
+		ret, err = repo.Scene().GetPlayHistory(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		ret = &models.ScenePlayHistory{SceneID: obj.ID}
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) PlayCount(ctx context.Context, obj *models.Scene) (*int, error) {
+	history, err := r.getPlayHistory(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &history.PlayCount, nil
+}
+
+func (r *sceneResolver) LastPlayedAt(ctx context.Context, obj *models.Scene) (*time.Time, error) {
+	history, err := r.getPlayHistory(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if history.LastPlayedAt.Valid {
+		return &history.LastPlayedAt.Timestamp, nil
+	}
+	return nil, nil
+}
+
+func (r *sceneResolver) ResumeTime(ctx context.Context, obj *models.Scene) (*float64, error) {
+	history, err := r.getPlayHistory(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &history.ResumeTime, nil
+}
+
+func (r *sceneResolver) PlayDuration(ctx context.Context, obj *models.Scene) (*float64, error) {
+	history, err := r.getPlayHistory(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return &history.PlayDuration, nil
+}
+
 func (r *sceneResolver) File(ctx context.Context, obj *models.Scene) (*models.SceneFileType, error) {
 	width := int(obj.Width.Int64)
 	height := int(obj.Height.Int64)
@@ -75,6 +155,74 @@ func (r *sceneResolver) File(ctx context.Context, obj *models.Scene) (*models.Sc
 	}, nil
 }
 
+func (r *sceneResolver) Files(ctx context.Context, obj *models.Scene) ([]*models.VideoFile, error) {
+	var files []*models.File
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		var err error
+		files, err = repo.File().FindBySceneID(obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var ret []*models.VideoFile
+	for _, f := range files {
+		ret = append(ret, fileToVideoFile(f))
+	}
+
+	return ret, nil
+}
+
+func fileToVideoFile(f *models.File) *models.VideoFile {
+	ret := &models.VideoFile{
+		ID:      strconv.Itoa(f.ID),
+		Path:    f.Path,
+		Primary: f.Primary,
+	}
+
+	if f.Checksum.Valid {
+		ret.Checksum = &f.Checksum.String
+	}
+	if f.OSHash.Valid {
+		ret.Oshash = &f.OSHash.String
+	}
+	if f.Size.Valid {
+		ret.Size = &f.Size.String
+	}
+	if f.Duration.Valid {
+		ret.Duration = &f.Duration.Float64
+	}
+	if f.VideoCodec.Valid {
+		ret.VideoCodec = &f.VideoCodec.String
+	}
+	if f.AudioCodec.Valid {
+		ret.AudioCodec = &f.AudioCodec.String
+	}
+	if f.Format.Valid {
+		ret.Format = &f.Format.String
+	}
+	if f.Width.Valid {
+		width := int(f.Width.Int64)
+		ret.Width = &width
+	}
+	if f.Height.Valid {
+		height := int(f.Height.Int64)
+		ret.Height = &height
+	}
+	if f.Framerate.Valid {
+		ret.Framerate = &f.Framerate.Float64
+	}
+	if f.Bitrate.Valid {
+		bitrate := int(f.Bitrate.Int64)
+		ret.Bitrate = &bitrate
+	}
+	if f.FileModTime.Valid {
+		ret.FileModTime = &f.FileModTime.Timestamp
+	}
+
+	return ret
+}
+
 func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*models.ScenePathsType, error) {
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj.ID)
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestScenePlayHistoryLoadedOnce(t *testing.T) {
+	const sceneID = 1
+
+	r := newResolver()
+
+	sceneRW := r.txnManager.(*mocks.TransactionManager).Scene().(*mocks.SceneReaderWriter)
+	sceneRW.On("GetPlayHistory", sceneID).Return(&models.ScenePlayHistory{
+		SceneID:      sceneID,
+		PlayCount:    2,
+		ResumeTime:   10,
+		PlayDuration: 20,
+	}, nil).Once()
+
+	// get the context of a request
+	var ctx context.Context
+	PlayHistoryLoaderMiddleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx = req.Context()
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/graphql", nil))
+
+	scene := &models.Scene{ID: sceneID}
+	sr := r.Scene()
+
+	playCount, err := sr.PlayCount(ctx, scene)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, *playCount)
+
+	resumeTime, err := sr.ResumeTime(ctx, scene)
+	assert.Nil(t, err)
+	assert.Equal(t, 10.0, *resumeTime)
+
+	playDuration, err := sr.PlayDuration(ctx, scene)
+	assert.Nil(t, err)
+	assert.Equal(t, 20.0, *playDuration)
+
+	sceneRW.AssertExpectations(t)
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *scheduledTaskResolver) LastRun(ctx context.Context, obj *models.ScheduledTask) (*time.Time, error) {
+	return manager.GetInstance().Scheduler.LastRun(obj.ID), nil
+}
+
+func (r *scheduledTaskResolver) NextRun(ctx context.Context, obj *models.ScheduledTask) (*time.Time, error) {
+	return manager.GetInstance().Scheduler.NextRun(obj.ID), nil
+}
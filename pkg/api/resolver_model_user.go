@@ -0,0 +1,11 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *userResolver) HasAPIKey(ctx context.Context, obj *models.User) (bool, error) {
+	return obj.APIKey.Valid && obj.APIKey.String != "", nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/user"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -88,7 +89,27 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input models.Co
 		config.Set(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
 
+	if input.MaxTranscodeSessions != nil {
+		config.Set(config.MaxTranscodeSessions, *input.MaxTranscodeSessions)
+	}
+
+	if input.TranscodeIdleTimeout != nil {
+		config.Set(config.TranscodeIdleTimeout, *input.TranscodeIdleTimeout)
+	}
+
+	if input.StreamSegmentCacheSize != nil {
+		config.Set(config.StreamSegmentCacheSize, *input.StreamSegmentCacheSize)
+	}
+
 	if input.Username != nil {
+		if *input.Username != "" && *input.Username != config.GetUsername() && usersEnabled() {
+			if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+				return user.ValidateUsername(repo.User(), *input.Username, 0)
+			}); err != nil {
+				return makeConfigGeneralResult(), err
+			}
+		}
+
 		config.Set(config.Username, input.Username)
 	}
 
@@ -140,6 +161,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input models.Co
 
 	config.Set(config.CreateGalleriesFromFolders, input.CreateGalleriesFromFolders)
 
+	if input.WatchStashPaths != nil {
+		config.Set(config.WatchStashPaths, *input.WatchStashPaths)
+	}
+
 	refreshScraperCache := false
 	if input.ScraperUserAgent != nil {
 		config.Set(config.ScraperUserAgent, input.ScraperUserAgent)
@@ -164,7 +189,12 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input models.Co
 		return makeConfigGeneralResult(), err
 	}
 
+	if err := r.syncConfigUser(); err != nil {
+		return makeConfigGeneralResult(), err
+	}
+
 	manager.GetInstance().RefreshConfig()
+	manager.GetInstance().RefreshWatcher()
 	if refreshScraperCache {
 		manager.GetInstance().RefreshScraperCache()
 	}
@@ -242,5 +272,19 @@ func (r *mutationResolver) GenerateAPIKey(ctx context.Context, input models.Gene
 		return newAPIKey, err
 	}
 
+	if err := r.syncConfigUser(); err != nil {
+		return newAPIKey, err
+	}
+
 	return newAPIKey, nil
 }
+
+// syncConfigUser updates the configured user after the credentials in the
+// configuration have been changed.
+func (r *mutationResolver) syncConfigUser() error {
+	if !usersEnabled() {
+		return nil
+	}
+
+	return manager.SyncConfigUser(r.txnManager)
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -90,6 +92,10 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input models.Galle
 		return nil, err
 	}
 
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, plugin.GalleryCreatePost, input, translator.getFields())
 	return gallery, nil
 }
 
@@ -130,6 +136,7 @@ func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.Galle
 		return nil, err
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.GalleryUpdatePost, input, translator.getFields())
 	return ret, nil
 }
 
@@ -156,6 +163,15 @@ func (r *mutationResolver) GalleriesUpdate(ctx context.Context, input []*models.
 		return nil, err
 	}
 
+	// execute post hooks outside of txn
+	for i, gallery := range ret {
+		translator := changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, plugin.GalleryUpdatePost, input[i], translator.getFields())
+	}
+
 	return ret, nil
 }
 
@@ -237,6 +253,11 @@ func (r *mutationResolver) galleryUpdate(input models.GalleryUpdateInput, transl
 }
 
 func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input models.BulkGalleryUpdateInput) ([]*models.Gallery, error) {
+	galleryIDs, err := utils.StringSliceToIntSlice(input.Ids)
+	if err != nil {
+		return nil, err
+	}
+
 	// Populate gallery from the input
 	updatedTime := time.Now()
 
@@ -261,8 +282,15 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input models.B
 	if err := r.withTxn(ctx, func(repo models.Repository) error {
 		qb := repo.Gallery()
 
-		for _, galleryIDStr := range input.Ids {
-			galleryID, _ := strconv.Atoi(galleryIDStr)
+		if input.SavedFilterID != nil {
+			filterGalleryIDs, err := savedfilter.FindGalleryIDs(repo.SavedFilter(), qb, *input.SavedFilterID)
+			if err != nil {
+				return err
+			}
+			galleryIDs = utils.IntAppendUniques(galleryIDs, filterGalleryIDs)
+		}
+
+		for _, galleryID := range galleryIDs {
 			updatedGallery.ID = galleryID
 
 			gallery, err := qb.UpdatePartial(updatedGallery)
@@ -314,6 +342,11 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input models.B
 		return nil, err
 	}
 
+	// execute post hooks outside of txn
+	for _, gallery := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, plugin.GalleryUpdatePost, input, translator.getFields())
+	}
+
 	return ret, nil
 }
 
@@ -438,6 +471,15 @@ func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.Gall
 		}
 	}
 
+	for _, gallery := range galleries {
+		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, plugin.GalleryDestroyPost, input, nil)
+	}
+
+	// images destroyed along with the galleries
+	for _, img := range imgsToPostProcess {
+		r.hookExecutor.ExecutePostHooks(ctx, img.ID, plugin.ImageDestroyPost, nil, nil)
+	}
+
 	return true, nil
 }
 
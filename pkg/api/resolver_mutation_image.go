@@ -8,6 +8,8 @@ import (
 
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -24,6 +26,7 @@ func (r *mutationResolver) ImageUpdate(ctx context.Context, input models.ImageUp
 		return nil, err
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.ImageUpdatePost, input, translator.getFields())
 	return ret, nil
 }
 
@@ -50,6 +53,15 @@ func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*models.Ima
 		return nil, err
 	}
 
+	// execute post hooks outside of txn
+	for i, image := range ret {
+		translator := changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		r.hookExecutor.ExecutePostHooks(ctx, image.ID, plugin.ImageUpdatePost, input[i], translator.getFields())
+	}
+
 	return ret, nil
 }
 
@@ -150,6 +162,14 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input models.Bul
 	if err := r.withTxn(ctx, func(repo models.Repository) error {
 		qb := repo.Image()
 
+		if input.SavedFilterID != nil {
+			filterImageIDs, err := savedfilter.FindImageIDs(repo.SavedFilter(), qb, *input.SavedFilterID)
+			if err != nil {
+				return err
+			}
+			imageIDs = utils.IntAppendUniques(imageIDs, filterImageIDs)
+		}
+
 		for _, imageID := range imageIDs {
 			updatedImage.ID = imageID
 
@@ -202,6 +222,11 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input models.Bul
 		return nil, err
 	}
 
+	// execute post hooks outside of txn
+	for _, image := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, image.ID, plugin.ImageUpdatePost, input, translator.getFields())
+	}
+
 	return ret, nil
 }
 
@@ -268,6 +293,8 @@ func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageD
 		manager.DeleteImageFile(image)
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, image.ID, plugin.ImageDestroyPost, input, nil)
+
 	return true, nil
 }
 
@@ -315,6 +342,8 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 		if input.DeleteFile != nil && *input.DeleteFile {
 			manager.DeleteImageFile(image)
 		}
+
+		r.hookExecutor.ExecutePostHooks(ctx, image.ID, plugin.ImageDestroyPost, input, nil)
 	}
 
 	return true, nil
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io/ioutil"
 	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/stashapp/stash/pkg/database"
@@ -15,13 +17,13 @@ import (
 )
 
 func (r *mutationResolver) MetadataScan(ctx context.Context, input models.ScanMetadataInput) (string, error) {
-	manager.GetInstance().Scan(input)
-	return "todo", nil
+	jobID := manager.GetInstance().Scan(ctx, input)
+	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) MetadataImport(ctx context.Context) (string, error) {
-	manager.GetInstance().Import()
-	return "todo", nil
+	jobID := manager.GetInstance().Import(ctx)
+	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) ImportObjects(ctx context.Context, input models.ImportObjectsInput) (string, error) {
@@ -30,27 +32,23 @@ func (r *mutationResolver) ImportObjects(ctx context.Context, input models.Impor
 		return "", err
 	}
 
-	_, err = manager.GetInstance().RunSingleTask(t)
-	if err != nil {
-		return "", err
-	}
+	jobID := manager.GetInstance().RunSingleTask(ctx, t)
 
-	return "todo", nil
+	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) MetadataExport(ctx context.Context) (string, error) {
-	manager.GetInstance().Export()
-	return "todo", nil
+	jobID := manager.GetInstance().Export(ctx)
+	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) ExportObjects(ctx context.Context, input models.ExportObjectsInput) (*string, error) {
 	t := manager.CreateExportTask(config.GetVideoFileNamingAlgorithm(), input)
-	wg, err := manager.GetInstance().RunSingleTask(t)
-	if err != nil {
-		return nil, err
-	}
 
-	wg.Wait()
+	// the export is run synchronously, since the result is returned to the caller
+	var wg sync.WaitGroup
+	wg.Add(1)
+	t.Start(&wg)
 
 	if t.DownloadHash != "" {
 		baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
@@ -65,38 +63,32 @@ func (r *mutationResolver) ExportObjects(ctx context.Context, input models.Expor
 }
 
 func (r *mutationResolver) MetadataGenerate(ctx context.Context, input models.GenerateMetadataInput) (string, error) {
-	manager.GetInstance().Generate(input)
-	return "todo", nil
+	jobID := manager.GetInstance().Generate(ctx, input)
+	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input models.AutoTagMetadataInput) (string, error) {
-	manager.GetInstance().AutoTag(input)
-	return "todo", nil
+	jobID := manager.GetInstance().AutoTag(ctx, input)
+	return strconv.Itoa(jobID), nil
 }
 
-func (r *mutationResolver) MetadataClean(ctx context.Context, input models.CleanMetadataInput) (string, error) {
-	manager.GetInstance().Clean(input)
-	return "todo", nil
-}
+func (r *mutationResolver) MetadataIdentify(ctx context.Context, input models.IdentifyMetadataInput) (string, error) {
+	jobID, err := manager.GetInstance().Identify(ctx, input)
+	if err != nil {
+		return "", err
+	}
 
-func (r *mutationResolver) MigrateHashNaming(ctx context.Context) (string, error) {
-	manager.GetInstance().MigrateHash()
-	return "todo", nil
+	return strconv.Itoa(jobID), nil
 }
 
-func (r *mutationResolver) JobStatus(ctx context.Context) (*models.MetadataUpdateStatus, error) {
-	status := manager.GetInstance().Status
-	ret := models.MetadataUpdateStatus{
-		Progress: status.Progress,
-		Status:   status.Status.String(),
-		Message:  "",
-	}
-
-	return &ret, nil
+func (r *mutationResolver) MetadataClean(ctx context.Context, input models.CleanMetadataInput) (string, error) {
+	jobID := manager.GetInstance().Clean(ctx, input)
+	return strconv.Itoa(jobID), nil
 }
 
-func (r *mutationResolver) StopJob(ctx context.Context) (bool, error) {
-	return manager.GetInstance().Status.Stop(), nil
+func (r *mutationResolver) MigrateHashNaming(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().MigrateHash(ctx)
+	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) BackupDatabase(ctx context.Context, input models.BackupDatabaseInput) (*string, error) {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -104,6 +105,10 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input models.MovieCr
 		return nil, err
 	}
 
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, movie.ID, plugin.MovieCreatePost, input, translator.getFields())
 	return movie, nil
 }
 
@@ -203,6 +208,7 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input models.MovieUp
 		return nil, err
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, movie.ID, plugin.MovieUpdatePost, input, translator.getFields())
 	return movie, nil
 }
 
@@ -217,6 +223,8 @@ func (r *mutationResolver) MovieDestroy(ctx context.Context, input models.MovieD
 	}); err != nil {
 		return false, err
 	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, id, plugin.MovieDestroyPost, input, nil)
 	return true, nil
 }
 
@@ -238,5 +246,10 @@ func (r *mutationResolver) MoviesDestroy(ctx context.Context, movieIDs []string)
 	}); err != nil {
 		return false, err
 	}
+
+	for _, id := range ids {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.MovieDestroyPost, movieIDs, nil)
+	}
+
 	return true, nil
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -120,6 +122,10 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 		return nil, err
 	}
 
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, performer.ID, plugin.PerformerCreatePost, input, translator.getFields())
 	return performer, nil
 }
 
@@ -221,6 +227,7 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		return nil, err
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, performer.ID, plugin.PerformerUpdatePost, input, translator.getFields())
 	return performer, nil
 }
 
@@ -279,6 +286,14 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input models
 	if err := r.withTxn(ctx, func(repo models.Repository) error {
 		qb := repo.Performer()
 
+		if input.SavedFilterID != nil {
+			filterPerformerIDs, err := savedfilter.FindPerformerIDs(repo.SavedFilter(), qb, *input.SavedFilterID)
+			if err != nil {
+				return err
+			}
+			performerIDs = utils.IntAppendUniques(performerIDs, filterPerformerIDs)
+		}
+
 		for _, performerID := range performerIDs {
 			updatedPerformer.ID = performerID
 
@@ -307,6 +322,11 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input models
 		return nil, err
 	}
 
+	// execute post hooks outside of txn
+	for _, performer := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, performer.ID, plugin.PerformerUpdatePost, input, translator.getFields())
+	}
+
 	return ret, nil
 }
 
@@ -321,6 +341,8 @@ func (r *mutationResolver) PerformerDestroy(ctx context.Context, input models.Pe
 	}); err != nil {
 		return false, err
 	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, id, plugin.PerformerDestroyPost, input, nil)
 	return true, nil
 }
 
@@ -342,5 +364,10 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 	}); err != nil {
 		return false, err
 	}
+
+	for _, id := range ids {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.PerformerDestroyPost, performerIDs, nil)
+	}
+
 	return true, nil
 }
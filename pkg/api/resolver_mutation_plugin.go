@@ -2,40 +2,81 @@ package api
 
 import (
 	"context"
-	"net/http"
+	"strconv"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/plugin/common"
 )
 
 func (r *mutationResolver) RunPluginTask(ctx context.Context, pluginID string, taskName string, args []*models.PluginArgInput) (string, error) {
-	currentUser := getCurrentUserID(ctx)
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		return plugin.ValidateSavedFilterArg(repo.SavedFilter(), args)
+	}); err != nil {
+		return "", err
+	}
 
-	var cookie *http.Cookie
-	var err error
-	if currentUser != nil {
-		cookie, err = createSessionCookie(*currentUser)
-		if err != nil {
-			return "", err
-		}
+	serverConnection, err := makePluginServerConnection(ctx)
+	if err != nil {
+		return "", err
 	}
 
+	jobID := manager.GetInstance().RunPluginTask(ctx, pluginID, taskName, args, serverConnection)
+	return strconv.Itoa(jobID), nil
+}
+
+func makePluginServerConnection(ctx context.Context) (common.StashServerConnection, error) {
 	serverConnection := common.StashServerConnection{
-		Scheme:        "http",
-		Port:          config.GetPort(),
-		SessionCookie: cookie,
-		Dir:           config.GetConfigPath(),
+		Scheme: "http",
+		Port:   config.GetPort(),
+		Dir:    config.GetConfigPath(),
 	}
 
 	if HasTLSConfig() {
 		serverConnection.Scheme = "https"
 	}
 
-	manager.GetInstance().RunPluginTask(pluginID, taskName, args, serverConnection)
-	return "todo", nil
+	currentUser := getCurrentUser(ctx)
+	if currentUser == nil && usersEnabled() {
+		// operations not initiated by a logged in user, such as scheduled
+		// tasks, are performed on behalf of an admin user
+		var err error
+		currentUser, err = findAdminUser(ctx)
+		if err != nil {
+			return serverConnection, err
+		}
+	}
+
+	userID := 0
+	if currentUser != nil {
+		userID = currentUser.ID
+	}
+
+	visitedHooks := plugin.VisitedHooks(ctx)
+	if userID != 0 || len(visitedHooks) > 0 {
+		cookie, err := createSessionCookie(userID, visitedHooks)
+		if err != nil {
+			return serverConnection, err
+		}
+		serverConnection.SessionCookie = cookie
+	}
+
+	return serverConnection, nil
+}
+
+// hookServerConnection returns the server connection details for plugin
+// hooks. Errors are logged, and the connection is returned without a session
+// cookie.
+func hookServerConnection(ctx context.Context) common.StashServerConnection {
+	serverConnection, err := makePluginServerConnection(ctx)
+	if err != nil {
+		logger.Errorf("Error creating plugin server connection: %s", err.Error())
+	}
+
+	return serverConnection
 }
 
 func (r *mutationResolver) ReloadPlugins(ctx context.Context) (bool, error) {
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/savedfilter"
+)
+
+func (r *mutationResolver) SaveFilter(ctx context.Context, input models.SaveFilterInput) (ret *models.SavedFilter, err error) {
+	if strings.TrimSpace(input.Name) == "" {
+		return nil, errors.New("name must not be empty")
+	}
+
+	if err := savedfilter.Validate(input.Mode, input.Filter); err != nil {
+		return nil, err
+	}
+
+	var id int
+	if input.ID != nil {
+		id, err = strconv.Atoi(*input.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newFilter := models.SavedFilter{
+		ID:     id,
+		Mode:   input.Mode,
+		Name:   input.Name,
+		Filter: input.Filter,
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.SavedFilter()
+
+		existing, err := qb.FindByMode(input.Mode)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range existing {
+			if f.Name == input.Name && f.ID != id {
+				return fmt.Errorf("a %s filter named %q already exists", input.Mode, input.Name)
+			}
+		}
+
+		if input.ID == nil {
+			ret, err = qb.Create(newFilter)
+			return err
+		}
+
+		found, err := qb.Find(id)
+		if err != nil {
+			return err
+		}
+
+		if found == nil {
+			return fmt.Errorf("saved filter with id %d not found", id)
+		}
+
+		ret, err = qb.Update(newFilter)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) DestroySavedFilter(ctx context.Context, input models.DestroyFilterInput) (bool, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.SavedFilter()
+
+		// default filters are not found, and are cleared using
+		// setDefaultFilter
+		found, err := qb.Find(id)
+		if err != nil {
+			return err
+		}
+
+		if found == nil {
+			return fmt.Errorf("saved filter with id %d not found", id)
+		}
+
+		return qb.Destroy(id)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SetDefaultFilter(ctx context.Context, input models.SetDefaultFilterInput) (bool, error) {
+	if input.Filter != nil {
+		if err := savedfilter.Validate(input.Mode, *input.Filter); err != nil {
+			return false, err
+		}
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.SavedFilter()
+
+		if input.Filter == nil {
+			// clear the default filter
+			existing, err := qb.FindDefault(input.Mode)
+			if err != nil || existing == nil {
+				return err
+			}
+
+			return qb.Destroy(existing.ID)
+		}
+
+		_, err := qb.SetDefault(models.SavedFilter{
+			Mode:   input.Mode,
+			Filter: *input.Filter,
+		})
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
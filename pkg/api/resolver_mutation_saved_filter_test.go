@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	savedFilterID   = 1
+	defaultFilterID = 2
+)
+
+func TestSavedFilterDefaultRows(t *testing.T) {
+	r := newResolver()
+
+	filterRW := r.txnManager.(*mocks.TransactionManager).SavedFilter().(*mocks.SavedFilterReaderWriter)
+	filterRW.On("Find", savedFilterID).Return(&models.SavedFilter{
+		ID:   savedFilterID,
+		Mode: models.FilterModeScenes,
+		Name: "saved",
+	}, nil)
+	// default filters are not returned by Find
+	filterRW.On("Find", defaultFilterID).Return(nil, nil)
+	filterRW.On("Destroy", savedFilterID).Return(nil).Once()
+
+	// default filters are not returned by findSavedFilter
+	ret, err := r.Query().FindSavedFilter(getTestContext(), "2")
+	assert.Nil(t, err)
+	assert.Nil(t, ret)
+
+	ret, err = r.Query().FindSavedFilter(getTestContext(), "1")
+	assert.Nil(t, err)
+	assert.NotNil(t, ret)
+
+	// default filters cannot be destroyed by destroySavedFilter
+	_, err = r.Mutation().DestroySavedFilter(getTestContext(), models.DestroyFilterInput{ID: "2"})
+	assert.NotNil(t, err)
+
+	_, err = r.Mutation().DestroySavedFilter(getTestContext(), models.DestroyFilterInput{ID: "1"})
+	assert.Nil(t, err)
+
+	filterRW.AssertExpectations(t)
+}
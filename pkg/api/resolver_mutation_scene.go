@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -10,6 +11,9 @@ import (
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/savedfilter"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -26,6 +30,7 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.SceneUpdatePost, input, translator.getFields())
 	return ret, nil
 }
 
@@ -52,6 +57,15 @@ func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.Sce
 		return nil, err
 	}
 
+	// execute post hooks outside of txn
+	for i, scene := range ret {
+		translator := changesetTranslator{
+			inputMap: inputMaps[i],
+		}
+
+		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, plugin.SceneUpdatePost, input[i], translator.getFields())
+	}
+
 	return ret, nil
 }
 
@@ -229,6 +243,14 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input models.Bul
 	if err := r.withTxn(ctx, func(repo models.Repository) error {
 		qb := repo.Scene()
 
+		if input.SavedFilterID != nil {
+			filterSceneIDs, err := savedfilter.FindSceneIDs(repo.SavedFilter(), qb, *input.SavedFilterID)
+			if err != nil {
+				return err
+			}
+			sceneIDs = utils.IntAppendUniques(sceneIDs, filterSceneIDs)
+		}
+
 		for _, sceneID := range sceneIDs {
 			updatedScene.ID = sceneID
 
@@ -281,6 +303,11 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input models.Bul
 		return nil, err
 	}
 
+	// execute post hooks outside of txn
+	for _, scene := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, plugin.SceneUpdatePost, input, translator.getFields())
+	}
+
 	return ret, nil
 }
 
@@ -359,6 +386,7 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 	}
 
 	var scene *models.Scene
+	var files []*models.File
 	var postCommitFunc func()
 	if err := r.withTxn(ctx, func(repo models.Repository) error {
 		qb := repo.Scene()
@@ -372,6 +400,11 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 			return fmt.Errorf("scene with id %d not found", sceneID)
 		}
 
+		files, err = repo.File().FindBySceneID(sceneID)
+		if err != nil {
+			return err
+		}
+
 		postCommitFunc, err = manager.DestroyScene(scene, repo)
 		return err
 	}); err != nil {
@@ -387,17 +420,20 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 		manager.DeleteGeneratedSceneFiles(scene, config.GetVideoFileNamingAlgorithm())
 	}
 
-	// if delete file is true, then delete the file as well
+	// if delete file is true, then delete the files as well
 	// if it fails, just log a message
 	if input.DeleteFile != nil && *input.DeleteFile {
-		manager.DeleteSceneFile(scene)
+		manager.DeleteSceneFiles(files)
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, scene.ID, plugin.SceneDestroyPost, input, nil)
+
 	return true, nil
 }
 
 func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.ScenesDestroyInput) (bool, error) {
 	var scenes []*models.Scene
+	sceneFiles := make(map[int][]*models.File)
 	var postCommitFuncs []func()
 	if err := r.withTxn(ctx, func(repo models.Repository) error {
 		qb := repo.Scene()
@@ -409,6 +445,12 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 			if scene != nil {
 				scenes = append(scenes, scene)
 			}
+
+			sceneFiles[sceneID], err = repo.File().FindBySceneID(sceneID)
+			if err != nil {
+				return err
+			}
+
 			f, err := manager.DestroyScene(scene, repo)
 			if err != nil {
 				return err
@@ -434,16 +476,109 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 			manager.DeleteGeneratedSceneFiles(scene, fileNamingAlgo)
 		}
 
-		// if delete file is true, then delete the file as well
+		// if delete file is true, then delete the files as well
 		// if it fails, just log a message
 		if input.DeleteFile != nil && *input.DeleteFile {
-			manager.DeleteSceneFile(scene)
+			manager.DeleteSceneFiles(sceneFiles[scene.ID])
 		}
+
+		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, plugin.SceneDestroyPost, input, nil)
 	}
 
 	return true, nil
 }
 
+func (r *mutationResolver) SceneMerge(ctx context.Context, source []string, destination string, values *models.SceneUpdateInput, deleteSourceFiles *bool) (*models.Scene, error) {
+	sourceIDs, err := utils.StringSliceToIntSlice(source)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationID, err := strconv.Atoi(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	if values == nil {
+		values = &models.SceneUpdateInput{}
+	}
+	values.ID = destination
+
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, "values"),
+	}
+
+	deleteFiles := deleteSourceFiles != nil && *deleteSourceFiles
+
+	var ret *models.Scene
+	var postCommitFunc func()
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		var err error
+		postCommitFunc, err = manager.MergeScenes(repo, sourceIDs, destinationID, deleteFiles)
+		if err != nil {
+			return err
+		}
+
+		ret, err = r.sceneUpdate(*values, translator, repo)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// perform the post-commit actions
+	postCommitFunc()
+
+	for _, id := range sourceIDs {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.SceneDestroyPost, nil, nil)
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.SceneUpdatePost, values, translator.getFields())
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneSetPrimaryFile(ctx context.Context, id string, fileID string) (*models.Scene, error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	primaryFileID, err := strconv.Atoi(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	fileNamingAlgo := config.GetVideoFileNamingAlgorithm()
+
+	var ret *models.Scene
+	var oldHash string
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.Scene()
+		s, err := qb.Find(sceneID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		oldHash = s.GetHash(fileNamingAlgo)
+		ret, err = scene.SetPrimaryFile(qb, repo.File(), sceneID, primaryFileID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	// generated files are named using the hash of the primary file
+	if newHash := ret.GetHash(fileNamingAlgo); newHash != "" && newHash != oldHash {
+		manager.MigrateHash(oldHash, newHash)
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.SceneUpdatePost, nil, nil)
+
+	return ret, nil
+}
+
 func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input models.SceneMarkerCreateInput) (*models.SceneMarker, error) {
 	primaryTagID, err := strconv.Atoi(input.PrimaryTagID)
 	if err != nil {
@@ -470,7 +605,16 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input models.S
 		return nil, err
 	}
 
-	return r.changeMarker(ctx, create, newSceneMarker, tagIDs)
+	ret, err := r.changeMarker(ctx, create, newSceneMarker, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.SceneMarkerCreatePost, input, translator.getFields())
+	return ret, nil
 }
 
 func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input models.SceneMarkerUpdateInput) (*models.SceneMarker, error) {
@@ -504,7 +648,16 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input models.S
 		return nil, err
 	}
 
-	return r.changeMarker(ctx, update, updatedSceneMarker, tagIDs)
+	ret, err := r.changeMarker(ctx, update, updatedSceneMarker, tagIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.SceneMarkerUpdatePost, input, translator.getFields())
+	return ret, nil
 }
 
 func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (bool, error) {
@@ -541,6 +694,8 @@ func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (b
 
 	postCommitFunc()
 
+	r.hookExecutor.ExecutePostHooks(ctx, markerID, plugin.SceneMarkerDestroyPost, id, nil)
+
 	return true, nil
 }
 
@@ -646,12 +801,54 @@ func (r *mutationResolver) SceneResetO(ctx context.Context, id string) (ret int,
 	return ret, nil
 }
 
+func (r *mutationResolver) SceneIncrementPlayCount(ctx context.Context, id string) (ret int, err error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.Scene()
+
+		ret, err = qb.IncrementPlayCount(sceneID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneSaveActivity(ctx context.Context, id string, resumeTime *float64, playDuration *float64) (bool, error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, err
+	}
+
+	if resumeTime != nil && *resumeTime < 0 {
+		return false, errors.New("resume_time must not be negative")
+	}
+
+	if playDuration != nil && *playDuration < 0 {
+		return false, errors.New("play_duration must not be negative")
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		return repo.Scene().SaveActivity(sceneID, resumeTime, playDuration)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
 func (r *mutationResolver) SceneGenerateScreenshot(ctx context.Context, id string, at *float64) (string, error) {
+	var jobID int
 	if at != nil {
-		manager.GetInstance().GenerateScreenshot(id, *at)
+		jobID = manager.GetInstance().GenerateScreenshot(ctx, id, *at)
 	} else {
-		manager.GetInstance().GenerateDefaultScreenshot(id)
+		jobID = manager.GetInstance().GenerateDefaultScreenshot(ctx, id)
 	}
 
-	return "todo", nil
+	return strconv.Itoa(jobID), nil
 }
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestSceneSaveActivityNegative(t *testing.T) {
+	r := newResolver()
+
+	sceneRW := r.txnManager.(*mocks.TransactionManager).Scene().(*mocks.SceneReaderWriter)
+
+	negative := -1.0
+
+	_, err := r.Mutation().SceneSaveActivity(getTestContext(), "1", &negative, nil)
+	assert.NotNil(t, err)
+
+	_, err = r.Mutation().SceneSaveActivity(getTestContext(), "1", nil, &negative)
+	assert.NotNil(t, err)
+
+	sceneRW.AssertNotCalled(t, "SaveActivity")
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *mutationResolver) ScheduledTaskCreate(ctx context.Context, input models.ScheduledTaskCreateInput) (*models.ScheduledTask, error) {
+	var newTask *models.ScheduledTask
+	if err := manager.GetInstance().UpdateScheduledTasks(func(tasks []*models.ScheduledTask) ([]*models.ScheduledTask, error) {
+		// assign the next available ID
+		id := 1
+		for _, t := range tasks {
+			if t.ID >= id {
+				id = t.ID + 1
+			}
+		}
+
+		newTask = &models.ScheduledTask{
+			ID:       id,
+			Name:     input.Name,
+			Schedule: input.Schedule,
+			Enabled:  input.Enabled == nil || *input.Enabled,
+			Type:     input.Type,
+			Scan:     input.Scan,
+			Generate: input.Generate,
+			AutoTag:  input.AutoTag,
+			Clean:    input.Clean,
+			Plugin:   input.Plugin,
+		}
+
+		if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+			return manager.ValidateScheduledTask(repo.SavedFilter(), newTask)
+		}); err != nil {
+			return nil, err
+		}
+
+		return append(tasks, newTask), nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return newTask, nil
+}
+
+func (r *mutationResolver) ScheduledTaskUpdate(ctx context.Context, input models.ScheduledTaskUpdateInput) (*models.ScheduledTask, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var task *models.ScheduledTask
+	if err := manager.GetInstance().UpdateScheduledTasks(func(tasks []*models.ScheduledTask) ([]*models.ScheduledTask, error) {
+		for _, t := range tasks {
+			if t.ID == id {
+				task = t
+				break
+			}
+		}
+
+		if task == nil {
+			return nil, fmt.Errorf("scheduled task with id %d not found", id)
+		}
+
+		if input.Name != nil {
+			task.Name = *input.Name
+		}
+		if input.Schedule != nil {
+			task.Schedule = *input.Schedule
+		}
+		if input.Enabled != nil {
+			task.Enabled = *input.Enabled
+		}
+		if input.Type != nil {
+			task.Type = *input.Type
+		}
+		if input.Scan != nil {
+			task.Scan = input.Scan
+		}
+		if input.Generate != nil {
+			task.Generate = input.Generate
+		}
+		if input.AutoTag != nil {
+			task.AutoTag = input.AutoTag
+		}
+		if input.Clean != nil {
+			task.Clean = input.Clean
+		}
+		if input.Plugin != nil {
+			task.Plugin = input.Plugin
+		}
+
+		if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+			return manager.ValidateScheduledTask(repo.SavedFilter(), task)
+		}); err != nil {
+			return nil, err
+		}
+
+		return tasks, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (r *mutationResolver) ScheduledTaskDestroy(ctx context.Context, input models.ScheduledTaskDestroyInput) (bool, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := manager.GetInstance().UpdateScheduledTasks(func(tasks []*models.ScheduledTask) ([]*models.ScheduledTask, error) {
+		var newTasks []*models.ScheduledTask
+		for _, t := range tasks {
+			if t.ID != id {
+				newTasks = append(newTasks, t)
+			}
+		}
+
+		if len(newTasks) == len(tasks) {
+			return nil, fmt.Errorf("scheduled task with id %d not found", id)
+		}
+
+		return newTasks, nil
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -15,3 +15,16 @@ func (r *mutationResolver) ReloadScrapers(ctx context.Context) (bool, error) {
 
 	return true, nil
 }
+
+func (r *mutationResolver) ClearScraperCache(ctx context.Context, scraperID *string) (bool, error) {
+	id := ""
+	if scraperID != nil {
+		id = *scraperID
+	}
+
+	if err := manager.GetInstance().ScraperCache.ClearResponseCache(id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
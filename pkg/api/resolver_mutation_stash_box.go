@@ -3,7 +3,9 @@ package api
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
@@ -20,3 +22,42 @@ func (r *mutationResolver) SubmitStashBoxFingerprints(ctx context.Context, input
 
 	return client.SubmitStashBoxFingerprints(input.SceneIds, boxes[input.StashBoxIndex].Endpoint)
 }
+
+func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input models.StashBoxDraftSubmissionInput) (*string, error) {
+	boxes := config.GetStashBoxes()
+
+	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+	}
+
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager)
+
+	return client.SubmitSceneDraft(id, boxes[input.StashBoxIndex].Endpoint)
+}
+
+func (r *mutationResolver) SubmitStashBoxPerformerDraft(ctx context.Context, input models.StashBoxDraftSubmissionInput) (*string, error) {
+	boxes := config.GetStashBoxes()
+
+	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+	}
+
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager)
+
+	return client.SubmitPerformerDraft(id, boxes[input.StashBoxIndex].Endpoint)
+}
+
+func (r *mutationResolver) StashBoxRefreshPerformers(ctx context.Context) (string, error) {
+	jobID := manager.GetInstance().StashBoxRefreshPerformers(ctx)
+	return strconv.Itoa(jobID), nil
+}
@@ -0,0 +1,15 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/manager"
+)
+
+func (r *mutationResolver) KillStreamSession(ctx context.Context, id string) (bool, error) {
+	if err := manager.KillStreamSession(id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
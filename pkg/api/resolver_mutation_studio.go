@@ -8,6 +8,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -73,6 +74,10 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 		return nil, err
 	}
 
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, studio.ID, plugin.StudioCreatePost, input, translator.getFields())
 	return studio, nil
 }
 
@@ -151,6 +156,7 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 		return nil, err
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, studio.ID, plugin.StudioUpdatePost, input, translator.getFields())
 	return studio, nil
 }
 
@@ -165,6 +171,8 @@ func (r *mutationResolver) StudioDestroy(ctx context.Context, input models.Studi
 	}); err != nil {
 		return false, err
 	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, id, plugin.StudioDestroyPost, input, nil)
 	return true, nil
 }
 
@@ -186,5 +194,10 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 	}); err != nil {
 		return false, err
 	}
+
+	for _, id := range ids {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.StudioDestroyPost, studioIDs, nil)
+	}
+
 	return true, nil
 }
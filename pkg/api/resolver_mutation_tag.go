@@ -8,6 +8,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -31,6 +32,16 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input models.TagCreate
 		}
 	}
 
+	parentIDs, err := utils.StringSliceToIntSlice(input.ParentIds)
+	if err != nil {
+		return nil, err
+	}
+
+	childIDs, err := utils.StringSliceToIntSlice(input.ChildIds)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the tag
 	var tag *models.Tag
 	if err := r.withTxn(ctx, func(repo models.Repository) error {
@@ -41,11 +52,36 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input models.TagCreate
 			return err
 		}
 
+		aliases := manager.CleanTagAliases(newTag.Name, input.Aliases)
+		if err := manager.EnsureAliasesUnique(0, aliases, qb); err != nil {
+			return err
+		}
+
 		tag, err = qb.Create(newTag)
 		if err != nil {
 			return err
 		}
 
+		if len(aliases) > 0 {
+			if err := qb.UpdateAliases(tag.ID, aliases); err != nil {
+				return err
+			}
+		}
+
+		if len(parentIDs) > 0 || len(childIDs) > 0 {
+			if err := manager.ValidateTagHierarchy(tag.ID, parentIDs, childIDs, qb); err != nil {
+				return err
+			}
+
+			if err := qb.UpdateParentTags(tag.ID, parentIDs); err != nil {
+				return err
+			}
+
+			if err := qb.UpdateChildTags(tag.ID, childIDs); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(tag.ID, imageData); err != nil {
@@ -58,6 +94,10 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input models.TagCreate
 		return nil, err
 	}
 
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, tag.ID, plugin.TagCreatePost, input, translator.getFields())
 	return tag, nil
 }
 
@@ -115,6 +155,23 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input models.TagUpdate
 			return err
 		}
 
+		if translator.hasField("aliases") {
+			aliases := manager.CleanTagAliases(updatedTag.Name, input.Aliases)
+			if err := manager.EnsureAliasesUnique(tagID, aliases, qb); err != nil {
+				return err
+			}
+
+			if err := qb.UpdateAliases(tagID, aliases); err != nil {
+				return err
+			}
+		}
+
+		if translator.hasField("parent_ids") || translator.hasField("child_ids") {
+			if err := r.updateTagHierarchy(qb, tagID, input, translator); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(tag.ID, imageData); err != nil {
@@ -132,9 +189,43 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input models.TagUpdate
 		return nil, err
 	}
 
+	r.hookExecutor.ExecutePostHooks(ctx, tag.ID, plugin.TagUpdatePost, input, translator.getFields())
 	return tag, nil
 }
 
+func (r *mutationResolver) updateTagHierarchy(qb models.TagReaderWriter, tagID int, input models.TagUpdateInput, translator changesetTranslator) error {
+	var parentIDs []int
+	var err error
+	if translator.hasField("parent_ids") {
+		parentIDs, err = utils.StringSliceToIntSlice(input.ParentIds)
+	} else {
+		parentIDs, err = qb.GetParentIDs(tagID)
+	}
+	if err != nil {
+		return err
+	}
+
+	var childIDs []int
+	if translator.hasField("child_ids") {
+		childIDs, err = utils.StringSliceToIntSlice(input.ChildIds)
+	} else {
+		childIDs, err = qb.GetChildIDs(tagID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := manager.ValidateTagHierarchy(tagID, parentIDs, childIDs, qb); err != nil {
+		return err
+	}
+
+	if err := qb.UpdateParentTags(tagID, parentIDs); err != nil {
+		return err
+	}
+
+	return qb.UpdateChildTags(tagID, childIDs)
+}
+
 func (r *mutationResolver) TagDestroy(ctx context.Context, input models.TagDestroyInput) (bool, error) {
 	tagID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -146,6 +237,8 @@ func (r *mutationResolver) TagDestroy(ctx context.Context, input models.TagDestr
 	}); err != nil {
 		return false, err
 	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, tagID, plugin.TagDestroyPost, input, nil)
 	return true, nil
 }
 
@@ -167,5 +260,45 @@ func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bo
 	}); err != nil {
 		return false, err
 	}
+
+	for _, id := range ids {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.TagDestroyPost, tagIDs, nil)
+	}
+
 	return true, nil
 }
+
+func (r *mutationResolver) TagsMerge(ctx context.Context, source []string, destination string) (*models.TagsMergeResult, error) {
+	sourceIDs, err := utils.StringSliceToIntSlice(source)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationID, err := strconv.Atoi(destination)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &models.TagsMergeResult{}
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.Tag()
+
+		var err error
+		ret.Counts, err = manager.MergeTags(qb, sourceIDs, destinationID)
+		if err != nil {
+			return err
+		}
+
+		ret.Tag, err = qb.Find(destinationID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, id := range sourceIDs {
+		r.hookExecutor.ExecutePostHooks(ctx, id, plugin.TagDestroyPost, nil, nil)
+	}
+	r.hookExecutor.ExecutePostHooks(ctx, destinationID, plugin.TagUpdatePost, nil, nil)
+
+	return ret, nil
+}
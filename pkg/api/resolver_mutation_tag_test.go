@@ -5,8 +5,13 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/plugin"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,10 +20,31 @@ import (
 // TODO - move this into a common area
 func newResolver() *Resolver {
 	return &Resolver{
-		txnManager: mocks.NewTransactionManager(),
+		txnManager:   mocks.NewTransactionManager(),
+		hookExecutor: &mockHookExecutor{},
+		jobManager:   job.NewManager(),
 	}
 }
 
+// getTestContext returns a context containing the minimum graphql request
+// details needed by mutation resolvers.
+func getTestContext() context.Context {
+	ctx := context.TODO()
+	ctx = graphql.WithOperationContext(ctx, &graphql.OperationContext{})
+	return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Field: graphql.CollectedField{
+			Field: &ast.Field{
+				Definition: &ast.FieldDefinition{},
+			},
+		},
+	})
+}
+
+type mockHookExecutor struct{}
+
+func (*mockHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string) {
+}
+
 const tagName = "tagName"
 const errTagName = "errTagName"
 
@@ -35,17 +61,18 @@ func TestTagCreate(t *testing.T) {
 		Name: existingTagName,
 	}, nil).Once()
 	tagRW.On("FindByName", errTagName, true).Return(nil, nil).Once()
+	tagRW.On("FindByAlias", errTagName, true).Return(nil, nil).Once()
 
 	expectedErr := errors.New("TagCreate error")
 	tagRW.On("Create", mock.AnythingOfType("models.Tag")).Return(nil, expectedErr)
 
-	_, err := r.Mutation().TagCreate(context.TODO(), models.TagCreateInput{
+	_, err := r.Mutation().TagCreate(getTestContext(), models.TagCreateInput{
 		Name: existingTagName,
 	})
 
 	assert.NotNil(t, err)
 
-	_, err = r.Mutation().TagCreate(context.TODO(), models.TagCreateInput{
+	_, err = r.Mutation().TagCreate(getTestContext(), models.TagCreateInput{
 		Name: errTagName,
 	})
 
@@ -56,15 +83,34 @@ func TestTagCreate(t *testing.T) {
 	tagRW = r.txnManager.(*mocks.TransactionManager).Tag().(*mocks.TagReaderWriter)
 
 	tagRW.On("FindByName", tagName, true).Return(nil, nil).Once()
+	tagRW.On("FindByAlias", tagName, true).Return(nil, nil).Once()
 	tagRW.On("Create", mock.AnythingOfType("models.Tag")).Return(&models.Tag{
 		ID:   newTagID,
 		Name: tagName,
 	}, nil)
 
-	tag, err := r.Mutation().TagCreate(context.TODO(), models.TagCreateInput{
+	tag, err := r.Mutation().TagCreate(getTestContext(), models.TagCreateInput{
 		Name: tagName,
 	})
 
 	assert.Nil(t, err)
 	assert.NotNil(t, tag)
 }
+
+func TestTagCreateExistingAlias(t *testing.T) {
+	r := newResolver()
+
+	tagRW := r.txnManager.(*mocks.TransactionManager).Tag().(*mocks.TagReaderWriter)
+	tagRW.On("FindByName", tagName, true).Return(nil, nil).Once()
+	tagRW.On("FindByAlias", tagName, true).Return(&models.Tag{
+		ID:   existingTagID,
+		Name: existingTagName,
+	}, nil).Once()
+
+	_, err := r.Mutation().TagCreate(getTestContext(), models.TagCreateInput{
+		Name: tagName,
+	})
+
+	assert.NotNil(t, err)
+	tagRW.AssertExpectations(t)
+}
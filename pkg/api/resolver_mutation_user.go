@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/manager"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/user"
+)
+
+func errConfigUser(u *models.User) error {
+	return fmt.Errorf("user %s is set in the configuration, and must be changed in the settings", u.Username)
+}
+
+// authorizeUserChange returns an error if the current user may not change
+// the user with the provided id. Users without the admin role may only
+// change themselves.
+func authorizeUserChange(ctx context.Context, id int) error {
+	if !isAdmin(ctx) && *getCurrentUserID(ctx) != id {
+		return errors.New("the ADMIN role is required to change other users")
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) UserCreate(ctx context.Context, input models.UserCreateInput) (*models.User, error) {
+	if input.Password == "" {
+		return nil, errors.New("password must not be empty")
+	}
+
+	passwordHash, err := user.HashPassword(input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	newUser := models.NewUser(input.Username, passwordHash, input.Role)
+
+	var ret *models.User
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.User()
+		if err := user.ValidateUsername(qb, input.Username, 0); err != nil {
+			return err
+		}
+
+		// the first user must be an admin
+		if err := user.ValidateAdminRemains(qb, 0, &input.Role); err != nil {
+			return err
+		}
+
+		ret, err = manager.CreateUser(repo, *newUser)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	manager.InvalidateUsersExist()
+
+	return ret, nil
+}
+
+func (r *mutationResolver) UserUpdate(ctx context.Context, input models.UserUpdateInput) (*models.User, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeUserChange(ctx, id); err != nil {
+		return nil, err
+	}
+
+	if !isAdmin(ctx) && (input.Username != nil || input.Role != nil) {
+		return nil, errors.New("the ADMIN role is required to change the username or role")
+	}
+
+	var ret *models.User
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.User()
+		existing, err := qb.Find(id)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if existing.Config {
+			return errConfigUser(existing)
+		}
+
+		if input.Username != nil && *input.Username != existing.Username {
+			if err := user.ValidateUsername(qb, *input.Username, id); err != nil {
+				return err
+			}
+
+			existing.Username = *input.Username
+
+			// API keys identify the user by username
+			existing.APIKey = sql.NullString{}
+		}
+
+		if input.Password != nil {
+			if *input.Password == "" {
+				return errors.New("password must not be empty")
+			}
+
+			existing.Password, err = user.HashPassword(*input.Password)
+			if err != nil {
+				return err
+			}
+		}
+
+		if input.Role != nil {
+			if err := user.ValidateAdminRemains(qb, id, input.Role); err != nil {
+				return err
+			}
+
+			existing.Role = *input.Role
+		}
+
+		existing.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+		ret, err = qb.Update(*existing)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) UserDestroy(ctx context.Context, input models.UserDestroyInput) (bool, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.User()
+		existing, err := qb.Find(id)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if existing.Config {
+			return errConfigUser(existing)
+		}
+
+		if err := user.ValidateAdminRemains(qb, id, nil); err != nil {
+			return err
+		}
+
+		return qb.Destroy(id)
+	}); err != nil {
+		return false, err
+	}
+	manager.InvalidateUsersExist()
+
+	return true, nil
+}
+
+func (r *mutationResolver) UserGenerateAPIKey(ctx context.Context, input models.UserGenerateAPIKeyInput) (string, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := authorizeUserChange(ctx, id); err != nil {
+		return "", err
+	}
+
+	var newAPIKey string
+	if err := r.withTxn(ctx, func(repo models.Repository) error {
+		qb := repo.User()
+		existing, err := qb.Find(id)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if existing.Config {
+			return errConfigUser(existing)
+		}
+
+		if input.Clear == nil || !*input.Clear {
+			newAPIKey, err = manager.GenerateAPIKey(existing.Username)
+			if err != nil {
+				return err
+			}
+		}
+
+		existing.APIKey = sql.NullString{
+			String: newAPIKey,
+			Valid:  newAPIKey != "",
+		}
+		existing.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+		_, err = qb.Update(*existing)
+		return err
+	}); err != nil {
+		return "", err
+	}
+
+	return newAPIKey, nil
+}
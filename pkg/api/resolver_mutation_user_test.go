@@ -0,0 +1,52 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/user"
+)
+
+func TestUserCreateFirstUser(t *testing.T) {
+	const (
+		username = "username"
+		password = "password"
+	)
+
+	r := newResolver()
+
+	userRW := r.txnManager.(*mocks.TransactionManager).User().(*mocks.UserReaderWriter)
+	sceneRW := r.txnManager.(*mocks.TransactionManager).Scene().(*mocks.SceneReaderWriter)
+	userRW.On("FindByUsername", username).Return(nil, nil)
+	userRW.On("All").Return(nil, nil)
+	userRW.On("Count").Return(0, nil).Once()
+	userRW.On("Create", mock.MatchedBy(func(u models.User) bool {
+		return u.Username == username && u.Role == models.UserRoleAdmin
+	})).Return(&models.User{ID: 1, Username: username, Role: models.UserRoleAdmin}, nil).Once()
+
+	// the play history without a user is given to the first user
+	sceneRW.On("AssignPlayHistory", 1).Return(nil).Once()
+
+	// the first user must be an admin
+	_, err := r.Mutation().UserCreate(getTestContext(), models.UserCreateInput{
+		Username: username,
+		Password: password,
+		Role:     models.UserRoleEditor,
+	})
+	assert.Equal(t, user.ErrNoAdmin, err)
+
+	created, err := r.Mutation().UserCreate(getTestContext(), models.UserCreateInput{
+		Username: username,
+		Password: password,
+		Role:     models.UserRoleAdmin,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, models.UserRoleAdmin, created.Role)
+
+	userRW.AssertExpectations(t)
+	sceneRW.AssertExpectations(t)
+}
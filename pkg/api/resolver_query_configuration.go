@@ -9,7 +9,30 @@ import (
 )
 
 func (r *queryResolver) Configuration(ctx context.Context) (*models.ConfigResult, error) {
-	return makeConfigResult(), nil
+	ret := makeConfigResult()
+
+	// only admins may see the configured credentials
+	if !isAdmin(ctx) {
+		redactConfigResult(ret)
+	}
+
+	return ret, nil
+}
+
+// redactConfigResult removes the credentials and API keys from the
+// configuration.
+func redactConfigResult(ret *models.ConfigResult) {
+	ret.General.APIKey = ""
+	ret.General.Username = ""
+	ret.General.Password = ""
+
+	var boxes []*models.StashBox
+	for _, box := range ret.General.StashBoxes {
+		redacted := *box
+		redacted.APIKey = ""
+		boxes = append(boxes, &redacted)
+	}
+	ret.General.StashBoxes = boxes
 }
 
 func (r *queryResolver) Directory(ctx context.Context, path *string) (*models.Directory, error) {
@@ -59,6 +82,9 @@ func makeConfigGeneralResult() *models.ConfigGeneralResult {
 		PreviewPreset:              config.GetPreviewPreset(),
 		MaxTranscodeSize:           &maxTranscodeSize,
 		MaxStreamingTranscodeSize:  &maxStreamingTranscodeSize,
+		MaxTranscodeSessions:       config.GetMaxTranscodeSessions(),
+		TranscodeIdleTimeout:       config.GetTranscodeIdleTimeout(),
+		StreamSegmentCacheSize:     config.GetStreamSegmentCacheSize(),
 		APIKey:                     config.GetAPIKey(),
 		Username:                   config.GetUsername(),
 		Password:                   config.GetPasswordHash(),
@@ -71,6 +97,7 @@ func makeConfigGeneralResult() *models.ConfigGeneralResult {
 		ImageExtensions:            config.GetImageExtensions(),
 		GalleryExtensions:          config.GetGalleryExtensions(),
 		CreateGalleriesFromFolders: config.GetCreateGalleriesFromFolders(),
+		WatchStashPaths:            config.GetWatchStashPaths(),
 		Excludes:                   config.GetExcludes(),
 		ImageExcludes:              config.GetImageExcludes(),
 		ScraperUserAgent:           &scraperUserAgent,
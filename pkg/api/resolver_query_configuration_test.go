@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func getUserContext(role models.UserRole) context.Context {
+	return models.WithCurrentUser(context.TODO(), models.NewUser("user", "", role))
+}
+
+func TestConfigurationNonAdmin(t *testing.T) {
+	config.Set(config.ApiKey, "apiKey")
+	config.Set(config.StashBoxes, []*models.StashBox{
+		{
+			Endpoint: "https://stashbox.example/graphql",
+			APIKey:   "stashBoxKey",
+			Name:     "stashbox",
+		},
+	})
+	defer config.Set(config.ApiKey, "")
+	defer config.Set(config.StashBoxes, nil)
+
+	r := newResolver()
+
+	ret, err := r.Query().Configuration(getUserContext(models.UserRoleAdmin))
+	assert.Nil(t, err)
+	assert.Equal(t, "apiKey", ret.General.APIKey)
+	if assert.Len(t, ret.General.StashBoxes, 1) {
+		assert.Equal(t, "stashBoxKey", ret.General.StashBoxes[0].APIKey)
+	}
+
+	for _, role := range []models.UserRole{models.UserRoleReadOnly, models.UserRoleEditor} {
+		ret, err := r.Query().Configuration(getUserContext(role))
+		assert.Nil(t, err)
+		assert.Equal(t, "", ret.General.APIKey)
+		assert.Equal(t, "", ret.General.Password)
+		if assert.Len(t, ret.General.StashBoxes, 1) {
+			box := ret.General.StashBoxes[0]
+			assert.Equal(t, "", box.APIKey)
+			// non-secret details are still returned
+			assert.Equal(t, "https://stashbox.example/graphql", box.Endpoint)
+		}
+	}
+}
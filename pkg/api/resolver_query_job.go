@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) JobQueue(ctx context.Context) ([]*models.Job, error) {
+	return jobsToJobModels(r.jobManager.GetQueue()), nil
+}
+
+func (r *queryResolver) JobHistory(ctx context.Context) ([]*models.Job, error) {
+	return jobsToJobModels(r.jobManager.GetHistory()), nil
+}
+
+func (r *queryResolver) FindJob(ctx context.Context, id string) (*models.Job, error) {
+	jobID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	j := r.jobManager.GetJob(jobID)
+	if j == nil {
+		return nil, nil
+	}
+
+	return jobToJobModel(*j), nil
+}
+
+func jobsToJobModels(jobs []job.Job) []*models.Job {
+	var ret []*models.Job
+	for _, j := range jobs {
+		ret = append(ret, jobToJobModel(j))
+	}
+
+	return ret
+}
+
+func jobToJobModel(j job.Job) *models.Job {
+	ret := &models.Job{
+		ID:          strconv.Itoa(j.ID),
+		Status:      models.JobStatus(j.Status),
+		Description: j.Description,
+		SubTasks:    j.Details,
+		StartTime:   j.StartTime,
+		EndTime:     j.EndTime,
+		AddTime:     j.AddTime,
+		Error:       j.Error,
+	}
+
+	if j.Progress != job.ProgressIndefinite {
+		p := j.Progress
+		ret.Progress = &p
+	}
+
+	return ret
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func waitJobHistory(t *testing.T, m *job.Manager, count int) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for len(m.GetHistory()) < count {
+		select {
+		case <-timeout:
+			t.Fatalf("timed out waiting for %d completed jobs", count)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestJobHistory(t *testing.T) {
+	r := newResolver()
+
+	ret, err := r.Query().JobHistory(context.TODO())
+	assert.Nil(t, err)
+	assert.Len(t, ret, 0)
+
+	const errMsg = "job error"
+	r.jobManager.Add(context.TODO(), "finished", job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		return nil
+	}))
+	r.jobManager.Add(context.TODO(), "failed", job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		return errors.New(errMsg)
+	}))
+	waitJobHistory(t, r.jobManager, 2)
+
+	ret, err = r.Query().JobHistory(context.TODO())
+	assert.Nil(t, err)
+	if assert.Len(t, ret, 2) {
+		assert.Equal(t, "finished", ret[0].Description)
+		assert.Equal(t, models.JobStatusFinished, ret[0].Status)
+		assert.Nil(t, ret[0].Error)
+
+		assert.Equal(t, "failed", ret[1].Description)
+		assert.Equal(t, models.JobStatusFailed, ret[1].Status)
+		if assert.NotNil(t, ret[1].Error) {
+			assert.Equal(t, errMsg, *ret[1].Error)
+		}
+	}
+
+	// completed jobs are not in the queue
+	queue, err := r.Query().JobQueue(context.TODO())
+	assert.Nil(t, err)
+	assert.Len(t, queue, 0)
+}
@@ -3,17 +3,31 @@ package api
 import (
 	"context"
 
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/models"
 )
 
 func (r *queryResolver) JobStatus(ctx context.Context) (*models.MetadataUpdateStatus, error) {
-	status := manager.GetInstance().Status
-	ret := models.MetadataUpdateStatus{
-		Progress: status.Progress,
-		Status:   status.Status.String(),
+	return getLegacyJobStatus(), nil
+}
+
+// getLegacyJobStatus returns the status of the first running job, for
+// clients that only support a single job at a time.
+func getLegacyJobStatus() *models.MetadataUpdateStatus {
+	ret := &models.MetadataUpdateStatus{
+		Progress: job.ProgressIndefinite,
+		Status:   "Idle",
 		Message:  "",
 	}
 
-	return &ret, nil
+	for _, j := range manager.GetInstance().JobManager.GetQueue() {
+		if j.Status == job.StatusRunning || j.Status == job.StatusStopping {
+			ret.Progress = j.Progress
+			ret.Status = j.Description
+			break
+		}
+	}
+
+	return ret
 }
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) ScheduledTasks(ctx context.Context) ([]*models.ScheduledTask, error) {
+	ret := config.GetScheduledTasks()
+	if ret == nil {
+		ret = []*models.ScheduledTask{}
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindScheduledTask(ctx context.Context, id string) (*models.ScheduledTask, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range config.GetScheduledTasks() {
+		if t.ID == idInt {
+			return t, nil
+		}
+	}
+
+	return nil, nil
+}
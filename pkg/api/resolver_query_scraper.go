@@ -52,6 +52,14 @@ func (r *queryResolver) ListMovieScrapers(ctx context.Context) ([]*models.Scrape
 	return manager.GetInstance().ScraperCache.ListMovieScrapers(), nil
 }
 
+func (r *queryResolver) ListScraperConfigErrors(ctx context.Context) ([]*models.ScraperConfigError, error) {
+	return manager.GetInstance().ScraperCache.ListConfigErrors(), nil
+}
+
+func (r *queryResolver) TestScraper(ctx context.Context, scraperID string, typeArg models.ScrapeContentType, input models.ScraperTestInput) (*models.ScraperTestResult, error) {
+	return manager.GetInstance().ScraperCache.TestScraper(scraperID, typeArg, input)
+}
+
 func (r *queryResolver) ScrapePerformerList(ctx context.Context, scraperID string, query string) ([]*models.ScrapedPerformer, error) {
 	if query == "" {
 		return nil, nil
@@ -68,6 +76,18 @@ func (r *queryResolver) ScrapePerformerURL(ctx context.Context, url string) (*mo
 	return manager.GetInstance().ScraperCache.ScrapePerformerURL(url)
 }
 
+func (r *queryResolver) ScrapeSceneQuery(ctx context.Context, scraperID string, query string) ([]*models.ScrapedScene, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	return manager.GetInstance().ScraperCache.ScrapeSceneQuery(scraperID, query)
+}
+
+func (r *queryResolver) ScrapeSceneQueryFragment(ctx context.Context, scraperID string, scene models.ScrapedSceneInput) (*models.ScrapedScene, error) {
+	return manager.GetInstance().ScraperCache.ScrapeSceneQueryFragment(scraperID, scene)
+}
+
 func (r *queryResolver) ScrapeScene(ctx context.Context, scraperID string, scene models.SceneUpdateInput) (*models.ScrapedScene, error) {
 	return manager.GetInstance().ScraperCache.ScrapeScene(scraperID, scene)
 }
@@ -76,6 +96,18 @@ func (r *queryResolver) ScrapeSceneURL(ctx context.Context, url string) (*models
 	return manager.GetInstance().ScraperCache.ScrapeSceneURL(url)
 }
 
+func (r *queryResolver) ScrapeGalleryList(ctx context.Context, scraperID string, query string) ([]*models.ScrapedGallery, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	return manager.GetInstance().ScraperCache.ScrapeGalleryList(scraperID, query)
+}
+
+func (r *queryResolver) ScrapeGalleryQueryFragment(ctx context.Context, scraperID string, gallery models.ScrapedGalleryInput) (*models.ScrapedGallery, error) {
+	return manager.GetInstance().ScraperCache.ScrapeGalleryQueryFragment(scraperID, gallery)
+}
+
 func (r *queryResolver) ScrapeGallery(ctx context.Context, scraperID string, gallery models.GalleryUpdateInput) (*models.ScrapedGallery, error) {
 	return manager.GetInstance().ScraperCache.ScrapeGallery(scraperID, gallery)
 }
@@ -84,6 +116,18 @@ func (r *queryResolver) ScrapeGalleryURL(ctx context.Context, url string) (*mode
 	return manager.GetInstance().ScraperCache.ScrapeGalleryURL(url)
 }
 
+func (r *queryResolver) ScrapeMovieList(ctx context.Context, scraperID string, query string) ([]*models.ScrapedMovie, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	return manager.GetInstance().ScraperCache.ScrapeMovieList(scraperID, query)
+}
+
+func (r *queryResolver) ScrapeMovie(ctx context.Context, scraperID string, scrapedMovie models.ScrapedMovieInput) (*models.ScrapedMovie, error) {
+	return manager.GetInstance().ScraperCache.ScrapeMovie(scraperID, scrapedMovie)
+}
+
 func (r *queryResolver) ScrapeMovieURL(ctx context.Context, url string) (*models.ScrapedMovie, error) {
 	return manager.GetInstance().ScraperCache.ScrapeMovieURL(url)
 }
@@ -107,3 +151,53 @@ func (r *queryResolver) QueryStashBoxScene(ctx context.Context, input models.Sta
 
 	return nil, nil
 }
+
+func (r *queryResolver) QueryStashBoxPerformer(ctx context.Context, input models.StashBoxPerformerQueryInput) ([]*models.ScrapedPerformer, error) {
+	boxes := config.GetStashBoxes()
+
+	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+	}
+
+	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager)
+
+	if input.StashID != nil {
+		performer, err := client.FindStashBoxPerformerByID(*input.StashID)
+		if err != nil || performer == nil {
+			return nil, err
+		}
+
+		return []*models.ScrapedPerformer{performer}, nil
+	}
+
+	if input.Q != nil {
+		return client.QueryStashBoxPerformer(*input.Q)
+	}
+
+	return nil, nil
+}
+
+func (r *queryResolver) QueryStashBoxStudio(ctx context.Context, input models.StashBoxStudioQueryInput) ([]*models.ScrapedSceneStudio, error) {
+	boxes := config.GetStashBoxes()
+
+	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+	}
+
+	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager)
+
+	if input.StashID != nil {
+		studio, err := client.FindStashBoxStudioByID(*input.StashID)
+		if err != nil || studio == nil {
+			return nil, err
+		}
+
+		return []*models.ScrapedSceneStudio{studio}, nil
+	}
+
+	if input.Q != nil {
+		return client.QueryStashBoxStudio(*input.Q)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,12 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) StreamSessions(ctx context.Context) ([]*models.StreamSession, error) {
+	return manager.ListStreamSessions(), nil
+}
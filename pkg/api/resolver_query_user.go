@@ -0,0 +1,44 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) CurrentUser(ctx context.Context) (*models.User, error) {
+	return getCurrentUser(ctx), nil
+}
+
+func (r *queryResolver) Users(ctx context.Context) (ret []*models.User, err error) {
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.User().All()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindUser(ctx context.Context, id string) (ret *models.User, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin(ctx) && *getCurrentUserID(ctx) != idInt {
+		return nil, errors.New("findUser requires the ADMIN role to find other users")
+	}
+
+	if err := r.withReadTxn(ctx, func(repo models.ReaderRepository) error {
+		ret, err = repo.User().Find(idInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func makeJobStatusUpdate(t models.JobStatusUpdateType, j job.Job) *models.JobStatusUpdate {
+	return &models.JobStatusUpdate{
+		Type: t,
+		Job:  jobToJobModel(j),
+	}
+}
+
+func (r *subscriptionResolver) JobsSubscribe(ctx context.Context) (<-chan *models.JobStatusUpdate, error) {
+	msg := make(chan *models.JobStatusUpdate, 100)
+
+	subscription := r.jobManager.Subscribe(ctx)
+
+	go func() {
+		defer close(msg)
+
+		// send returns false if the subscription has ended
+		send := func(t models.JobStatusUpdateType, j job.Job) bool {
+			select {
+			case msg <- makeJobStatusUpdate(t, j):
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			select {
+			case j, ok := <-subscription.NewJob:
+				if !ok || !send(models.JobStatusUpdateTypeAdd, j) {
+					return
+				}
+			case j, ok := <-subscription.RemovedJob:
+				if !ok || !send(models.JobStatusUpdateTypeRemove, j) {
+					return
+				}
+			case j, ok := <-subscription.UpdatedJob:
+				if !ok || !send(models.JobStatusUpdateTypeUpdate, j) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return msg, nil
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -14,20 +13,15 @@ func (r *subscriptionResolver) MetadataUpdate(ctx context.Context) (<-chan *mode
 	ticker := time.NewTicker(5 * time.Second)
 
 	go func() {
-		lastStatus := manager.TaskStatus{}
+		lastStatus := models.MetadataUpdateStatus{}
 		for {
 			select {
 			case _ = <-ticker.C:
-				thisStatus := manager.GetInstance().Status
-				if thisStatus != lastStatus {
-					ret := models.MetadataUpdateStatus{
-						Progress: thisStatus.Progress,
-						Status:   thisStatus.Status.String(),
-						Message:  "",
-					}
-					msg <- &ret
+				thisStatus := getLegacyJobStatus()
+				if *thisStatus != lastStatus {
+					msg <- thisStatus
 				}
-				lastStatus = thisStatus
+				lastStatus = *thisStatus
 			case <-ctx.Done():
 				ticker.Stop()
 				close(msg)
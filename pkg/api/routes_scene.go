@@ -30,8 +30,11 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/stream.mkv", rs.StreamMKV)
 		r.Get("/stream.webm", rs.StreamWebM)
 		r.Get("/stream.m3u8", rs.StreamHLS)
+		r.Get("/stream.mpd", rs.StreamDASH)
 		r.Get("/stream.ts", rs.StreamTS)
 		r.Get("/stream.mp4", rs.StreamMp4)
+		r.Get("/segments/{resolution}/index.m3u8", rs.StreamHLSRendition)
+		r.Get("/segments/{resolution}/{segment}", rs.StreamSegment)
 
 		r.Get("/screenshot", rs.Screenshot)
 		r.Get("/preview", rs.Preview)
@@ -88,17 +91,21 @@ func (rs sceneRoutes) StreamMKV(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	rs.streamTranscode(w, r, ffmpeg.CodecMKVAudio)
+	rs.streamTranscode(w, r, ffmpeg.CodecMKVAudio, "mkv")
 }
 
 func (rs sceneRoutes) StreamWebM(w http.ResponseWriter, r *http.Request) {
-	rs.streamTranscode(w, r, ffmpeg.CodecVP9)
+	rs.streamTranscode(w, r, ffmpeg.CodecVP9, "webm")
 }
 
 func (rs sceneRoutes) StreamMp4(w http.ResponseWriter, r *http.Request) {
-	rs.streamTranscode(w, r, ffmpeg.CodecH264)
+	rs.streamTranscode(w, r, ffmpeg.CodecH264, "mp4")
 }
 
+// streamSegmentsURL is the URL of the stream segments, relative to the HLS
+// master playlist and DASH manifest.
+const streamSegmentsURL = "segments"
+
 func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -110,29 +117,129 @@ func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 
 	logger.Debug("Returning HLS playlist")
 
-	// getting the playlist manifest only
-	w.Header().Set("Content-Type", ffmpeg.MimeHLS)
+	renditions := ffmpeg.GetStreamRenditions(*videoFile, config.GetMaxStreamingTranscodeSize())
+
+	var str strings.Builder
+	ffmpeg.WriteHLSMasterPlaylist(*videoFile, renditions, manager.StreamVideoOnly(scene), streamSegmentsURL, r.URL.RawQuery, &str)
+
+	serveStreamManifest(w, r, ffmpeg.MimeHLS, str.String())
+}
+
+func (rs sceneRoutes) StreamDASH(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	videoFile, err := ffmpeg.NewVideoFile(manager.GetInstance().FFProbePath, scene.Path, false)
+	if err != nil {
+		logger.Errorf("[stream] error reading video file: %s", err.Error())
+		return
+	}
+
+	logger.Debug("Returning DASH manifest")
+
+	renditions := ffmpeg.GetStreamRenditions(*videoFile, config.GetMaxStreamingTranscodeSize())
+
 	var str strings.Builder
+	ffmpeg.WriteDASHManifest(*videoFile, renditions, manager.StreamVideoOnly(scene), streamSegmentsURL, r.URL.RawQuery, &str)
+
+	serveStreamManifest(w, r, ffmpeg.MimeDASH, str.String())
+}
 
-	ffmpeg.WriteHLSPlaylist(*videoFile, r.URL.String(), &str)
+func (rs sceneRoutes) StreamTS(w http.ResponseWriter, r *http.Request) {
+	rs.streamTranscode(w, r, ffmpeg.CodecHLS, "ts")
+}
+
+func (rs sceneRoutes) StreamHLSRendition(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	if _, ok := getStreamRendition(w, r); !ok {
+		return
+	}
+
+	videoFile, err := ffmpeg.NewVideoFile(manager.GetInstance().FFProbePath, scene.Path, false)
+	if err != nil {
+		logger.Errorf("[stream] error reading video file: %s", err.Error())
+		return
+	}
+
+	var str strings.Builder
+	ffmpeg.WriteHLSPlaylist(*videoFile, r.URL.RawQuery, &str)
+
+	serveStreamManifest(w, r, ffmpeg.MimeHLS, str.String())
+}
+
+func (rs sceneRoutes) StreamSegment(w http.ResponseWriter, r *http.Request) {
+	scene := r.Context().Value(sceneKey).(*models.Scene)
+
+	rendition, ok := getStreamRendition(w, r)
+	if !ok {
+		return
+	}
+
+	segmentName := chi.URLParam(r, "segment")
+	segment := -1
+	if segmentName != ffmpeg.StreamInitSegment {
+		var err error
+		segment, err = strconv.Atoi(strings.TrimSuffix(segmentName, ".m4s"))
+		if err != nil || !strings.HasSuffix(segmentName, ".m4s") {
+			http.Error(w, "invalid segment", http.StatusNotFound)
+			return
+		}
+	}
+
+	videoFile, err := ffmpeg.NewVideoFile(manager.GetInstance().FFProbePath, scene.Path, false)
+	if err != nil {
+		logger.Errorf("[stream] error reading video file: %s", err.Error())
+		return
+	}
+
+	var fn string
+	if segment == -1 {
+		fn, err = manager.GetStreamInitSegment(r, scene, *videoFile, rendition)
+	} else {
+		fn, err = manager.GetStreamSegment(r, scene, *videoFile, rendition, segment)
+	}
+
+	if err == manager.ErrTooManyStreamSessions {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	if err != nil {
+		logger.Errorf("[stream] error getting segment %s: %s", segmentName, err.Error())
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", ffmpeg.MimeMp4)
+	http.ServeFile(w, r, fn)
+}
+
+func getStreamRendition(w http.ResponseWriter, r *http.Request) (ffmpeg.StreamRendition, bool) {
+	resolution := models.StreamingResolutionEnum(chi.URLParam(r, "resolution"))
+	rendition, ok := ffmpeg.GetStreamRendition(resolution)
+	if !ok {
+		http.Error(w, "invalid resolution", http.StatusNotFound)
+	}
+
+	return rendition, ok
+}
+
+func serveStreamManifest(w http.ResponseWriter, r *http.Request, mimeType string, manifest string) {
+	w.Header().Set("Content-Type", mimeType)
 
 	requestByteRange := utils.CreateByteRange(r.Header.Get("Range"))
 	if requestByteRange.RawString != "" {
 		logger.Debugf("Requested range: %s", requestByteRange.RawString)
 	}
 
-	ret := requestByteRange.Apply([]byte(str.String()))
-	rangeStr := requestByteRange.ToHeaderValue(int64(str.Len()))
+	ret := requestByteRange.Apply([]byte(manifest))
+	rangeStr := requestByteRange.ToHeaderValue(int64(len(manifest)))
 	w.Header().Set("Content-Range", rangeStr)
 
 	w.Write(ret)
 }
 
-func (rs sceneRoutes) StreamTS(w http.ResponseWriter, r *http.Request) {
-	rs.streamTranscode(w, r, ffmpeg.CodecHLS)
-}
-
-func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, videoCodec ffmpeg.Codec) {
+func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, videoCodec ffmpeg.Codec, format string) {
 	logger.Debugf("Streaming as %s", videoCodec.MimeType)
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 
@@ -149,8 +256,6 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, vi
 	startTime := r.Form.Get("start")
 	requestedSize := r.Form.Get("resolution")
 
-	var stream *ffmpeg.Stream
-
 	audioCodec := ffmpeg.MissingUnsupported
 	if scene.AudioCodec.Valid {
 		audioCodec = ffmpeg.AudioCodec(scene.AudioCodec.String)
@@ -163,8 +268,12 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, vi
 		options.MaxTranscodeSize = models.StreamingResolutionEnum(requestedSize)
 	}
 
-	encoder := ffmpeg.NewEncoder(manager.GetInstance().FFMPEGPath)
-	stream, err = encoder.GetTranscodeStream(options)
+	err = manager.ServeLiveTranscode(w, r, scene, format, options)
+
+	if err == manager.ErrTooManyStreamSessions {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
 
 	if err != nil {
 		logger.Errorf("[stream] error transcoding video file: %s", err.Error())
@@ -172,8 +281,6 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, vi
 		w.Write([]byte(err.Error()))
 		return
 	}
-
-	stream.Serve(w, r)
 }
 
 func (rs sceneRoutes) Screenshot(w http.ResponseWriter, r *http.Request) {
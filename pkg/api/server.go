@@ -28,6 +28,7 @@ import (
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/manager/paths"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -53,24 +54,41 @@ func authenticateHandler() func(http.Handler) http.Handler {
 			ctx := r.Context()
 
 			// translate api key into current user, if present
-			userID := ""
+			userID := 0
+			username := ""
 			apiKey := r.Header.Get(ApiKeyHeader)
 			var err error
 
 			if apiKey != "" {
-				// match against configured API and set userID to the
-				// configured username. In future, we'll want to
-				// get the username from the key.
-				if config.GetAPIKey() != apiKey {
+				username, err = getAPIKeyUsername(apiKey)
+				if err != nil {
 					w.Header().Add("WWW-Authenticate", `FormBased`)
 					w.WriteHeader(http.StatusUnauthorized)
 					return
 				}
-
-				userID = config.GetUsername()
 			} else {
 				// handle session
-				userID, err = getSessionUserID(w, r)
+				userID, username, err = getSessionUser(w, r)
+			}
+
+			var currentUser *models.User
+			if err == nil && apiKey != "" && usersEnabled() {
+				currentUser, err = findUser(ctx, username)
+
+				// api keys must match the key stored for the user, so
+				// that they can be cleared
+				if err == nil && (currentUser == nil || currentUser.APIKey.String != apiKey) {
+					w.Header().Add("WWW-Authenticate", `FormBased`)
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+			} else if err == nil && userID != 0 && usersEnabled() {
+				currentUser, err = findUserByID(ctx, userID)
+			}
+
+			var required bool
+			if err == nil {
+				required, err = authRequired(ctx)
 			}
 
 			if err != nil {
@@ -79,8 +97,10 @@ func authenticateHandler() func(http.Handler) http.Handler {
 				return
 			}
 
+			authenticated := currentUser != nil || (!usersEnabled() && username != "")
+
 			// handle redirect if no user and user is required
-			if userID == "" && config.HasCredentials() && !allowUnauthenticated(r) {
+			if !authenticated && required && !allowUnauthenticated(r) {
 				// if we don't have a userID, then redirect
 				// if graphql was requested, we just return a forbidden error
 				if r.URL.Path == "/graphql" {
@@ -100,7 +120,10 @@ func authenticateHandler() func(http.Handler) http.Handler {
 				return
 			}
 
-			ctx = context.WithValue(ctx, ContextUser, userID)
+			ctx = models.WithCurrentUser(ctx, currentUser)
+
+			// requests made by plugin hooks must not trigger the same hooks
+			ctx = plugin.WithVisitedHooks(ctx, getSessionVisitedHooks(r))
 
 			r = r.WithContext(ctx)
 
@@ -109,6 +132,22 @@ func authenticateHandler() func(http.Handler) http.Handler {
 	}
 }
 
+// getAPIKeyUsername returns the username of the user that the API key was
+// generated for.
+func getAPIKeyUsername(apiKey string) (string, error) {
+	if !usersEnabled() {
+		// match against the configured API key until the database has
+		// been migrated
+		if config.GetAPIKey() != apiKey {
+			return "", manager.ErrInvalidToken
+		}
+
+		return config.GetUsername(), nil
+	}
+
+	return manager.GetUserIDFromAPIKey(apiKey)
+}
+
 const setupEndPoint = "/setup"
 const migrateEndPoint = "/migrate"
 const loginEndPoint = "/login"
@@ -154,13 +193,19 @@ func Start() {
 	websocketKeepAliveDuration := handler.WebsocketKeepAliveDuration(10 * time.Second)
 
 	txnManager := manager.GetInstance().TxnManager
+	pluginCache := manager.GetInstance().PluginCache
+	pluginCache.RegisterServerConnectionFunc(hookServerConnection)
 	resolver := &Resolver{
-		txnManager: txnManager,
+		txnManager:   txnManager,
+		hookExecutor: pluginCache,
+		jobManager:   manager.GetInstance().JobManager,
 	}
 
-	gqlHandler := handler.GraphQL(models.NewExecutableSchema(models.Config{Resolvers: resolver}), recoverFunc, websocketUpgrader, websocketKeepAliveDuration, maxUploadSize)
+	roleMiddleware := handler.ResolverMiddleware(authorizeField)
+
+	gqlHandler := handler.GraphQL(models.NewExecutableSchema(models.Config{Resolvers: resolver}), recoverFunc, websocketUpgrader, websocketKeepAliveDuration, maxUploadSize, roleMiddleware)
 
-	r.Handle("/graphql", gqlHandler)
+	r.Handle("/graphql", PlayHistoryLoaderMiddleware(gqlHandler))
 	r.Handle("/playground", handler.Playground("GraphQL playground", "/graphql"))
 
 	// session handlers
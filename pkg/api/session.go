@@ -6,7 +6,11 @@ import (
 	"html/template"
 	"net/http"
 
+	"github.com/stashapp/stash/pkg/database"
+	"github.com/stashapp/stash/pkg/manager"
 	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/user"
 
 	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
@@ -17,6 +21,11 @@ const usernameFormKey = "username"
 const passwordFormKey = "password"
 const userIDKey = "userID"
 
+// usernameKey stores the username of the configured credentials until the
+// database has been migrated
+const usernameKey = "username"
+const visitedHooksKey = "visitedPluginHooks"
+
 const returnURLParam = "returnURL"
 
 var sessionStore = sessions.NewCookieStore(config.GetSessionStoreKey())
@@ -44,8 +53,92 @@ func redirectToLogin(w http.ResponseWriter, returnURL string, loginError string)
 	}
 }
 
+// usersEnabled returns true if users are stored in the database. Until the
+// database has been migrated, the configured credentials are used instead.
+func usersEnabled() bool {
+	return database.DB != nil && !database.NeedsMigration()
+}
+
+// authRequired returns true if requests must be made by a logged in user.
+func authRequired(ctx context.Context) (bool, error) {
+	if !usersEnabled() {
+		return config.HasCredentials(), nil
+	}
+
+	return manager.UsersExist(manager.GetInstance().TxnManager)
+}
+
+func findUser(ctx context.Context, username string) (*models.User, error) {
+	var ret *models.User
+	err := manager.GetInstance().TxnManager.WithReadTxn(ctx, func(repo models.ReaderRepository) error {
+		var err error
+		ret, err = repo.User().FindByUsername(username)
+		return err
+	})
+
+	return ret, err
+}
+
+func findUserByID(ctx context.Context, id int) (*models.User, error) {
+	var ret *models.User
+	err := manager.GetInstance().TxnManager.WithReadTxn(ctx, func(repo models.ReaderRepository) error {
+		var err error
+		ret, err = repo.User().Find(id)
+		return err
+	})
+
+	return ret, err
+}
+
+// findAdminUser returns a user with the admin role, preferring the user
+// added from the configured credentials. Returns nil if there are no users.
+func findAdminUser(ctx context.Context) (*models.User, error) {
+	var ret *models.User
+	err := manager.GetInstance().TxnManager.WithReadTxn(ctx, func(repo models.ReaderRepository) error {
+		users, err := repo.User().All()
+		if err != nil {
+			return err
+		}
+
+		for _, u := range users {
+			if u.Role == models.UserRoleAdmin && (ret == nil || u.Config) {
+				ret = u
+			}
+		}
+
+		return nil
+	})
+
+	return ret, err
+}
+
+// validateCredentials returns true if the username and password are those
+// of a user. The user is returned if users are stored in the database.
+func validateCredentials(ctx context.Context, username string, password string) (*models.User, bool, error) {
+	if !usersEnabled() {
+		return nil, config.ValidateCredentials(username, password), nil
+	}
+
+	u, err := findUser(ctx, username)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if u == nil || !user.ValidatePassword(u, password) {
+		return nil, false, nil
+	}
+
+	return u, true, nil
+}
+
 func getLoginHandler(w http.ResponseWriter, r *http.Request) {
-	if !config.HasCredentials() {
+	required, err := authRequired(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !required {
 		http.Redirect(w, r, "/", http.StatusFound)
 		return
 	}
@@ -66,15 +159,25 @@ func handleLogin(w http.ResponseWriter, r *http.Request) {
 	password := r.FormValue("password")
 
 	// authenticate the user
-	if !config.ValidateCredentials(username, password) {
+	u, valid, err := validateCredentials(r.Context(), username, password)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !valid {
 		// redirect back to the login page with an error
 		redirectToLogin(w, url, "Username or password is invalid")
 		return
 	}
 
-	newSession.Values[userIDKey] = username
+	if u != nil {
+		newSession.Values[userIDKey] = u.ID
+	} else {
+		newSession.Values[usernameKey] = username
+	}
 
-	err := newSession.Save(r, w)
+	err = newSession.Save(r, w)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -91,6 +194,7 @@ func handleLogout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	delete(session.Values, userIDKey)
+	delete(session.Values, usernameKey)
 	session.Options.MaxAge = -1
 
 	err = session.Save(r, w)
@@ -103,44 +207,66 @@ func handleLogout(w http.ResponseWriter, r *http.Request) {
 	getLoginHandler(w, r)
 }
 
-func getSessionUserID(w http.ResponseWriter, r *http.Request) (string, error) {
+// getSessionUser returns the ID of the user of the session, or the username
+// of the configured credentials if the session was created before the
+// database was migrated.
+func getSessionUser(w http.ResponseWriter, r *http.Request) (int, string, error) {
 	session, err := sessionStore.Get(r, cookieName)
 	// ignore errors and treat as an empty user id, so that we handle expired
 	// cookie
 	if err != nil {
-		return "", nil
+		return 0, "", nil
 	}
 
 	if !session.IsNew {
-		val := session.Values[userIDKey]
+		userID, _ := session.Values[userIDKey].(int)
+		username, _ := session.Values[usernameKey].(string)
 
 		// refresh the cookie
 		err = session.Save(r, w)
 		if err != nil {
-			return "", err
+			return 0, "", err
 		}
 
-		ret, _ := val.(string)
-
-		return ret, nil
+		return userID, username, nil
 	}
 
-	return "", nil
+	return 0, "", nil
+}
+
+// getCurrentUser returns the user that made the request. Returns nil if
+// authentication is not required, or if the database has not been migrated.
+func getCurrentUser(ctx context.Context) *models.User {
+	return models.CurrentUser(ctx)
 }
 
-func getCurrentUserID(ctx context.Context) *string {
-	userCtxVal := ctx.Value(ContextUser)
-	if userCtxVal != nil {
-		currentUser := userCtxVal.(string)
-		return &currentUser
+// getSessionVisitedHooks returns the plugin hooks that created the session
+// of the request. Requests made by plugin hooks carry the hooks that
+// triggered them, so that the hooks are not triggered again.
+func getSessionVisitedHooks(r *http.Request) []string {
+	session, err := sessionStore.Get(r, cookieName)
+	if err != nil || session.IsNew {
+		return nil
 	}
 
-	return nil
+	ret, _ := session.Values[visitedHooksKey].([]string)
+	return ret
+}
+
+// getCurrentUserID returns the ID of the user that made the request, or nil
+// if there is no current user.
+func getCurrentUserID(ctx context.Context) *int {
+	return models.CurrentUserID(ctx)
 }
 
-func createSessionCookie(username string) (*http.Cookie, error) {
+func createSessionCookie(userID int, visitedHooks []string) (*http.Cookie, error) {
 	session := sessions.NewSession(sessionStore, cookieName)
-	session.Values[userIDKey] = username
+	if userID != 0 {
+		session.Values[userIDKey] = userID
+	}
+	if len(visitedHooks) > 0 {
+		session.Values[visitedHooksKey] = visitedHooks
+	}
 
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values,
 		sessionStore.Codecs...)
@@ -0,0 +1,67 @@
+CREATE TABLE `files` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer not null,
+  `is_primary` boolean not null default '0',
+  `path` varchar(510) not null,
+  `checksum` varchar(255),
+  `oshash` varchar(255),
+  `size` varchar(255),
+  `duration` float,
+  `video_codec` varchar(255),
+  `format` varchar(255),
+  `audio_codec` varchar(255),
+  `width` tinyint,
+  `height` tinyint,
+  `framerate` float,
+  `bitrate` integer,
+  `file_mod_time` datetime,
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE UNIQUE INDEX `index_files_on_path` on `files` (`path`);
+CREATE INDEX `index_files_on_scene_id` on `files` (`scene_id`);
+CREATE INDEX `index_files_on_checksum` on `files` (`checksum`);
+CREATE INDEX `index_files_on_oshash` on `files` (`oshash`);
+
+-- the existing file of each scene is its primary file
+INSERT INTO `files`
+  (
+    `scene_id`,
+    `is_primary`,
+    `path`,
+    `checksum`,
+    `oshash`,
+    `size`,
+    `duration`,
+    `video_codec`,
+    `format`,
+    `audio_codec`,
+    `width`,
+    `height`,
+    `framerate`,
+    `bitrate`,
+    `file_mod_time`,
+    `created_at`,
+    `updated_at`
+  )
+  SELECT
+    `id`,
+    1,
+    `path`,
+    `checksum`,
+    `oshash`,
+    `size`,
+    `duration`,
+    `video_codec`,
+    `format`,
+    `audio_codec`,
+    `width`,
+    `height`,
+    `framerate`,
+    `bitrate`,
+    `file_mod_time`,
+    `created_at`,
+    `updated_at`
+  FROM `scenes`;
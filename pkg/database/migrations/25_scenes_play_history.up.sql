@@ -0,0 +1,17 @@
+CREATE TABLE `scenes_play_history` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer not null,
+  `user_id` integer,
+  `play_count` integer not null default 0,
+  `last_played_at` datetime,
+  `resume_time` float not null default 0,
+  `play_duration` float not null default 0,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE
+);
+
+-- the play history of each scene is unique per user, including the user-less
+-- history recorded while authentication is disabled
+CREATE UNIQUE INDEX `index_scenes_play_history_on_scene_id_user_id` on `scenes_play_history` (`scene_id`, coalesce(`user_id`, 0));
+CREATE INDEX `index_scenes_play_history_on_user_id` on `scenes_play_history` (`user_id`);
+CREATE INDEX `index_scenes_play_history_on_last_played_at` on `scenes_play_history` (`last_played_at`);
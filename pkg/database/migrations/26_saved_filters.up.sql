@@ -0,0 +1,10 @@
+CREATE TABLE `saved_filters` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(510) not null,
+  `mode` varchar(255) not null,
+  `filter` blob not null,
+  `is_default` boolean not null default '0'
+);
+
+CREATE UNIQUE INDEX `index_saved_filters_on_mode_name_unique` on `saved_filters` (`mode`, `name`) WHERE `is_default` = 0;
+CREATE UNIQUE INDEX `index_saved_filters_on_mode_default_unique` on `saved_filters` (`mode`) WHERE `is_default` = 1;
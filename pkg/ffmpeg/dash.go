@@ -0,0 +1,36 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"html"
+	"io"
+)
+
+// WriteDASHManifest writes a DASH manifest containing a representation for
+// each rendition. The representations use the same segments as the HLS
+// stream, located at <segmentsURL>/<resolution>/. query is appended to each
+// URL if it is not empty.
+func WriteDASHManifest(probeResult VideoFile, renditions []StreamRendition, videoOnly bool, segmentsURL string, query string, w io.Writer) {
+	duration := fmt.Sprintf("PT%.3fS", probeResult.Duration)
+
+	fmt.Fprint(w, "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n")
+	fmt.Fprintf(w, "<MPD xmlns=\"urn:mpeg:dash:schema:mpd:2011\" profiles=\"urn:mpeg:dash:profile:isoff-live:2011\" type=\"static\" mediaPresentationDuration=\"%s\" minBufferTime=\"PT%dS\">\n", duration, StreamSegmentLength)
+	fmt.Fprintf(w, "  <Period id=\"0\" start=\"PT0S\" duration=\"%s\">\n", duration)
+	fmt.Fprint(w, "    <AdaptationSet id=\"0\" contentType=\"video\" mimeType=\"video/mp4\" segmentAlignment=\"true\" startWithSAP=\"1\">\n")
+
+	// the segment template is relative to the representation base URL
+	initialization := html.EscapeString(StreamInitSegment + querySuffix(query))
+	media := html.EscapeString("$Number$.m4s" + querySuffix(query))
+	fmt.Fprintf(w, "      <SegmentTemplate timescale=\"1000\" duration=\"%d\" startNumber=\"0\" initialization=\"%s\" media=\"%s\"/>\n", StreamSegmentLength*1000, initialization, media)
+
+	for _, r := range renditions {
+		width, height := r.dimensions(probeResult)
+		fmt.Fprintf(w, "      <Representation id=\"%s\" codecs=\"%s\" bandwidth=\"%d\" width=\"%d\" height=\"%d\">\n", r.Resolution, r.codecs(videoOnly), r.Bandwidth, width, height)
+		fmt.Fprintf(w, "        <BaseURL>%s/%s/</BaseURL>\n", html.EscapeString(segmentsURL), r.Resolution)
+		fmt.Fprint(w, "      </Representation>\n")
+	}
+
+	fmt.Fprint(w, "    </AdaptationSet>\n")
+	fmt.Fprint(w, "  </Period>\n")
+	fmt.Fprint(w, "</MPD>\n")
+}
@@ -55,6 +55,9 @@ func waitAndDeregister(path string, cmd *exec.Cmd) error {
 	return err
 }
 
+// KillRunningEncoders kills the encoders generating files from the file with
+// the provided path. Live transcodes are not registered as running encoders,
+// they are stopped using their stream sessions.
 func KillRunningEncoders(path string) {
 	runningEncodersMutex.RLock()
 	processes := runningEncoders[path]
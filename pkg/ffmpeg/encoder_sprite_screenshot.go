@@ -0,0 +1,40 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"image"
+	"image/jpeg"
+	"strings"
+)
+
+type SpriteScreenshotOptions struct {
+	Time  float64
+	Width int
+}
+
+// SpriteScreenshot extracts a single frame from the video file at the
+// provided time, and returns it as an image, without writing it to disk.
+func (e *Encoder) SpriteScreenshot(probeResult VideoFile, options SpriteScreenshotOptions) (image.Image, error) {
+	args := []string{
+		"-v", "error",
+		"-ss", fmt.Sprintf("%v", options.Time),
+		"-i", probeResult.Path,
+		"-vframes", "1",
+		"-vf", fmt.Sprintf("scale=%v:-2", options.Width),
+		"-c:v", "mjpeg",
+		"-q:v", "2",
+		"-f", "image2pipe",
+		"-",
+	}
+	data, err := e.run(probeResult, args)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := jpeg.Decode(strings.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding screenshot at %v for %s: %s", options.Time, probeResult.Path, err.Error())
+	}
+
+	return img, nil
+}
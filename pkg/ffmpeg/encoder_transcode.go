@@ -11,27 +11,41 @@ type TranscodeOptions struct {
 	MaxTranscodeSize models.StreamingResolutionEnum
 }
 
-func calculateTranscodeScale(probeResult VideoFile, maxTranscodeSize models.StreamingResolutionEnum) string {
-	maxSize := 0
-	switch maxTranscodeSize {
+// getTranscodeSize returns the size of the smaller dimension of the
+// streaming resolution, or 0 for the original resolution.
+func getTranscodeSize(resolution models.StreamingResolutionEnum) int {
+	switch resolution {
 	case models.StreamingResolutionEnumLow:
-		maxSize = 240
+		return 240
 	case models.StreamingResolutionEnumStandard:
-		maxSize = 480
+		return 480
 	case models.StreamingResolutionEnumStandardHd:
-		maxSize = 720
+		return 720
 	case models.StreamingResolutionEnumFullHd:
-		maxSize = 1080
+		return 1080
 	case models.StreamingResolutionEnumFourK:
-		maxSize = 2160
+		return 2160
 	}
 
-	// get the smaller dimension of the video file
+	return 0
+}
+
+// getVideoSize returns the smaller dimension of the video file.
+func getVideoSize(probeResult VideoFile) int {
 	videoSize := probeResult.Height
 	if probeResult.Width < videoSize {
 		videoSize = probeResult.Width
 	}
 
+	return videoSize
+}
+
+func calculateTranscodeScale(probeResult VideoFile, maxTranscodeSize models.StreamingResolutionEnum) string {
+	maxSize := getTranscodeSize(maxTranscodeSize)
+
+	// get the smaller dimension of the video file
+	videoSize := getVideoSize(probeResult)
+
 	// if our streaming resolution is larger than the video dimension
 	// or we are streaming the original resolution, then just set the
 	// input width
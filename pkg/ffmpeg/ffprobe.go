@@ -53,6 +53,7 @@ const (
 	MimeMp4            string     = "video/mp4"
 	MimeHLS            string     = "application/vnd.apple.mpegurl"
 	MimeMpegts         string     = "video/MP2T"
+	MimeDASH           string     = "application/dash+xml"
 )
 
 // only support H264 by default, since Safari does not support VP8/VP9
@@ -3,40 +3,154 @@ package ffmpeg
 import (
 	"fmt"
 	"io"
-	"strings"
+	"math"
+
+	"github.com/stashapp/stash/pkg/models"
 )
 
-const hlsSegmentLength = 10.0
+// StreamSegmentLength is the length in seconds of each segment of HLS and
+// DASH streams.
+const StreamSegmentLength = 6
 
-func WriteHLSPlaylist(probeResult VideoFile, baseUrl string, w io.Writer) {
-	fmt.Fprint(w, "#EXTM3U\n")
-	fmt.Fprint(w, "#EXT-X-VERSION:3\n")
-	fmt.Fprint(w, "#EXT-X-MEDIA-SEQUENCE:0\n")
-	fmt.Fprint(w, "#EXT-X-ALLOW-CACHE:YES\n")
-	fmt.Fprintf(w, "#EXT-X-TARGETDURATION:%d\n", int(hlsSegmentLength))
-	fmt.Fprint(w, "#EXT-X-PLAYLIST-TYPE:VOD\n")
+// StreamRendition is a resolution that scenes are transcoded to for adaptive
+// streaming.
+type StreamRendition struct {
+	Resolution models.StreamingResolutionEnum
+	// the maximum bitrate of the rendition, in bits per second
+	Bandwidth int
+	// the H.264 level of the rendition
+	level string
+}
 
-	duration := probeResult.Duration
+var streamRenditions = []StreamRendition{
+	{Resolution: models.StreamingResolutionEnumLow, Bandwidth: 500000, level: "3.0"},
+	{Resolution: models.StreamingResolutionEnumStandard, Bandwidth: 1500000, level: "3.1"},
+	{Resolution: models.StreamingResolutionEnumStandardHd, Bandwidth: 3000000, level: "3.1"},
+	{Resolution: models.StreamingResolutionEnumFullHd, Bandwidth: 6000000, level: "4.0"},
+	{Resolution: models.StreamingResolutionEnumFourK, Bandwidth: 20000000, level: "5.1"},
+}
 
-	leftover := duration
-	upTo := 0.0
+// GetStreamRendition returns the rendition for the resolution, and false if
+// the resolution is not used for adaptive streaming.
+func GetStreamRendition(resolution models.StreamingResolutionEnum) (StreamRendition, bool) {
+	for _, r := range streamRenditions {
+		if r.Resolution == resolution {
+			return r, true
+		}
+	}
 
-	tsURL := baseUrl
-	i := strings.LastIndex(baseUrl, ".m3u8")
-	tsURL = baseUrl[0:i] + ".ts"
+	return StreamRendition{}, false
+}
 
-	for leftover > 0 {
-		thisLength := hlsSegmentLength
-		if leftover < thisLength {
-			thisLength = leftover
+// GetStreamRenditions returns the renditions that the video file may be
+// streamed at, from lowest to highest resolution. Renditions larger than the
+// video file or maxTranscodeSize are excluded. The lowest rendition is always
+// included.
+func GetStreamRenditions(probeResult VideoFile, maxTranscodeSize models.StreamingResolutionEnum) []StreamRendition {
+	maxSize := getTranscodeSize(maxTranscodeSize)
+	videoSize := getVideoSize(probeResult)
+
+	ret := []StreamRendition{streamRenditions[0]}
+	for _, r := range streamRenditions[1:] {
+		size := getTranscodeSize(r.Resolution)
+		if size > videoSize || (maxSize != 0 && size > maxSize) {
+			break
 		}
 
-		fmt.Fprintf(w, "#EXTINF: %f,\n", thisLength)
-		fmt.Fprintf(w, "%s?start=%f\n", tsURL, upTo)
+		ret = append(ret, r)
+	}
+
+	return ret
+}
+
+// dimensions returns the width and height of the video file when transcoded
+// to the rendition.
+func (r StreamRendition) dimensions(probeResult VideoFile) (int, int) {
+	size := getTranscodeSize(r.Resolution)
+	videoSize := getVideoSize(probeResult)
+	if videoSize == 0 || size >= videoSize {
+		return probeResult.Width, probeResult.Height
+	}
+
+	// scale the larger dimension, rounded to an even number
+	scale := func(v int) int {
+		return int(math.Round(float64(v)*float64(size)/float64(videoSize)/2)) * 2
+	}
+
+	if probeResult.Width > probeResult.Height {
+		return scale(probeResult.Width), size
+	}
+
+	return size, scale(probeResult.Height)
+}
+
+// codecs returns the RFC 6381 codecs string of the rendition.
+func (r StreamRendition) codecs(videoOnly bool) string {
+	levels := map[string]string{
+		"3.0": "1e",
+		"3.1": "1f",
+		"4.0": "28",
+		"5.1": "33",
+	}
+
+	ret := "avc1.6400" + levels[r.level]
+	if !videoOnly {
+		ret += ",mp4a.40.2"
+	}
+
+	return ret
+}
+
+// GetStreamSegmentCount returns the number of segments in a stream of the
+// video file.
+func GetStreamSegmentCount(probeResult VideoFile) int {
+	return int(math.Ceil(probeResult.Duration / StreamSegmentLength))
+}
+
+func getStreamSegmentDuration(probeResult VideoFile, segment int) float64 {
+	return math.Min(StreamSegmentLength, probeResult.Duration-float64(segment*StreamSegmentLength))
+}
+
+// WriteHLSMasterPlaylist writes a HLS master playlist containing a variant
+// stream for each rendition. Variant playlists are located at
+// <segmentsURL>/<resolution>/index.m3u8. query is appended to each URL if it
+// is not empty.
+func WriteHLSMasterPlaylist(probeResult VideoFile, renditions []StreamRendition, videoOnly bool, segmentsURL string, query string, w io.Writer) {
+	fmt.Fprint(w, "#EXTM3U\n")
+	fmt.Fprint(w, "#EXT-X-VERSION:7\n")
+	fmt.Fprint(w, "#EXT-X-INDEPENDENT-SEGMENTS\n")
 
-		leftover -= thisLength
-		upTo += thisLength
+	for _, r := range renditions {
+		width, height := r.dimensions(probeResult)
+		fmt.Fprintf(w, "#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,CODECS=\"%s\"\n", r.Bandwidth, width, height, r.codecs(videoOnly))
+		fmt.Fprintf(w, "%s/%s/index.m3u8%s\n", segmentsURL, r.Resolution, querySuffix(query))
+	}
+}
+
+// WriteHLSPlaylist writes the HLS media playlist of a single rendition. The
+// initialization and media segments are relative to the playlist URL.
+func WriteHLSPlaylist(probeResult VideoFile, query string, w io.Writer) {
+	fmt.Fprint(w, "#EXTM3U\n")
+	fmt.Fprint(w, "#EXT-X-VERSION:7\n")
+	fmt.Fprint(w, "#EXT-X-MEDIA-SEQUENCE:0\n")
+	fmt.Fprintf(w, "#EXT-X-TARGETDURATION:%d\n", StreamSegmentLength)
+	fmt.Fprint(w, "#EXT-X-PLAYLIST-TYPE:VOD\n")
+	fmt.Fprint(w, "#EXT-X-INDEPENDENT-SEGMENTS\n")
+	fmt.Fprintf(w, "#EXT-X-MAP:URI=\"%s%s\"\n", StreamInitSegment, querySuffix(query))
+
+	segments := GetStreamSegmentCount(probeResult)
+	for i := 0; i < segments; i++ {
+		fmt.Fprintf(w, "#EXTINF:%f,\n", getStreamSegmentDuration(probeResult, i))
+		fmt.Fprintf(w, "%s%s\n", GetStreamSegmentFilename(i), querySuffix(query))
 	}
 
 	fmt.Fprint(w, "#EXT-X-ENDLIST\n")
 }
+
+func querySuffix(query string) string {
+	if query == "" {
+		return ""
+	}
+
+	return "?" + query
+}
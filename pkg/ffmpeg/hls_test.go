@@ -0,0 +1,104 @@
+package ffmpeg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestGetStreamRenditions(t *testing.T) {
+	resolutions := func(renditions []StreamRendition) []models.StreamingResolutionEnum {
+		var ret []models.StreamingResolutionEnum
+		for _, r := range renditions {
+			ret = append(ret, r.Resolution)
+		}
+		return ret
+	}
+
+	fullHD := VideoFile{Width: 1920, Height: 1080}
+
+	assert.Equal(t, []models.StreamingResolutionEnum{
+		models.StreamingResolutionEnumLow,
+		models.StreamingResolutionEnumStandard,
+		models.StreamingResolutionEnumStandardHd,
+		models.StreamingResolutionEnumFullHd,
+	}, resolutions(GetStreamRenditions(fullHD, models.StreamingResolutionEnumOriginal)))
+
+	// capped by the maximum transcode size
+	assert.Equal(t, []models.StreamingResolutionEnum{
+		models.StreamingResolutionEnumLow,
+		models.StreamingResolutionEnumStandard,
+	}, resolutions(GetStreamRenditions(fullHD, models.StreamingResolutionEnumStandard)))
+
+	// portrait videos use the smaller dimension
+	portrait := VideoFile{Width: 720, Height: 1280}
+	assert.Equal(t, []models.StreamingResolutionEnum{
+		models.StreamingResolutionEnumLow,
+		models.StreamingResolutionEnumStandard,
+		models.StreamingResolutionEnumStandardHd,
+	}, resolutions(GetStreamRenditions(portrait, models.StreamingResolutionEnumOriginal)))
+
+	// the lowest rendition is always included
+	tiny := VideoFile{Width: 160, Height: 120}
+	assert.Equal(t, []models.StreamingResolutionEnum{
+		models.StreamingResolutionEnumLow,
+	}, resolutions(GetStreamRenditions(tiny, models.StreamingResolutionEnumOriginal)))
+}
+
+func TestWriteHLSMasterPlaylist(t *testing.T) {
+	videoFile := VideoFile{Width: 1920, Height: 1080}
+	renditions := GetStreamRenditions(videoFile, models.StreamingResolutionEnumStandard)
+
+	var str strings.Builder
+	WriteHLSMasterPlaylist(videoFile, renditions, false, "segments", "apikey=key", &str)
+
+	assert.Equal(t, `#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-INDEPENDENT-SEGMENTS
+#EXT-X-STREAM-INF:BANDWIDTH=500000,RESOLUTION=426x240,CODECS="avc1.64001e,mp4a.40.2"
+segments/LOW/index.m3u8?apikey=key
+#EXT-X-STREAM-INF:BANDWIDTH=1500000,RESOLUTION=854x480,CODECS="avc1.64001f,mp4a.40.2"
+segments/STANDARD/index.m3u8?apikey=key
+`, str.String())
+}
+
+func TestWriteHLSPlaylist(t *testing.T) {
+	videoFile := VideoFile{Duration: 15}
+
+	var str strings.Builder
+	WriteHLSPlaylist(videoFile, "", &str)
+
+	assert.Equal(t, `#EXTM3U
+#EXT-X-VERSION:7
+#EXT-X-MEDIA-SEQUENCE:0
+#EXT-X-TARGETDURATION:6
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXT-X-INDEPENDENT-SEGMENTS
+#EXT-X-MAP:URI="init.mp4"
+#EXTINF:6.000000,
+0.m4s
+#EXTINF:6.000000,
+1.m4s
+#EXTINF:3.000000,
+2.m4s
+#EXT-X-ENDLIST
+`, str.String())
+}
+
+func TestWriteDASHManifest(t *testing.T) {
+	videoFile := VideoFile{Width: 1280, Height: 720, Duration: 15}
+	renditions := GetStreamRenditions(videoFile, models.StreamingResolutionEnumOriginal)
+
+	var str strings.Builder
+	WriteDASHManifest(videoFile, renditions, true, "segments", "a=1&b=2", &str)
+
+	manifest := str.String()
+	assert.Contains(t, manifest, `mediaPresentationDuration="PT15.000S"`)
+	assert.Contains(t, manifest, `initialization="init.mp4?a=1&amp;b=2" media="$Number$.m4s?a=1&amp;b=2"`)
+	assert.Contains(t, manifest, `<Representation id="STANDARD_HD" codecs="avc1.64001f" bandwidth="3000000" width="1280" height="720">`)
+	assert.Contains(t, manifest, `<BaseURL>segments/STANDARD_HD/</BaseURL>`)
+	assert.Equal(t, 3, strings.Count(manifest, "<Representation "))
+}
@@ -0,0 +1,168 @@
+package ffmpeg
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// StreamInitSegment is the filename of the initialization segment of HLS and
+// DASH streams.
+const StreamInitSegment = "init.mp4"
+
+// GetStreamSegmentFilename returns the filename of the media segment with
+// the provided index.
+func GetStreamSegmentFilename(segment int) string {
+	return fmt.Sprintf("%d.m4s", segment)
+}
+
+type SegmenterOptions struct {
+	ProbeResult VideoFile
+	Rendition   StreamRendition
+	// the directory that the segments are written to
+	OutputDir string
+	// the index of the first segment to write
+	StartSegment int
+	// the index after the last segment to write, or 0 to write the segments
+	// up to the end of the video file
+	EndSegment int
+	// transcode the video, remove the audio
+	VideoOnly bool
+}
+
+func (o SegmenterOptions) getArgs() []string {
+	startTime := o.StartSegment * StreamSegmentLength
+
+	args := []string{
+		"-hide_banner",
+		"-v", "error",
+	}
+
+	if startTime > 0 {
+		args = append(args, "-ss", strconv.Itoa(startTime))
+	}
+
+	args = append(args,
+		"-i", o.ProbeResult.Path,
+	)
+
+	if o.EndSegment > o.StartSegment {
+		args = append(args, "-t", strconv.Itoa((o.EndSegment-o.StartSegment)*StreamSegmentLength))
+	}
+
+	args = append(args,
+		"-c:v", "libx264",
+		"-pix_fmt", "yuv420p",
+		"-profile:v", "high",
+		"-level", o.Rendition.level,
+		"-preset", "veryfast",
+		"-crf", "23",
+		"-maxrate", strconv.Itoa(o.Rendition.Bandwidth),
+		"-bufsize", strconv.Itoa(o.Rendition.Bandwidth*2),
+		"-vf", "scale="+calculateTranscodeScale(o.ProbeResult, o.Rendition.Resolution),
+		// force keyframes at the segment boundaries so that segments are
+		// the same regardless of where the segmenter was started
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", StreamSegmentLength),
+	)
+
+	if o.VideoOnly {
+		args = append(args, "-an")
+	} else {
+		args = append(args,
+			"-c:a", "aac",
+			// this is needed for 5-channel ac3 files
+			"-ac", "2",
+		)
+	}
+
+	args = append(args,
+		"-output_ts_offset", strconv.Itoa(startTime),
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(StreamSegmentLength),
+		"-hls_list_size", "0",
+		"-hls_playlist_type", "vod",
+		"-hls_segment_type", "fmp4",
+		"-hls_fmp4_init_filename", StreamInitSegment,
+		"-hls_segment_filename", filepath.Join(o.OutputDir, "%d.m4s"),
+		"-start_number", strconv.Itoa(o.StartSegment),
+		filepath.Join(o.OutputDir, "ffmpeg.m3u8"),
+	)
+
+	return args
+}
+
+// Segmenter is a running ffmpeg process that writes the segments of a HLS
+// or DASH stream to a directory.
+type Segmenter struct {
+	Options SegmenterOptions
+	Process *os.Process
+
+	done chan struct{}
+	err  error
+}
+
+// StartSegmenter starts an ffmpeg process that writes the stream segments
+// from the start segment to the end segment, or the end of the video file.
+func (e *Encoder) StartSegmenter(options SegmenterOptions) (*Segmenter, error) {
+	cmd := exec.Command(e.Path, options.getArgs()...)
+	logger.Debugf("Segmenting via: %s", strings.Join(cmd.Args, " "))
+
+	stderr, err := cmd.StderrPipe()
+	if nil != err {
+		logger.Error("FFMPEG stderr not available: " + err.Error())
+		return nil, err
+	}
+
+	if err = cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	ret := &Segmenter{
+		Options: options,
+		Process: cmd.Process,
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		// stderr must be consumed or the process deadlocks
+		stderrData, _ := ioutil.ReadAll(stderr)
+
+		ret.err = cmd.Wait()
+		if ret.err != nil && len(stderrData) > 0 {
+			logger.Debugf("[stream] ffmpeg stderr: %s", string(stderrData))
+		}
+
+		close(ret.done)
+	}()
+
+	return ret, nil
+}
+
+// Done returns a channel that is closed when the process exits.
+func (s *Segmenter) Done() <-chan struct{} {
+	return s.done
+}
+
+// Err returns the error that the process exited with. It must only be called
+// after the process has exited.
+func (s *Segmenter) Err() error {
+	return s.err
+}
+
+// Stop kills the process and waits for it to exit. It does not wait more
+// than a few seconds.
+func (s *Segmenter) Stop() {
+	s.Process.Kill()
+
+	select {
+	case <-s.done:
+	case <-time.After(5 * time.Second):
+	}
+}
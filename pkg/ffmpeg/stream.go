@@ -3,7 +3,6 @@ package ffmpeg
 import (
 	"io"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"os/exec"
 	"strconv"
@@ -22,25 +21,6 @@ type Stream struct {
 	mimeType string
 }
 
-func (s *Stream) Serve(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", s.mimeType)
-	w.WriteHeader(http.StatusOK)
-
-	logger.Infof("[stream] transcoding video file to %s", s.mimeType)
-
-	// handle if client closes the connection
-	notify := r.Context().Done()
-	go func() {
-		<-notify
-		s.Process.Kill()
-	}()
-
-	_, err := io.Copy(w, s.Stdout)
-	if err != nil {
-		logger.Errorf("[stream] error serving transcoded video file: %s", err.Error())
-	}
-}
-
 type Codec struct {
 	Codec     string
 	format    string
@@ -49,6 +29,10 @@ type Codec struct {
 	hls       bool
 }
 
+// hlsSegmentLength is the length in seconds of the MPEG-TS segments served
+// by the legacy HLS transcode stream.
+const hlsSegmentLength = 10
+
 var CodecHLS = Codec{
 	Codec:    "libx264",
 	format:   "mpegts",
@@ -161,7 +145,7 @@ func (o TranscodeStreamOptions) getStreamArgs() []string {
 
 	if o.Codec.hls {
 		// we only serve a fixed segment length
-		args = append(args, "-t", strconv.Itoa(int(hlsSegmentLength)))
+		args = append(args, "-t", strconv.Itoa(hlsSegmentLength))
 	}
 
 	args = append(args,
@@ -223,8 +207,11 @@ func (e *Encoder) stream(probeResult VideoFile, options TranscodeStreamOptions)
 		return nil, err
 	}
 
-	registerRunningEncoder(probeResult.Path, cmd.Process)
-	go waitAndDeregister(probeResult.Path, cmd)
+	// the process is stopped using the stream session of the transcode,
+	// so it is not registered as a running encoder
+	go func() {
+		_ = cmd.Wait()
+	}()
 
 	// stderr must be consumed or the process deadlocks
 	go func() {
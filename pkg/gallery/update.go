@@ -1,6 +1,8 @@
 package gallery
 
 import (
+	"database/sql"
+
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -12,6 +14,19 @@ func UpdateFileModTime(qb models.GalleryWriter, id int, modTime models.NullSQLit
 	})
 }
 
+// UpdateMovedFile sets the path and file modification time of the gallery to
+// those of the zip file that it was moved or renamed to.
+func UpdateMovedFile(qb models.GalleryWriter, id int, path string, modTime models.NullSQLiteTimestamp) (*models.Gallery, error) {
+	return qb.UpdatePartial(models.GalleryPartial{
+		ID: id,
+		Path: &sql.NullString{
+			String: path,
+			Valid:  true,
+		},
+		FileModTime: &modTime,
+	})
+}
+
 func AddImage(qb models.GalleryReaderWriter, galleryID int, imageID int) error {
 	imageIDs, err := qb.GetImageIDs(galleryID)
 	if err != nil {
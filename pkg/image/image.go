@@ -59,6 +59,17 @@ func ZipFilename(zipFilename, filenameInZip string) string {
 	return zipFilename + zipSeparator + filenameInZip
 }
 
+// ChangeZipFilename returns the path of the image within the zip file
+// zipFilename. Paths that are not within a zip file are returned unchanged.
+func ChangeZipFilename(path, zipFilename string) string {
+	oldZipFilename, filename := getFilePath(path)
+	if oldZipFilename == "" {
+		return path
+	}
+
+	return ZipFilename(zipFilename, filename)
+}
+
 // IsZipPath returns true if the path includes the zip separator byte,
 // indicating it is within a zip file.
 // TODO - this should be moved to utils
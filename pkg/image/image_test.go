@@ -32,3 +32,23 @@ func TestIsCover(t *testing.T) {
 		assert.Equal(tc.isCover, IsCover(img), "expected: %t for %s", tc.isCover, tc.fn)
 	}
 }
+
+func TestChangeZipFilename(t *testing.T) {
+	type test struct {
+		path     string
+		expected string
+	}
+
+	const newZip = "new.zip"
+
+	tests := []test{
+		{ZipFilename("old.zip", "image.jpg"), ZipFilename(newZip, "image.jpg")},
+		{ZipFilename("old.zip", "sub/image.jpg"), ZipFilename(newZip, "sub/image.jpg")},
+		{"image.jpg", "image.jpg"},
+	}
+
+	assert := assert.New(t)
+	for _, tc := range tests {
+		assert.Equal(tc.expected, ChangeZipFilename(tc.path, newZip), "unexpected path for %s", PathDisplayName(tc.path))
+	}
+}
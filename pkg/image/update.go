@@ -8,3 +8,13 @@ func UpdateFileModTime(qb models.ImageWriter, id int, modTime models.NullSQLiteT
 		FileModTime: &modTime,
 	})
 }
+
+// UpdateMovedFile sets the path and file modification time of the image to
+// those of the file that it was moved or renamed to.
+func UpdateMovedFile(qb models.ImageWriter, id int, path string, modTime models.NullSQLiteTimestamp) (*models.Image, error) {
+	return qb.Update(models.ImagePartial{
+		ID:          id,
+		Path:        &path,
+		FileModTime: &modTime,
+	})
+}
@@ -0,0 +1,99 @@
+// Package job implements a manager for long-running background jobs.
+//
+// Jobs are added to the Manager, which assigns each job an ID and executes
+// it. Exclusive jobs are executed one at a time in the order that they were
+// added. Non-exclusive jobs are executed immediately, alongside any other
+// running jobs. Completed jobs are retained in a history of limited size.
+package job
+
+import (
+	"context"
+	"time"
+)
+
+// JobExec represents the implementation of a Job to be executed.
+type JobExec interface {
+	// Execute performs the job. The context is cancelled when the job is
+	// stopped. Progress is used to report the job's progress. A non-nil error
+	// marks the job as failed.
+	Execute(ctx context.Context, progress *Progress) error
+}
+
+// JobExecFunc is a function that implements JobExec.
+type JobExecFunc func(ctx context.Context, progress *Progress) error
+
+// Execute calls f(ctx, progress).
+func (f JobExecFunc) Execute(ctx context.Context, progress *Progress) error {
+	return f(ctx, progress)
+}
+
+// Status is the status of a Job.
+type Status string
+
+const (
+	// StatusReady means that the Job is not yet started.
+	StatusReady Status = "READY"
+	// StatusRunning means that the job is currently running.
+	StatusRunning Status = "RUNNING"
+	// StatusStopping means that the job is cancelled but is still running.
+	StatusStopping Status = "STOPPING"
+	// StatusFinished means that the job was completed.
+	StatusFinished Status = "FINISHED"
+	// StatusCancelled means that the job was cancelled and is now stopped.
+	StatusCancelled Status = "CANCELLED"
+	// StatusFailed means that the job returned an error or panicked.
+	StatusFailed Status = "FAILED"
+)
+
+// Job represents the status of a queued or running job.
+type Job struct {
+	ID     int
+	Status Status
+
+	// details of the current operations of the job
+	Details []string
+
+	Description string
+
+	// Progress in terms of 0 - 1. Indefinite progress is represented by -1.
+	Progress  float64
+	StartTime *time.Time
+	EndTime   *time.Time
+	AddTime   time.Time
+
+	// Error is set if the job failed.
+	Error *string
+
+	exclusive  bool
+	exec       JobExec
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+}
+
+func (j *Job) isActive() bool {
+	return j.Status == StatusReady || j.Status == StatusRunning || j.Status == StatusStopping
+}
+
+func (j *Job) cancel() {
+	if j.Status == StatusReady {
+		j.Status = StatusCancelled
+	} else if j.Status == StatusRunning {
+		j.Status = StatusStopping
+	}
+
+	if j.cancelFunc != nil {
+		j.cancelFunc()
+	}
+}
+
+// IsCancelled returns true if the provided context is cancelled. Job
+// implementations should check this periodically and return as soon as
+// possible when it returns true.
+func IsCancelled(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
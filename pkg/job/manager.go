@@ -0,0 +1,316 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const maxGraveyardSize = 100
+
+// Manager maintains a queue of jobs. Exclusive jobs are executed one at a
+// time, in the order that they were added. Non-exclusive jobs are executed
+// immediately.
+type Manager struct {
+	mutex sync.Mutex
+
+	queue     []*Job
+	graveyard []*Job
+	lastID    int
+
+	subscriptions []*ManagerSubscription
+}
+
+// NewManager initialises and returns a new Manager.
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Add queues an exclusive job for execution. The job is started once all
+// previously added exclusive jobs are complete. Returns the ID of the job.
+func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
+	return m.add(ctx, description, e, true)
+}
+
+// Start starts a non-exclusive job immediately, alongside any running jobs.
+// Returns the ID of the job.
+func (m *Manager) Start(ctx context.Context, description string, e JobExec) int {
+	return m.add(ctx, description, e, false)
+}
+
+func (m *Manager) add(ctx context.Context, description string, e JobExec, exclusive bool) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.lastID++
+	j := &Job{
+		ID:          m.lastID,
+		Status:      StatusReady,
+		Description: description,
+		Progress:    ProgressIndefinite,
+		AddTime:     time.Now(),
+		exclusive:   exclusive,
+		exec:        e,
+	}
+
+	// the context should not be cancelled if the request that added the job
+	// completes, so only the values of the provided context are used
+	j.ctx, j.cancelFunc = context.WithCancel(valueOnlyContext{ctx})
+
+	m.queue = append(m.queue, j)
+	m.notifyNewJob(j)
+
+	if exclusive {
+		m.startNextExclusive()
+	} else {
+		m.startJob(j)
+	}
+
+	return j.ID
+}
+
+// valueOnlyContext returns the values of the wrapped context, but is never
+// cancelled and has no deadline.
+type valueOnlyContext struct {
+	context.Context
+}
+
+func (valueOnlyContext) Deadline() (deadline time.Time, ok bool) {
+	return
+}
+
+func (valueOnlyContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (valueOnlyContext) Err() error {
+	return nil
+}
+
+// startNextExclusive starts the next ready exclusive job if no other
+// exclusive job is running. Must be called with the mutex held.
+func (m *Manager) startNextExclusive() {
+	for _, j := range m.queue {
+		if j.exclusive && (j.Status == StatusRunning || j.Status == StatusStopping) {
+			return
+		}
+	}
+
+	for _, j := range m.queue {
+		if j.exclusive && j.Status == StatusReady {
+			m.startJob(j)
+			return
+		}
+	}
+}
+
+// startJob starts executing the job in a new goroutine. Must be called with
+// the mutex held.
+func (m *Manager) startJob(j *Job) {
+	now := time.Now()
+	j.StartTime = &now
+	j.Status = StatusRunning
+
+	progress := newProgress(func(p float64, details []string) {
+		m.updateProgress(j, p, details)
+	}, func(description string) {
+		m.updateDescription(j, description)
+	})
+
+	m.notifyJobUpdate(j)
+
+	go m.executeJob(j.ctx, j, progress)
+}
+
+func (m *Manager) executeJob(ctx context.Context, j *Job, progress *Progress) {
+	var err error
+
+	defer func() {
+		if p := recover(); p != nil {
+			logger.Errorf("panic in job %d - %s: %v", j.ID, j.Description, p)
+			err = fmt.Errorf("panic: %v", p)
+		}
+
+		m.onJobComplete(ctx, j, err)
+	}()
+
+	err = j.exec.Execute(ctx, progress)
+}
+
+func (m *Manager) onJobComplete(ctx context.Context, j *Job, err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	switch {
+	case IsCancelled(ctx):
+		j.Status = StatusCancelled
+	case err != nil:
+		j.Status = StatusFailed
+		errStr := err.Error()
+		j.Error = &errStr
+		logger.Errorf("job %d - %s failed: %s", j.ID, j.Description, errStr)
+	default:
+		j.Status = StatusFinished
+	}
+
+	now := time.Now()
+	j.EndTime = &now
+
+	// release the context resources
+	j.cancelFunc()
+
+	m.removeJob(j)
+
+	if j.exclusive {
+		m.startNextExclusive()
+	}
+}
+
+// removeJob moves the job from the queue to the graveyard. Must be called
+// with the mutex held.
+func (m *Manager) removeJob(j *Job) {
+	for i, qj := range m.queue {
+		if qj == j {
+			m.queue = append(m.queue[:i], m.queue[i+1:]...)
+			break
+		}
+	}
+
+	// clear the details of the finished job
+	j.Details = nil
+	j.exec = nil
+	j.ctx = nil
+
+	m.graveyard = append(m.graveyard, j)
+	if len(m.graveyard) > maxGraveyardSize {
+		m.graveyard = m.graveyard[len(m.graveyard)-maxGraveyardSize:]
+	}
+
+	m.notifyJobRemoved(j)
+}
+
+func (m *Manager) updateProgress(j *Job, progress float64, details []string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// ignore updates from jobs that have completed
+	if !j.isActive() {
+		return
+	}
+
+	j.Progress = progress
+	j.Details = details
+
+	m.notifyJobUpdate(j)
+}
+
+func (m *Manager) updateDescription(j *Job, description string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// ignore updates from jobs that have completed
+	if !j.isActive() {
+		return
+	}
+
+	j.Description = description
+
+	m.notifyJobUpdate(j)
+}
+
+// CancelJob cancels the job with the provided ID. Jobs that are not yet
+// started are removed from the queue. Running jobs are signalled to stop.
+// Does nothing if the job is not in the queue.
+func (m *Manager) CancelJob(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, j := range m.queue {
+		if j.ID == id {
+			m.cancelJob(j)
+			return
+		}
+	}
+}
+
+// CancelAll cancels all of the jobs in the queue.
+func (m *Manager) CancelAll() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// iterate over a copy, since cancelling may modify the queue
+	queue := make([]*Job, len(m.queue))
+	copy(queue, m.queue)
+
+	for _, j := range queue {
+		m.cancelJob(j)
+	}
+}
+
+// cancelJob must be called with the mutex held.
+func (m *Manager) cancelJob(j *Job) {
+	wasReady := j.Status == StatusReady
+	j.cancel()
+
+	if wasReady {
+		now := time.Now()
+		j.EndTime = &now
+		m.removeJob(j)
+	} else {
+		m.notifyJobUpdate(j)
+	}
+}
+
+// GetJob returns a copy of the job with the provided ID, from either the
+// queue or the job history. Returns nil if the job is not found.
+func (m *Manager) GetJob(id int) *Job {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, j := range m.queue {
+		if j.ID == id {
+			ret := *j
+			return &ret
+		}
+	}
+
+	for _, j := range m.graveyard {
+		if j.ID == id {
+			ret := *j
+			return &ret
+		}
+	}
+
+	return nil
+}
+
+// GetQueue returns copies of the jobs in the queue, in the order that they
+// were added.
+func (m *Manager) GetQueue() []Job {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var ret []Job
+	for _, j := range m.queue {
+		ret = append(ret, *j)
+	}
+
+	return ret
+}
+
+// GetHistory returns copies of the completed jobs, in the order that they
+// were completed.
+func (m *Manager) GetHistory() []Job {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var ret []Job
+	for _, j := range m.graveyard {
+		ret = append(ret, *j)
+	}
+
+	return ret
+}
@@ -0,0 +1,240 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const waitTimeout = 5 * time.Second
+
+// testExec is a JobExec that blocks until it is released or cancelled.
+type testExec struct {
+	started chan struct{}
+	release chan struct{}
+	err     error
+}
+
+func newTestExec() *testExec {
+	return &testExec{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (e *testExec) Execute(ctx context.Context, progress *Progress) error {
+	close(e.started)
+
+	select {
+	case <-e.release:
+	case <-ctx.Done():
+	}
+
+	return e.err
+}
+
+func waitStarted(t *testing.T, e *testExec) bool {
+	select {
+	case <-e.started:
+		return true
+	case <-time.After(waitTimeout):
+		t.Error("timed out waiting for job to start")
+		return false
+	}
+}
+
+func isStarted(e *testExec) bool {
+	select {
+	case <-e.started:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitStatus waits until the job with the provided id has the expected status.
+func waitStatus(t *testing.T, m *Manager, id int, status Status) *Job {
+	deadline := time.Now().Add(waitTimeout)
+	for time.Now().Before(deadline) {
+		j := m.GetJob(id)
+		if j != nil && j.Status == status {
+			return j
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Errorf("timed out waiting for job %d to have status %s", id, status)
+	return nil
+}
+
+func TestExclusiveJobs(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec()
+	exec2 := newTestExec()
+
+	id1 := m.Add(context.Background(), "job 1", exec1)
+	id2 := m.Add(context.Background(), "job 2", exec2)
+
+	assert.NotEqual(t, id1, id2)
+
+	waitStarted(t, exec1)
+
+	// second job must not start until the first is complete
+	assert.False(t, isStarted(exec2))
+	assert.Equal(t, StatusReady, m.GetJob(id2).Status)
+	assert.Len(t, m.GetQueue(), 2)
+
+	close(exec1.release)
+	waitStarted(t, exec2)
+	waitStatus(t, m, id1, StatusFinished)
+
+	close(exec2.release)
+	waitStatus(t, m, id2, StatusFinished)
+
+	assert.Len(t, m.GetQueue(), 0)
+	assert.Len(t, m.GetHistory(), 2)
+}
+
+func TestNonExclusiveJobs(t *testing.T) {
+	m := NewManager()
+
+	exclusive := newTestExec()
+	nonExclusive := newTestExec()
+
+	m.Add(context.Background(), "exclusive", exclusive)
+	m.Start(context.Background(), "non-exclusive", nonExclusive)
+
+	// both jobs should run side by side
+	waitStarted(t, exclusive)
+	waitStarted(t, nonExclusive)
+
+	close(exclusive.release)
+	close(nonExclusive.release)
+}
+
+func TestCancelJob(t *testing.T) {
+	m := NewManager()
+
+	running := newTestExec()
+	queued := newTestExec()
+
+	runningID := m.Add(context.Background(), "running", running)
+	queuedID := m.Add(context.Background(), "queued", queued)
+
+	waitStarted(t, running)
+
+	// cancelling a queued job removes it from the queue without running it
+	m.CancelJob(queuedID)
+	j := m.GetJob(queuedID)
+	assert.Equal(t, StatusCancelled, j.Status)
+	assert.Len(t, m.GetQueue(), 1)
+
+	// cancelling a running job cancels its context
+	m.CancelJob(runningID)
+	waitStatus(t, m, runningID, StatusCancelled)
+	assert.False(t, isStarted(queued))
+	assert.Len(t, m.GetQueue(), 0)
+}
+
+func TestFailedJob(t *testing.T) {
+	m := NewManager()
+
+	const errMsg = "job error"
+	failed := newTestExec()
+	failed.err = errors.New(errMsg)
+	close(failed.release)
+
+	id := m.Add(context.Background(), "failed", failed)
+	j := waitStatus(t, m, id, StatusFailed)
+	if assert.NotNil(t, j) && assert.NotNil(t, j.Error) {
+		assert.Equal(t, errMsg, *j.Error)
+	}
+
+	panicked := m.Add(context.Background(), "panic", JobExecFunc(func(ctx context.Context, progress *Progress) error {
+		panic("job panic")
+	}))
+	waitStatus(t, m, panicked, StatusFailed)
+
+	// subsequent exclusive jobs must still run
+	next := newTestExec()
+	close(next.release)
+	id = m.Add(context.Background(), "next", next)
+	waitStatus(t, m, id, StatusFinished)
+}
+
+func TestProgress(t *testing.T) {
+	m := NewManager()
+
+	updated := make(chan struct{})
+	release := make(chan struct{})
+	id := m.Start(context.Background(), "progress", JobExecFunc(func(ctx context.Context, progress *Progress) error {
+		progress.SetTotal(4)
+		progress.Increment()
+		progress.ExecuteTask("subtask", func() {
+			close(updated)
+			<-release
+		})
+		return nil
+	}))
+
+	<-updated
+	j := m.GetJob(id)
+	assert.Equal(t, 0.25, j.Progress)
+	assert.Equal(t, []string{"subtask"}, j.Details)
+
+	close(release)
+	j = waitStatus(t, m, id, StatusFinished)
+	assert.Len(t, j.Details, 0)
+}
+
+func TestSetDescription(t *testing.T) {
+	m := NewManager()
+
+	const description = "job complete"
+	id := m.Start(context.Background(), "job", JobExecFunc(func(ctx context.Context, progress *Progress) error {
+		progress.SetDescription(description)
+		return nil
+	}))
+
+	// the description is retained in the job history
+	j := waitStatus(t, m, id, StatusFinished)
+	if assert.NotNil(t, j) {
+		assert.Equal(t, description, j.Description)
+	}
+	if history := m.GetHistory(); assert.Len(t, history, 1) {
+		assert.Equal(t, description, history[0].Description)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	m := NewManager()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := m.Subscribe(ctx)
+
+	exec := newTestExec()
+	id := m.Add(context.Background(), "job", exec)
+
+	select {
+	case j := <-sub.NewJob:
+		assert.Equal(t, id, j.ID)
+	case <-time.After(waitTimeout):
+		t.Error("timed out waiting for new job")
+	}
+
+	close(exec.release)
+
+	select {
+	case j := <-sub.RemovedJob:
+		assert.Equal(t, id, j.ID)
+		assert.Equal(t, StatusFinished, j.Status)
+	case <-time.After(waitTimeout):
+		t.Error("timed out waiting for removed job")
+	}
+
+	cancel()
+}
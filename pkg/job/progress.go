@@ -0,0 +1,149 @@
+package job
+
+import "sync"
+
+// ProgressIndefinite is the progress value of a job whose progress cannot be
+// determined.
+const ProgressIndefinite float64 = -1
+
+// Progress is used by JobExec to communicate the progress of the job to the
+// Manager. Progress is safe for concurrent use.
+type Progress struct {
+	updater   func(progress float64, details []string)
+	describer func(description string)
+
+	mutex     sync.Mutex
+	total     int
+	processed int
+	percent   float64
+	details   []string
+}
+
+func newProgress(updater func(progress float64, details []string), describer func(description string)) *Progress {
+	return &Progress{
+		updater:   updater,
+		describer: describer,
+		percent:   ProgressIndefinite,
+	}
+}
+
+// SetDescription replaces the description of the job. It may be used to
+// report the outcome of the job before it completes.
+func (p *Progress) SetDescription(description string) {
+	if p.describer == nil {
+		return
+	}
+
+	p.describer(description)
+}
+
+// Indefinite sets the progress to an indefinite amount.
+func (p *Progress) Indefinite() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.total = 0
+	p.percent = ProgressIndefinite
+	p.updated()
+}
+
+// SetTotal sets the total number of work units. This is used to calculate
+// the progress percentage.
+func (p *Progress) SetTotal(total int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.total = total
+	p.calculatePercent()
+}
+
+// AddTotal adds to the total number of work units.
+func (p *Progress) AddTotal(total int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.total += total
+	p.calculatePercent()
+}
+
+// SetProcessed sets the number of work units completed.
+func (p *Progress) SetProcessed(processed int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.processed = processed
+	p.calculatePercent()
+}
+
+// Increment increments the number of work units completed.
+func (p *Progress) Increment() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.processed++
+	p.calculatePercent()
+}
+
+// SetPercent sets the progress percentage directly, in terms of 0 - 1. The
+// total and processed values are reset.
+func (p *Progress) SetPercent(percent float64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.total = 0
+	p.processed = 0
+	p.percent = percent
+	p.updated()
+}
+
+// ExecuteTask adds the description to the job details, executes fn, then
+// removes the description from the job details.
+func (p *Progress) ExecuteTask(description string, fn func()) {
+	p.addDetail(description)
+	defer p.removeDetail(description)
+
+	fn()
+}
+
+func (p *Progress) addDetail(description string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.details = append(p.details, description)
+	p.updated()
+}
+
+func (p *Progress) removeDetail(description string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for i, d := range p.details {
+		if d == description {
+			p.details = append(p.details[:i], p.details[i+1:]...)
+			break
+		}
+	}
+	p.updated()
+}
+
+func (p *Progress) calculatePercent() {
+	if p.total <= 0 {
+		p.percent = ProgressIndefinite
+	} else if p.processed >= p.total {
+		p.percent = 1
+	} else {
+		p.percent = float64(p.processed) / float64(p.total)
+	}
+
+	p.updated()
+}
+
+func (p *Progress) updated() {
+	if p.updater == nil {
+		return
+	}
+
+	details := make([]string, len(p.details))
+	copy(details, p.details)
+	p.updater(p.percent, details)
+}
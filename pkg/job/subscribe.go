@@ -0,0 +1,100 @@
+package job
+
+import "context"
+
+// the size of the subscription channel buffers. Updates are dropped if a
+// subscriber's buffer is full.
+const subscriptionBufferSize = 100
+
+// ManagerSubscription is a collection of channels that receive updates from
+// the job manager.
+type ManagerSubscription struct {
+	// new jobs are sent to this channel
+	NewJob <-chan Job
+	// removed jobs are sent to this channel
+	RemovedJob <-chan Job
+	// updated jobs are sent to this channel
+	UpdatedJob <-chan Job
+
+	newJob     chan Job
+	removedJob chan Job
+	updatedJob chan Job
+}
+
+func newSubscription() *ManagerSubscription {
+	ret := &ManagerSubscription{
+		newJob:     make(chan Job, subscriptionBufferSize),
+		removedJob: make(chan Job, subscriptionBufferSize),
+		updatedJob: make(chan Job, subscriptionBufferSize),
+	}
+
+	ret.NewJob = ret.newJob
+	ret.RemovedJob = ret.removedJob
+	ret.UpdatedJob = ret.updatedJob
+
+	return ret
+}
+
+func (s *ManagerSubscription) close() {
+	close(s.newJob)
+	close(s.removedJob)
+	close(s.updatedJob)
+}
+
+// Subscribe subscribes to changes to jobs in the manager queue. The
+// subscription is closed when the provided context is cancelled.
+func (m *Manager) Subscribe(ctx context.Context) *ManagerSubscription {
+	ret := newSubscription()
+
+	m.mutex.Lock()
+	m.subscriptions = append(m.subscriptions, ret)
+	m.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		for i, s := range m.subscriptions {
+			if s == ret {
+				m.subscriptions = append(m.subscriptions[:i], m.subscriptions[i+1:]...)
+				break
+			}
+		}
+
+		ret.close()
+	}()
+
+	return ret
+}
+
+// notify functions must be called with the mutex held.
+
+func (m *Manager) notifyNewJob(j *Job) {
+	for _, s := range m.subscriptions {
+		// don't block if the channel is full
+		select {
+		case s.newJob <- *j:
+		default:
+		}
+	}
+}
+
+func (m *Manager) notifyJobUpdate(j *Job) {
+	for _, s := range m.subscriptions {
+		select {
+		case s.updatedJob <- *j:
+		default:
+		}
+	}
+}
+
+func (m *Manager) notifyJobRemoved(j *Job) {
+	for _, s := range m.subscriptions {
+		select {
+		case s.removedJob <- *j:
+		default:
+		}
+	}
+}
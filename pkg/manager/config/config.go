@@ -9,6 +9,8 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -47,6 +49,10 @@ var defaultGalleryExtensions = []string{"zip", "cbz"}
 
 const CreateGalleriesFromFolders = "create_galleries_from_folders"
 
+// WatchStashPaths is the config key used to determine if the stash paths
+// should be watched for changes, which are then scanned automatically.
+const WatchStashPaths = "watch_stash_paths"
+
 // CalculateMD5 is the config key used to determine if MD5 should be calculated
 // for video files.
 const CalculateMD5 = "calculate_md5"
@@ -60,6 +66,21 @@ const PreviewPreset = "preview_preset"
 const MaxTranscodeSize = "max_transcode_size"
 const MaxStreamingTranscodeSize = "max_streaming_transcode_size"
 
+// MaxTranscodeSessions is the maximum number of concurrent live transcodes.
+// Zero means no limit.
+const MaxTranscodeSessions = "max_transcode_sessions"
+
+// TranscodeIdleTimeout is the time in seconds after which live transcodes
+// that have not been accessed are stopped.
+const TranscodeIdleTimeout = "transcode_idle_timeout"
+const transcodeIdleTimeoutDefault = 60
+
+// StreamSegmentCacheSize is the maximum size in MB of the cached HLS and DASH
+// stream segments. The least recently used segments are deleted when the
+// cache exceeds this size. Zero means no limit.
+const StreamSegmentCacheSize = "stream_segment_cache_size"
+const streamSegmentCacheSizeDefault = 10240
+
 const ParallelTasks = "parallel_tasks"
 const parallelTasksDefault = 1
 
@@ -90,6 +111,7 @@ const ScrapersPath = "scrapers_path"
 const ScraperUserAgent = "scraper_user_agent"
 const ScraperCertCheck = "scraper_cert_check"
 const ScraperCDPPath = "scraper_cdp_path"
+const ScraperSecrets = "scraper_secrets"
 
 // stash-box options
 const StashBoxes = "stash_boxes"
@@ -97,6 +119,10 @@ const StashBoxes = "stash_boxes"
 // plugin options
 const PluginsPath = "plugins_path"
 
+// scheduled tasks
+const ScheduledTasks = "scheduled_tasks"
+const ScheduledTasksLastRun = "scheduled_tasks_last_run"
+
 // i18n
 const Language = "language"
 
@@ -237,6 +263,10 @@ func GetCreateGalleriesFromFolders() bool {
 	return viper.GetBool(CreateGalleriesFromFolders)
 }
 
+func GetWatchStashPaths() bool {
+	return viper.GetBool(WatchStashPaths)
+}
+
 func GetLanguage() string {
 	ret := viper.GetString(Language)
 
@@ -292,12 +322,64 @@ func GetScraperCertCheck() bool {
 	return ret
 }
 
+// ScraperSecret holds the credentials used by scrapers that log in.
+type ScraperSecret struct {
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+// GetScraperSecrets returns the scraper credentials by name.
+func GetScraperSecrets() map[string]ScraperSecret {
+	var ret map[string]ScraperSecret
+	viper.UnmarshalKey(ScraperSecrets, &ret)
+	return ret
+}
+
 func GetStashBoxes() []*models.StashBox {
 	var boxes []*models.StashBox
 	viper.UnmarshalKey(StashBoxes, &boxes)
 	return boxes
 }
 
+// GetScheduledTasks returns the scheduled tasks stored in the configuration.
+func GetScheduledTasks() []*models.ScheduledTask {
+	var ret []*models.ScheduledTask
+	viper.UnmarshalKey(ScheduledTasks, &ret)
+	return ret
+}
+
+// GetScheduledTasksLastRun returns the last run times of the scheduled
+// tasks, keyed by task ID.
+func GetScheduledTasksLastRun() map[int]time.Time {
+	ret := make(map[int]time.Time)
+	for k, v := range viper.GetStringMapString(ScheduledTasksLastRun) {
+		id, err := strconv.Atoi(k)
+		if err != nil {
+			continue
+		}
+
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			continue
+		}
+
+		ret[id] = t
+	}
+
+	return ret
+}
+
+// SetScheduledTasksLastRun sets the last run times of the scheduled tasks,
+// keyed by task ID.
+func SetScheduledTasksLastRun(lastRun map[int]time.Time) {
+	m := make(map[string]string)
+	for id, t := range lastRun {
+		m[strconv.Itoa(id)] = t.Format(time.RFC3339)
+	}
+
+	Set(ScheduledTasksLastRun, m)
+}
+
 func GetDefaultPluginsPath() string {
 	// default to the same directory as the config file
 	fn := filepath.Join(GetConfigPath(), "plugins")
@@ -400,6 +482,29 @@ func GetMaxStreamingTranscodeSize() models.StreamingResolutionEnum {
 	return models.StreamingResolutionEnum(ret)
 }
 
+// GetMaxTranscodeSessions returns the maximum number of concurrent live
+// transcodes. Returns 0 if there is no limit.
+func GetMaxTranscodeSessions() int {
+	return viper.GetInt(MaxTranscodeSessions)
+}
+
+// GetTranscodeIdleTimeout returns the time in seconds after which live
+// transcodes that have not been accessed are stopped.
+func GetTranscodeIdleTimeout() int {
+	ret := viper.GetInt(TranscodeIdleTimeout)
+	if ret <= 0 {
+		return transcodeIdleTimeoutDefault
+	}
+
+	return ret
+}
+
+// GetStreamSegmentCacheSize returns the maximum size in MB of the cached
+// stream segments. Returns 0 if there is no limit.
+func GetStreamSegmentCacheSize() int {
+	return viper.GetInt(StreamSegmentCacheSize)
+}
+
 func GetAPIKey() string {
 	return viper.GetString(ApiKey)
 }
@@ -626,6 +731,8 @@ func setDefaultValues() {
 	viper.SetDefault(PreviewSegments, previewSegmentsDefault)
 	viper.SetDefault(PreviewExcludeStart, previewExcludeStartDefault)
 	viper.SetDefault(PreviewExcludeEnd, previewExcludeEndDefault)
+	viper.SetDefault(TranscodeIdleTimeout, transcodeIdleTimeoutDefault)
+	viper.SetDefault(StreamSegmentCacheSize, streamSegmentCacheSizeDefault)
 }
 
 // SetInitialConfig fills in missing required config fields
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledTasksLastRun(t *testing.T) {
+	defer Set(ScheduledTasksLastRun, nil)
+
+	assert.Len(t, GetScheduledTasksLastRun(), 0)
+
+	lastRun := map[int]time.Time{
+		1:  time.Date(2021, 3, 15, 10, 30, 0, 0, time.UTC),
+		12: time.Date(2021, 3, 16, 0, 0, 0, 0, time.UTC),
+	}
+	SetScheduledTasksLastRun(lastRun)
+	assert.Equal(t, lastRun, GetScheduledTasksLastRun())
+
+	// invalid entries are ignored
+	Set(ScheduledTasksLastRun, map[string]string{
+		"1":       "2021-03-15T10:30:00Z",
+		"invalid": "2021-03-15T10:30:00Z",
+		"2":       "invalid",
+	})
+	assert.Equal(t, map[int]time.Time{1: lastRun[1]}, GetScheduledTasksLastRun())
+}
@@ -594,6 +594,11 @@ func (p *SceneFilenameParser) queryTag(qb models.TagReader, tagName string) *mod
 	// match tag name exactly
 	ret, _ := qb.FindByName(tagName, true)
 
+	// try matching by alias
+	if ret == nil {
+		ret, _ = qb.FindByAlias(tagName, true)
+	}
+
 	// add result to cache
 	p.tagCache[tagName] = ret
 
@@ -35,26 +35,37 @@ type SceneMovie struct {
 	SceneIndex int    `json:"scene_index,omitempty"`
 }
 
+// ScenePlayHistory is the play activity of a user for a scene. User is empty
+// for activity recorded while authentication was not required.
+type ScenePlayHistory struct {
+	User         string          `json:"user,omitempty"`
+	PlayCount    int             `json:"play_count,omitempty"`
+	LastPlayedAt models.JSONTime `json:"last_played_at,omitempty"`
+	ResumeTime   float64         `json:"resume_time,omitempty"`
+	PlayDuration float64         `json:"play_duration,omitempty"`
+}
+
 type Scene struct {
-	Title      string          `json:"title,omitempty"`
-	Checksum   string          `json:"checksum,omitempty"`
-	OSHash     string          `json:"oshash,omitempty"`
-	Studio     string          `json:"studio,omitempty"`
-	URL        string          `json:"url,omitempty"`
-	Date       string          `json:"date,omitempty"`
-	Rating     int             `json:"rating,omitempty"`
-	Organized  bool            `json:"organized,omitempty"`
-	OCounter   int             `json:"o_counter,omitempty"`
-	Details    string          `json:"details,omitempty"`
-	Galleries  []string        `json:"galleries,omitempty"`
-	Performers []string        `json:"performers,omitempty"`
-	Movies     []SceneMovie    `json:"movies,omitempty"`
-	Tags       []string        `json:"tags,omitempty"`
-	Markers    []SceneMarker   `json:"markers,omitempty"`
-	File       *SceneFile      `json:"file,omitempty"`
-	Cover      string          `json:"cover,omitempty"`
-	CreatedAt  models.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  models.JSONTime `json:"updated_at,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Checksum    string             `json:"checksum,omitempty"`
+	OSHash      string             `json:"oshash,omitempty"`
+	Studio      string             `json:"studio,omitempty"`
+	URL         string             `json:"url,omitempty"`
+	Date        string             `json:"date,omitempty"`
+	Rating      int                `json:"rating,omitempty"`
+	Organized   bool               `json:"organized,omitempty"`
+	OCounter    int                `json:"o_counter,omitempty"`
+	PlayHistory []ScenePlayHistory `json:"play_history,omitempty"`
+	Details     string             `json:"details,omitempty"`
+	Galleries   []string           `json:"galleries,omitempty"`
+	Performers  []string           `json:"performers,omitempty"`
+	Movies      []SceneMovie       `json:"movies,omitempty"`
+	Tags        []string           `json:"tags,omitempty"`
+	Markers     []SceneMarker      `json:"markers,omitempty"`
+	File        *SceneFile         `json:"file,omitempty"`
+	Cover       string             `json:"cover,omitempty"`
+	CreatedAt   models.JSONTime    `json:"created_at,omitempty"`
+	UpdatedAt   models.JSONTime    `json:"updated_at,omitempty"`
 }
 
 func LoadSceneFile(filePath string) (*Scene, error) {
@@ -10,6 +10,7 @@ import (
 
 type Tag struct {
 	Name      string          `json:"name,omitempty"`
+	Aliases   []string        `json:"aliases,omitempty"`
 	Image     string          `json:"image,omitempty"`
 	CreatedAt models.JSONTime `json:"created_at,omitempty"`
 	UpdatedAt models.JSONTime `json:"updated_at,omitempty"`
@@ -7,19 +7,20 @@ import (
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/manager/paths"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scheduler"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
 type singleton struct {
-	Status TaskStatus
-	Paths  *paths.Paths
+	Paths *paths.Paths
 
 	FFMPEGPath  string
 	FFProbePath string
@@ -30,6 +31,15 @@ type singleton struct {
 	DownloadStore *DownloadStore
 
 	TxnManager models.TransactionManager
+
+	JobManager *job.Manager
+	Scheduler  *scheduler.Scheduler
+
+	// scheduledTasksMutex serializes changes to the scheduled tasks and
+	// their last run times in the configuration
+	scheduledTasksMutex sync.Mutex
+
+	watcher *watcher
 }
 
 var instance *singleton
@@ -54,13 +64,14 @@ func Initialize() *singleton {
 		initLog()
 		initEnvs()
 		instance = &singleton{
-			Status: TaskStatus{Status: Idle, Progress: -1},
-			Paths:  paths.NewPaths(),
+			Paths: paths.NewPaths(),
 
 			PluginCache: initPluginCache(),
 
 			DownloadStore: NewDownloadStore(),
 			TxnManager:    sqlite.NewTransactionManager(),
+			JobManager:    job.NewManager(),
+			Scheduler:     scheduler.New(),
 		}
 		instance.ScraperCache = instance.initScraperCache()
 
@@ -74,6 +85,12 @@ func Initialize() *singleton {
 		}
 
 		initFFMPEG()
+
+		instance.RefreshScheduledTasks()
+		instance.Scheduler.OnRun(instance.saveScheduledTaskLastRun)
+		instance.Scheduler.Start()
+
+		instance.RefreshWatcher()
 	})
 
 	return instance
@@ -187,14 +204,19 @@ func initPluginCache() *plugin.Cache {
 	return ret
 }
 
-// initScraperCache initializes a new scraper cache and returns it.
-func (s *singleton) initScraperCache() *scraper.Cache {
-	scraperConfig := scraper.GlobalConfig{
+func scraperGlobalConfig() scraper.GlobalConfig {
+	return scraper.GlobalConfig{
 		Path:      config.GetScrapersPath(),
 		UserAgent: config.GetScraperUserAgent(),
 		CDPPath:   config.GetScraperCDPPath(),
+		CachePath: config.GetCachePath(),
+		Secrets:   config.GetScraperSecrets(),
 	}
-	ret, err := scraper.NewCache(scraperConfig, s.TxnManager)
+}
+
+// initScraperCache initializes a new scraper cache and returns it.
+func (s *singleton) initScraperCache() *scraper.Cache {
+	ret, err := scraper.NewCache(scraperGlobalConfig(), s.TxnManager)
 
 	if err != nil {
 		logger.Errorf("Error reading scraper configs: %s", err.Error())
@@ -210,13 +232,23 @@ func (s *singleton) RefreshConfig() {
 		utils.EnsureDir(s.Paths.Generated.Vtt)
 		utils.EnsureDir(s.Paths.Generated.Markers)
 		utils.EnsureDir(s.Paths.Generated.Transcodes)
+		utils.EnsureDir(s.Paths.Generated.Segments)
 		utils.EnsureDir(s.Paths.Generated.Downloads)
 		paths.EnsureJSONDirs(config.GetMetadataPath())
 	}
 }
 
 // RefreshScraperCache refreshes the scraper cache. Call this when scraper
-// configuration changes.
+// configuration changes. The existing cache is reloaded, so that the rate
+// limiters and login sessions of the scrapers are kept.
 func (s *singleton) RefreshScraperCache() {
-	s.ScraperCache = s.initScraperCache()
+	if s.ScraperCache == nil {
+		s.ScraperCache = s.initScraperCache()
+		return
+	}
+
+	s.ScraperCache.UpdateConfig(scraperGlobalConfig())
+	if err := s.ScraperCache.ReloadScrapers(); err != nil {
+		logger.Errorf("Error reading scraper configs: %s", err.Error())
+	}
 }
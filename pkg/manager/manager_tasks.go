@@ -11,9 +11,11 @@ import (
 
 	"github.com/remeh/sizedwaitgroup"
 
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/savedfilter"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -32,56 +34,6 @@ func isImage(pathname string) bool {
 	return matchExtension(pathname, imgExt)
 }
 
-type TaskStatus struct {
-	Status     JobStatus
-	Progress   float64
-	LastUpdate time.Time
-	stopping   bool
-	upTo       int
-	total      int
-}
-
-func (t *TaskStatus) Stop() bool {
-	t.stopping = true
-	t.updated()
-	return true
-}
-
-func (t *TaskStatus) SetStatus(s JobStatus) {
-	t.Status = s
-	t.updated()
-}
-
-func (t *TaskStatus) setProgress(upTo int, total int) {
-	if total == 0 {
-		t.Progress = 1
-	}
-	t.upTo = upTo
-	t.total = total
-	t.Progress = float64(upTo) / float64(total)
-	t.updated()
-}
-
-func (t *TaskStatus) setProgressPercent(progress float64) {
-	if progress != t.Progress {
-		t.Progress = progress
-		t.updated()
-	}
-}
-
-func (t *TaskStatus) incrementProgress() {
-	t.setProgress(t.upTo+1, t.total)
-}
-
-func (t *TaskStatus) indefiniteProgress() {
-	t.Progress = -1
-	t.updated()
-}
-
-func (t *TaskStatus) updated() {
-	t.LastUpdate = time.Now()
-}
-
 func getScanPaths(inputPaths []string) []*models.StashConfig {
 	if len(inputPaths) == 0 {
 		return config.GetStashPaths()
@@ -104,7 +56,7 @@ func getScanPaths(inputPaths []string) []*models.StashConfig {
 	return ret
 }
 
-func (s *singleton) neededScan(paths []*models.StashConfig) (total *int, newFiles *int) {
+func (s *singleton) neededScan(ctx context.Context, paths []*models.StashConfig) (total *int, newFiles *int) {
 	const timeout = 90 * time.Second
 
 	// create a control channel through which to signal the counting loop when the timeout is reached
@@ -133,7 +85,7 @@ func (s *singleton) neededScan(paths []*models.StashConfig) (total *int, newFile
 			}
 
 			// check stop
-			if s.Status.stopping {
+			if job.IsCancelled(ctx) {
 				return timeoutErr
 			}
 
@@ -154,23 +106,15 @@ func (s *singleton) neededScan(paths []*models.StashConfig) (total *int, newFile
 	return &t, &n
 }
 
-func (s *singleton) Scan(input models.ScanMetadataInput) {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(Scan)
-	s.Status.indefiniteProgress()
-
-	go func() {
-		defer s.returnToIdleState()
-
+func (s *singleton) Scan(ctx context.Context, input models.ScanMetadataInput) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
 		paths := getScanPaths(input.Paths)
 
-		total, newFiles := s.neededScan(paths)
+		total, newFiles := s.neededScan(ctx, paths)
 
-		if s.Status.stopping {
+		if job.IsCancelled(ctx) {
 			logger.Info("Stopping due to user request")
-			return
+			return nil
 		}
 
 		if total == nil || newFiles == nil {
@@ -185,9 +129,13 @@ func (s *singleton) Scan(input models.ScanMetadataInput) {
 		logger.Infof("Scan started with %d parallel tasks", parallelTasks)
 		wg := sizedwaitgroup.New(parallelTasks)
 
-		s.Status.Progress = 0
+		if total != nil {
+			progress.SetTotal(*total)
+		}
+
 		fileNamingAlgo := config.GetVideoFileNamingAlgorithm()
 		calculateMD5 := config.IsCalculateMD5()
+		summary := &scanSummary{}
 
 		i := 0
 		stoppingErr := errors.New("stopping")
@@ -197,11 +145,11 @@ func (s *singleton) Scan(input models.ScanMetadataInput) {
 		for _, sp := range paths {
 			err := walkFilesToScan(sp, func(path string, info os.FileInfo, err error) error {
 				if total != nil {
-					s.Status.setProgress(i, *total)
+					progress.SetProcessed(i)
 					i++
 				}
 
-				if s.Status.stopping {
+				if job.IsCancelled(ctx) {
 					return stoppingErr
 				}
 
@@ -222,6 +170,9 @@ func (s *singleton) Scan(input models.ScanMetadataInput) {
 					GeneratePreview:      utils.IsTrue(input.ScanGeneratePreviews),
 					GenerateImagePreview: utils.IsTrue(input.ScanGenerateImagePreviews),
 					GenerateSprite:       utils.IsTrue(input.ScanGenerateSprites),
+					GeneratePhash:        utils.IsTrue(input.ScanGeneratePhashes),
+					pluginCache:          s.PluginCache,
+					summary:              summary,
 				}
 				go task.Start(&wg)
 
@@ -233,21 +184,27 @@ func (s *singleton) Scan(input models.ScanMetadataInput) {
 			}
 
 			if err != nil {
-				logger.Errorf("Error encountered scanning files: %s", err.Error())
-				return
+				// wait for the running tasks so that they do not overlap
+				// with the next job
+				wg.Wait()
+				return fmt.Errorf("error encountered scanning files: %s", err.Error())
 			}
 		}
 
-		if s.Status.stopping {
+		// always wait for the running tasks, so that they do not overlap
+		// with the next job when stopping
+		wg.Wait()
+
+		if job.IsCancelled(ctx) {
 			logger.Info("Stopping due to user request")
-			return
+			return nil
 		}
 
-		wg.Wait()
 		instance.Paths.Generated.EmptyTmpDir()
 
 		elapsed := time.Since(start)
-		logger.Info(fmt.Sprintf("Scan finished (%s)", elapsed))
+		logger.Info(fmt.Sprintf("Scan finished (%s). %s", elapsed, summary))
+		progress.SetDescription(fmt.Sprintf("Scanning... (%s)", summary))
 
 		for _, path := range galleries {
 			wg.Add()
@@ -260,19 +217,15 @@ func (s *singleton) Scan(input models.ScanMetadataInput) {
 			wg.Wait()
 		}
 		logger.Info("Finished gallery association")
-	}()
-}
 
-func (s *singleton) Import() {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(Import)
-	s.Status.indefiniteProgress()
+		return nil
+	})
 
-	go func() {
-		defer s.returnToIdleState()
+	return s.JobManager.Add(ctx, "Scanning...", j)
+}
 
+func (s *singleton) Import(ctx context.Context) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
 		var wg sync.WaitGroup
 		wg.Add(1)
 		task := ImportTask{
@@ -285,19 +238,15 @@ func (s *singleton) Import() {
 		}
 		go task.Start(&wg)
 		wg.Wait()
-	}()
-}
 
-func (s *singleton) Export() {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(Export)
-	s.Status.indefiniteProgress()
+		return nil
+	})
 
-	go func() {
-		defer s.returnToIdleState()
+	return s.JobManager.Add(ctx, "Importing...", j)
+}
 
+func (s *singleton) Export(ctx context.Context) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
 		var wg sync.WaitGroup
 		wg.Add(1)
 		task := ExportTask{
@@ -307,27 +256,25 @@ func (s *singleton) Export() {
 		}
 		go task.Start(&wg)
 		wg.Wait()
-	}()
-}
 
-func (s *singleton) RunSingleTask(t Task) (*sync.WaitGroup, error) {
-	if s.Status.Status != Idle {
-		return nil, errors.New("task already running")
-	}
+		return nil
+	})
 
-	s.Status.SetStatus(t.GetStatus())
-	s.Status.indefiniteProgress()
-	var wg sync.WaitGroup
-	wg.Add(1)
-
-	go func() {
-		defer s.returnToIdleState()
+	return s.JobManager.Add(ctx, "Exporting...", j)
+}
 
+// RunSingleTask queues the provided task as a job. Returns the job ID.
+func (s *singleton) RunSingleTask(ctx context.Context, t Task) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		var wg sync.WaitGroup
+		wg.Add(1)
 		go t.Start(&wg)
 		wg.Wait()
-	}()
 
-	return &wg, nil
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, t.GetDescription(), j)
 }
 
 func setGeneratePreviewOptionsInput(optionsInput *models.GeneratePreviewOptionsInput) {
@@ -357,16 +304,7 @@ func setGeneratePreviewOptionsInput(optionsInput *models.GeneratePreviewOptionsI
 	}
 }
 
-func (s *singleton) Generate(input models.GenerateMetadataInput) {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(Generate)
-	s.Status.indefiniteProgress()
-
-	//this.job.total = await ObjectionUtils.getCount(Scene);
-	instance.Paths.Generated.EnsureTmpDir()
-
+func (s *singleton) Generate(ctx context.Context, input models.GenerateMetadataInput) int {
 	sceneIDs, err := utils.StringSliceToIntSlice(input.SceneIDs)
 	if err != nil {
 		logger.Error(err.Error())
@@ -376,16 +314,28 @@ func (s *singleton) Generate(input models.GenerateMetadataInput) {
 		logger.Error(err.Error())
 	}
 
-	go func() {
-		defer s.returnToIdleState()
+	// saved filter criteria depend on the user that queued the task. The job
+	// outlives the request, so only the user is carried over.
+	currentUser := models.CurrentUser(ctx)
+
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		instance.Paths.Generated.EnsureTmpDir()
+		defer instance.Paths.Generated.RemoveTmpDir()
 
 		var scenes []*models.Scene
 		var err error
 		var markers []*models.SceneMarker
 
-		if err := s.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		if err := s.TxnManager.WithReadTxn(models.WithCurrentUser(ctx, currentUser), func(r models.ReaderRepository) error {
 			qb := r.Scene()
-			if len(sceneIDs) > 0 {
+			if input.SavedFilterID != nil {
+				filterSceneIDs, err := savedfilter.FindSceneIDs(r.SavedFilter(), qb, *input.SavedFilterID)
+				if err != nil {
+					return err
+				}
+				sceneIDs = utils.IntAppendUniques(sceneIDs, filterSceneIDs)
+				scenes, err = qb.FindMany(sceneIDs)
+			} else if len(sceneIDs) > 0 {
 				scenes, err = qb.FindMany(sceneIDs)
 			} else {
 				scenes, err = qb.All()
@@ -404,8 +354,7 @@ func (s *singleton) Generate(input models.GenerateMetadataInput) {
 
 			return nil
 		}); err != nil {
-			logger.Error(err.Error())
-			return
+			return err
 		}
 
 		parallelTasks := config.GetParallelTasksWithAutoDetection()
@@ -413,21 +362,21 @@ func (s *singleton) Generate(input models.GenerateMetadataInput) {
 		logger.Infof("Generate started with %d parallel tasks", parallelTasks)
 		wg := sizedwaitgroup.New(parallelTasks)
 
-		s.Status.Progress = 0
 		lenScenes := len(scenes)
 		total := lenScenes + len(markers)
+		progress.SetTotal(total)
 
-		if s.Status.stopping {
+		if job.IsCancelled(ctx) {
 			logger.Info("Stopping due to user request")
-			return
+			return nil
 		}
 
-		totalsNeeded := s.neededGenerate(scenes, input)
+		totalsNeeded := s.neededGenerate(ctx, scenes, input)
 		if totalsNeeded == nil {
 			logger.Infof("Taking too long to count content. Skipping...")
 			logger.Infof("Generating content")
 		} else {
-			logger.Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes", totalsNeeded.sprites, totalsNeeded.previews, totalsNeeded.imagePreviews, totalsNeeded.markers, totalsNeeded.transcodes)
+			logger.Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes %d phashes", totalsNeeded.sprites, totalsNeeded.previews, totalsNeeded.imagePreviews, totalsNeeded.markers, totalsNeeded.transcodes, totalsNeeded.phashes)
 		}
 
 		fileNamingAlgo := config.GetVideoFileNamingAlgorithm()
@@ -448,10 +397,11 @@ func (s *singleton) Generate(input models.GenerateMetadataInput) {
 		instance.Paths.Generated.EnsureTmpDir()
 
 		for i, scene := range scenes {
-			s.Status.setProgress(i, total)
-			if s.Status.stopping {
+			progress.SetProcessed(i)
+			if job.IsCancelled(ctx) {
 				logger.Info("Stopping due to user request")
-				return
+				wg.Wait()
+				return nil
 			}
 
 			if scene == nil {
@@ -501,15 +451,26 @@ func (s *singleton) Generate(input models.GenerateMetadataInput) {
 				}
 				go task.Start(&wg)
 			}
+
+			if utils.IsTrue(input.Phashes) {
+				task := GeneratePhashTask{
+					Scene:      *scene,
+					Overwrite:  overwrite,
+					txnManager: s.TxnManager,
+				}
+				wg.Add()
+				go task.Start(&wg)
+			}
 		}
 
 		wg.Wait()
 
 		for i, marker := range markers {
-			s.Status.setProgress(lenScenes+i, total)
-			if s.Status.stopping {
+			progress.SetProcessed(lenScenes + i)
+			if job.IsCancelled(ctx) {
 				logger.Info("Stopping due to user request")
-				return
+				wg.Wait()
+				return nil
 			}
 
 			if marker == nil {
@@ -532,34 +493,27 @@ func (s *singleton) Generate(input models.GenerateMetadataInput) {
 		instance.Paths.Generated.EmptyTmpDir()
 		elapsed := time.Since(start)
 		logger.Info(fmt.Sprintf("Generate finished (%s)", elapsed))
-	}()
+
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Generating...", j)
 }
 
-func (s *singleton) GenerateDefaultScreenshot(sceneId string) {
-	s.generateScreenshot(sceneId, nil)
+func (s *singleton) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
+	return s.generateScreenshot(ctx, sceneId, nil)
 }
 
-func (s *singleton) GenerateScreenshot(sceneId string, at float64) {
-	s.generateScreenshot(sceneId, &at)
+func (s *singleton) GenerateScreenshot(ctx context.Context, sceneId string, at float64) int {
+	return s.generateScreenshot(ctx, sceneId, &at)
 }
 
 // generate default screenshot if at is nil
-func (s *singleton) generateScreenshot(sceneId string, at *float64) {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(Generate)
-	s.Status.indefiniteProgress()
-
-	instance.Paths.Generated.EnsureTmpDir()
-
-	go func() {
-		defer s.returnToIdleState()
-
+func (s *singleton) generateScreenshot(ctx context.Context, sceneId string, at *float64) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
 		sceneIdInt, err := strconv.Atoi(sceneId)
 		if err != nil {
-			logger.Errorf("Error parsing scene id %s: %s", sceneId, err.Error())
-			return
+			return fmt.Errorf("error parsing scene id %s: %s", sceneId, err.Error())
 		}
 
 		var scene *models.Scene
@@ -567,9 +521,12 @@ func (s *singleton) generateScreenshot(sceneId string, at *float64) {
 			var err error
 			scene, err = r.Scene().Find(sceneIdInt)
 			return err
-		}); err != nil || scene == nil {
-			logger.Errorf("failed to get scene for generate: %s", err.Error())
-			return
+		}); err != nil {
+			return fmt.Errorf("failed to get scene for generate: %s", err.Error())
+		}
+
+		if scene == nil {
+			return fmt.Errorf("scene with id %s not found", sceneId)
 		}
 
 		task := GenerateScreenshotTask{
@@ -585,20 +542,17 @@ func (s *singleton) generateScreenshot(sceneId string, at *float64) {
 
 		wg.Wait()
 
-		logger.Infof("Generate finished")
-	}()
-}
+		logger.Infof("Generate screenshot finished")
 
-func (s *singleton) AutoTag(input models.AutoTagMetadataInput) {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(AutoTag)
-	s.Status.indefiniteProgress()
+		return nil
+	})
 
-	go func() {
-		defer s.returnToIdleState()
+	// screenshot generation is quick and independent of other jobs
+	return s.JobManager.Start(ctx, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j)
+}
 
+func (s *singleton) AutoTag(ctx context.Context, input models.AutoTagMetadataInput) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
 		performerIds := input.Performers
 		studioIds := input.Studios
 		tagIds := input.Tags
@@ -636,22 +590,30 @@ func (s *singleton) AutoTag(input models.AutoTagMetadataInput) {
 
 			return nil
 		}); err != nil {
-			logger.Error(err.Error())
-			return
+			return err
 		}
 
 		total := performerCount + studioCount + tagCount
-		s.Status.setProgress(0, total)
+		progress.SetTotal(total)
 
-		s.autoTagPerformers(input.Paths, performerIds)
-		s.autoTagStudios(input.Paths, studioIds)
-		s.autoTagTags(input.Paths, tagIds)
-	}()
+		s.autoTagPerformers(ctx, progress, input.Paths, performerIds)
+		s.autoTagStudios(ctx, progress, input.Paths, studioIds)
+		s.autoTagTags(ctx, progress, input.Paths, tagIds)
+
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Auto-tagging...", j)
 }
 
-func (s *singleton) autoTagPerformers(paths []string, performerIds []string) {
+func (s *singleton) autoTagPerformers(ctx context.Context, progress *job.Progress, paths []string, performerIds []string) {
 	var wg sync.WaitGroup
 	for _, performerId := range performerIds {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
 		var performers []*models.Performer
 
 		if err := s.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
@@ -694,14 +656,19 @@ func (s *singleton) autoTagPerformers(paths []string, performerIds []string) {
 			go task.Start(&wg)
 			wg.Wait()
 
-			s.Status.incrementProgress()
+			progress.Increment()
 		}
 	}
 }
 
-func (s *singleton) autoTagStudios(paths []string, studioIds []string) {
+func (s *singleton) autoTagStudios(ctx context.Context, progress *job.Progress, paths []string, studioIds []string) {
 	var wg sync.WaitGroup
 	for _, studioId := range studioIds {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
 		var studios []*models.Studio
 
 		if err := s.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
@@ -743,14 +710,19 @@ func (s *singleton) autoTagStudios(paths []string, studioIds []string) {
 			go task.Start(&wg)
 			wg.Wait()
 
-			s.Status.incrementProgress()
+			progress.Increment()
 		}
 	}
 }
 
-func (s *singleton) autoTagTags(paths []string, tagIds []string) {
+func (s *singleton) autoTagTags(ctx context.Context, progress *job.Progress, paths []string, tagIds []string) {
 	var wg sync.WaitGroup
 	for _, tagId := range tagIds {
+		if job.IsCancelled(ctx) {
+			logger.Info("Stopping due to user request")
+			return
+		}
+
 		var tags []*models.Tag
 		if err := s.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
 			tagQuery := r.Tag()
@@ -791,22 +763,15 @@ func (s *singleton) autoTagTags(paths []string, tagIds []string) {
 			go task.Start(&wg)
 			wg.Wait()
 
-			s.Status.incrementProgress()
+			progress.Increment()
 		}
 	}
 }
 
-func (s *singleton) Clean(input models.CleanMetadataInput) {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(Clean)
-	s.Status.indefiniteProgress()
-
-	go func() {
-		defer s.returnToIdleState()
-
+func (s *singleton) Clean(ctx context.Context, input models.CleanMetadataInput) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
 		var scenes []*models.Scene
+		var sceneFiles []*models.File
 		var images []*models.Image
 		var galleries []*models.Gallery
 
@@ -837,26 +802,37 @@ func (s *singleton) Clean(input models.CleanMetadataInput) {
 				return errors.New("failed to fetch list of galleries for cleaning")
 			}
 
+			// the secondary files of scenes are needed to filter by path
+			if len(input.Paths) > 0 {
+				sceneFiles, err = r.File().All()
+				if err != nil {
+					return errors.New("failed to fetch list of scene files for cleaning")
+				}
+			}
+
 			return nil
 		}); err != nil {
-			logger.Error(err.Error())
-			return
+			return err
 		}
 
-		if s.Status.stopping {
+		if len(input.Paths) > 0 {
+			scenes, images, galleries = filterCleanPaths(input.Paths, scenes, sceneFiles, images, galleries)
+		}
+
+		if job.IsCancelled(ctx) {
 			logger.Info("Stopping due to user request")
-			return
+			return nil
 		}
 
 		var wg sync.WaitGroup
-		s.Status.Progress = 0
 		total := len(scenes) + len(images) + len(galleries)
+		progress.SetTotal(total)
 		fileNamingAlgo := config.GetVideoFileNamingAlgorithm()
 		for i, scene := range scenes {
-			s.Status.setProgress(i, total)
-			if s.Status.stopping {
+			progress.SetProcessed(i)
+			if job.IsCancelled(ctx) {
 				logger.Info("Stopping due to user request")
-				return
+				return nil
 			}
 
 			if scene == nil {
@@ -876,10 +852,10 @@ func (s *singleton) Clean(input models.CleanMetadataInput) {
 		}
 
 		for i, img := range images {
-			s.Status.setProgress(len(scenes)+i, total)
-			if s.Status.stopping {
+			progress.SetProcessed(len(scenes) + i)
+			if job.IsCancelled(ctx) {
 				logger.Info("Stopping due to user request")
-				return
+				return nil
 			}
 
 			if img == nil {
@@ -898,10 +874,10 @@ func (s *singleton) Clean(input models.CleanMetadataInput) {
 		}
 
 		for i, gallery := range galleries {
-			s.Status.setProgress(len(scenes)+len(galleries)+i, total)
-			if s.Status.stopping {
+			progress.SetProcessed(len(scenes) + len(galleries) + i)
+			if job.IsCancelled(ctx) {
 				logger.Info("Stopping due to user request")
-				return
+				return nil
 			}
 
 			if gallery == nil {
@@ -920,19 +896,15 @@ func (s *singleton) Clean(input models.CleanMetadataInput) {
 		}
 
 		logger.Info("Finished Cleaning")
-	}()
-}
 
-func (s *singleton) MigrateHash() {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(Migrate)
-	s.Status.indefiniteProgress()
+		return nil
+	})
 
-	go func() {
-		defer s.returnToIdleState()
+	return s.JobManager.Add(ctx, "Cleaning...", j)
+}
 
+func (s *singleton) MigrateHash(ctx context.Context) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
 		fileNamingAlgo := config.GetVideoFileNamingAlgorithm()
 		logger.Infof("Migrating generated files for %s naming hash", fileNamingAlgo.String())
 
@@ -942,19 +914,18 @@ func (s *singleton) MigrateHash() {
 			scenes, err = r.Scene().All()
 			return err
 		}); err != nil {
-			logger.Errorf("failed to fetch list of scenes for migration: %s", err.Error())
-			return
+			return fmt.Errorf("failed to fetch list of scenes for migration: %s", err.Error())
 		}
 
 		var wg sync.WaitGroup
-		s.Status.Progress = 0
 		total := len(scenes)
+		progress.SetTotal(total)
 
 		for i, scene := range scenes {
-			s.Status.setProgress(i, total)
-			if s.Status.stopping {
+			progress.SetProcessed(i)
+			if job.IsCancelled(ctx) {
 				logger.Info("Stopping due to user request")
-				return
+				return nil
 			}
 
 			if scene == nil {
@@ -970,20 +941,11 @@ func (s *singleton) MigrateHash() {
 		}
 
 		logger.Info("Finished migrating")
-	}()
-}
 
-func (s *singleton) returnToIdleState() {
-	if r := recover(); r != nil {
-		logger.Info("recovered from ", r)
-	}
+		return nil
+	})
 
-	if s.Status.Status == Generate {
-		instance.Paths.Generated.RemoveTmpDir()
-	}
-	s.Status.SetStatus(Idle)
-	s.Status.indefiniteProgress()
-	s.Status.stopping = false
+	return s.JobManager.Add(ctx, "Migrating scene hashes...", j)
 }
 
 type totalsGenerate struct {
@@ -992,9 +954,10 @@ type totalsGenerate struct {
 	imagePreviews int64
 	markers       int64
 	transcodes    int64
+	phashes       int64
 }
 
-func (s *singleton) neededGenerate(scenes []*models.Scene, input models.GenerateMetadataInput) *totalsGenerate {
+func (s *singleton) neededGenerate(ctx context.Context, scenes []*models.Scene, input models.GenerateMetadataInput) *totalsGenerate {
 
 	var totals totalsGenerate
 	const timeout = 90 * time.Second
@@ -1065,6 +1028,17 @@ func (s *singleton) neededGenerate(scenes []*models.Scene, input models.Generate
 					totals.transcodes++
 				}
 			}
+
+			if utils.IsTrue(input.Phashes) {
+				task := GeneratePhashTask{
+					Scene:     *scene,
+					Overwrite: overwrite,
+				}
+
+				if task.shouldGenerate() {
+					totals.phashes++
+				}
+			}
 		}
 		//check for timeout
 		select {
@@ -1073,6 +1047,10 @@ func (s *singleton) neededGenerate(scenes []*models.Scene, input models.Generate
 		default:
 		}
 
+		// check stop
+		if job.IsCancelled(ctx) {
+			return nil
+		}
 	}
 	return &totals
 }
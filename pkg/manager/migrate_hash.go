@@ -34,6 +34,10 @@ func MigrateHash(oldHash string, newHash string) {
 	newPath = scenePaths.GetTranscodePath(newHash)
 	migrate(oldPath, newPath)
 
+	oldPath = scenePaths.GetStreamSegmentsPath(oldHash)
+	newPath = scenePaths.GetStreamSegmentsPath(newHash)
+	migrate(oldPath, newPath)
+
 	oldPath = scenePaths.GetSpriteVttFilePath(oldHash)
 	newPath = scenePaths.GetSpriteVttFilePath(newHash)
 	migrate(oldPath, newPath)
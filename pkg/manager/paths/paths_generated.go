@@ -18,6 +18,7 @@ type generatedPaths struct {
 	Vtt         string
 	Markers     string
 	Transcodes  string
+	Segments    string
 	Downloads   string
 	Tmp         string
 }
@@ -29,6 +30,7 @@ func newGeneratedPaths() *generatedPaths {
 	gp.Vtt = filepath.Join(config.GetGeneratedPath(), "vtt")
 	gp.Markers = filepath.Join(config.GetGeneratedPath(), "markers")
 	gp.Transcodes = filepath.Join(config.GetGeneratedPath(), "transcodes")
+	gp.Segments = filepath.Join(config.GetGeneratedPath(), "segments")
 	gp.Downloads = filepath.Join(config.GetGeneratedPath(), "download_stage")
 	gp.Tmp = filepath.Join(config.GetGeneratedPath(), "tmp")
 	return &gp
@@ -27,6 +27,12 @@ func (sp *scenePaths) GetTranscodePath(checksum string) string {
 	return filepath.Join(sp.generated.Transcodes, checksum+".mp4")
 }
 
+// GetStreamSegmentsPath returns the directory containing the cached HLS and
+// DASH stream segments of the scene.
+func (sp *scenePaths) GetStreamSegmentsPath(checksum string) string {
+	return filepath.Join(sp.generated.Segments, checksum)
+}
+
 func (sp *scenePaths) GetStreamPath(scenePath string, checksum string) string {
 	transcodePath := sp.GetTranscodePath(checksum)
 	transcodeExists, _ := utils.FileExists(transcodePath)
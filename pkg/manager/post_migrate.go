@@ -1,6 +1,19 @@
 package manager
 
+import (
+	"github.com/stashapp/stash/pkg/logger"
+)
+
 // PostMigrate is executed after migrations have been executed.
 func (s *singleton) PostMigrate() {
 	setInitialMD5Config(s.TxnManager)
+	s.RefreshConfigUser()
+}
+
+// RefreshConfigUser adds the configured credentials to the users. It must
+// not be called until the database has been migrated.
+func (s *singleton) RefreshConfigUser() {
+	if err := SyncConfigUser(s.TxnManager); err != nil {
+		logger.Errorf("Error adding configured user: %s", err.Error())
+	}
 }
@@ -41,7 +41,11 @@ func WaitAndDeregisterStream(filepath string, w *http.ResponseWriter, r *http.Re
 	}()
 }
 
+// KillRunningStreams stops everything reading the file with the provided
+// path, so that it can be moved or deleted: the live transcodes of the file,
+// the encoders generating files from it, and the direct streams of it.
 func KillRunningStreams(path string) {
+	streamSessions.stopPath(path)
 	ffmpeg.KillRunningEncoders(path)
 
 	streamingFilesMutex.RLock()
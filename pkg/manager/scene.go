@@ -1,9 +1,12 @@
 package manager
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/logger"
@@ -124,6 +127,18 @@ func DeleteGeneratedSceneFiles(scene *models.Scene, fileNamingAlgo models.HashAl
 		}
 	}
 
+	segmentsPath := GetInstance().Paths.Scene.GetStreamSegmentsPath(sceneHash)
+	exists, _ = utils.FileExists(segmentsPath)
+	if exists {
+		// stop any running segmenters
+		KillRunningStreams(scene.Path)
+
+		err := os.RemoveAll(segmentsPath)
+		if err != nil {
+			logger.Warnf("Could not delete folder %s: %s", segmentsPath, err.Error())
+		}
+	}
+
 	spritePath := GetInstance().Paths.Scene.GetSpriteImageFilePath(sceneHash)
 	exists, _ = utils.FileExists(spritePath)
 	if exists {
@@ -166,14 +181,320 @@ func DeleteSceneMarkerFiles(scene *models.Scene, seconds int, fileNamingAlgo mod
 	}
 }
 
-// DeleteSceneFile deletes the scene video file from the filesystem.
-func DeleteSceneFile(scene *models.Scene) {
-	// kill any running encoders
-	KillRunningStreams(scene.Path)
+// DeleteSceneFiles deletes the scene video files from the filesystem.
+func DeleteSceneFiles(files []*models.File) {
+	for _, f := range files {
+		// kill any running encoders
+		KillRunningStreams(f.Path)
+
+		err := os.Remove(f.Path)
+		if err != nil {
+			logger.Warnf("Could not delete file %s: %s", f.Path, err.Error())
+		}
+	}
+}
 
-	err := os.Remove(scene.Path)
+// moveSceneFiles moves the files of the source scene to the destination
+// scene as secondary files. Returns the moved files.
+func moveSceneFiles(fqb models.FileReaderWriter, sourceID int, destinationID int) ([]*models.File, error) {
+	files, err := fqb.FindBySceneID(sourceID)
 	if err != nil {
-		logger.Warnf("Could not delete file %s: %s", scene.Path, err.Error())
+		return nil, err
+	}
+
+	for _, f := range files {
+		f.SceneID = destinationID
+		f.Primary = false
+		if _, err := fqb.Update(*f); err != nil {
+			return nil, err
+		}
+	}
+
+	return files, nil
+}
+
+// MergeScenes merges the source scenes into the destination scene. The tags,
+// performers, movies, galleries, markers and stash IDs of the source scenes
+// are added to the destination scene, and their o-counters are added to the
+// destination o-counter. The source files are moved to the destination scene,
+// or deleted if deleteFiles is true. The source scenes are then destroyed.
+// Returns a function to perform any post-commit actions.
+func MergeScenes(repo models.Repository, sourceIDs []int, destinationID int, deleteFiles bool) (func(), error) {
+	qb := repo.Scene()
+	mqb := repo.SceneMarker()
+	fqb := repo.File()
+
+	destination, err := qb.Find(destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if destination == nil {
+		return nil, fmt.Errorf("scene with id %d not found", destinationID)
+	}
+
+	performerIDs, err := qb.GetPerformerIDs(destinationID)
+	if err != nil {
+		return nil, err
+	}
+	tagIDs, err := qb.GetTagIDs(destinationID)
+	if err != nil {
+		return nil, err
+	}
+	galleryIDs, err := qb.GetGalleryIDs(destinationID)
+	if err != nil {
+		return nil, err
+	}
+	movies, err := qb.GetMovies(destinationID)
+	if err != nil {
+		return nil, err
+	}
+	stashIDs, err := qb.GetStashIDs(destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	oCounter := destination.OCounter
+	playHistory, err := qb.GetAllPlayHistory(destinationID)
+	if err != nil {
+		return nil, err
+	}
+	fileNamingAlgo := config.GetVideoFileNamingAlgorithm()
+	destinationHash := destination.GetHash(fileNamingAlgo)
+
+	var funcs []func()
+	var filesToDelete []*models.File
+	for _, id := range sourceIDs {
+		if id == destinationID {
+			return nil, errors.New("cannot merge a scene into itself")
+		}
+
+		source, err := qb.Find(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if source == nil {
+			return nil, fmt.Errorf("scene with id %d not found", id)
+		}
+
+		ids, err := qb.GetPerformerIDs(id)
+		if err != nil {
+			return nil, err
+		}
+		performerIDs = utils.IntAppendUniques(performerIDs, ids)
+
+		ids, err = qb.GetTagIDs(id)
+		if err != nil {
+			return nil, err
+		}
+		tagIDs = utils.IntAppendUniques(tagIDs, ids)
+
+		ids, err = qb.GetGalleryIDs(id)
+		if err != nil {
+			return nil, err
+		}
+		galleryIDs = utils.IntAppendUniques(galleryIDs, ids)
+
+		sourceMovies, err := qb.GetMovies(id)
+		if err != nil {
+			return nil, err
+		}
+		movies = appendUniqueMovies(movies, sourceMovies)
+
+		sourceStashIDs, err := qb.GetStashIDs(id)
+		if err != nil {
+			return nil, err
+		}
+		stashIDs = appendUniqueStashIDs(stashIDs, sourceStashIDs)
+
+		oCounter += source.OCounter
+
+		sourcePlayHistory, err := qb.GetAllPlayHistory(id)
+		if err != nil {
+			return nil, err
+		}
+		playHistory = mergePlayHistory(destinationID, playHistory, sourcePlayHistory)
+
+		markers, err := mqb.FindBySceneID(id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range markers {
+			m.SceneID = sql.NullInt64{Int64: int64(destinationID), Valid: true}
+			if _, err := mqb.Update(*m); err != nil {
+				return nil, err
+			}
+		}
+
+		if deleteFiles {
+			files, err := fqb.FindBySceneID(id)
+			if err != nil {
+				return nil, err
+			}
+			filesToDelete = append(filesToDelete, files...)
+		} else if _, err := moveSceneFiles(fqb, id, destinationID); err != nil {
+			return nil, err
+		}
+
+		f, err := DestroyScene(source, repo)
+		if err != nil {
+			return nil, err
+		}
+		funcs = append(funcs, f)
+
+		sourceHash := source.GetHash(fileNamingAlgo)
+		funcs = append(funcs, func() {
+			moveSceneMarkerFiles(sourceHash, destinationHash, markers)
+			if sourceHash != destinationHash {
+				DeleteGeneratedSceneFiles(source, fileNamingAlgo)
+			}
+		})
+	}
+
+	if err := qb.UpdatePerformers(destinationID, performerIDs); err != nil {
+		return nil, err
+	}
+	if err := qb.UpdateTags(destinationID, tagIDs); err != nil {
+		return nil, err
+	}
+	if err := qb.UpdateGalleries(destinationID, galleryIDs); err != nil {
+		return nil, err
+	}
+	if err := qb.UpdateMovies(destinationID, movies); err != nil {
+		return nil, err
+	}
+
+	var stashIDJoins []models.StashID
+	for _, s := range stashIDs {
+		stashIDJoins = append(stashIDJoins, *s)
+	}
+	if err := qb.UpdateStashIDs(destinationID, stashIDJoins); err != nil {
+		return nil, err
+	}
+
+	for _, h := range playHistory {
+		if err := qb.UpdatePlayHistory(*h); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := qb.Update(models.ScenePartial{
+		ID:        destinationID,
+		OCounter:  &oCounter,
+		UpdatedAt: &models.SQLiteTimestamp{Timestamp: time.Now()},
+	}); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		for _, f := range funcs {
+			f()
+		}
+		DeleteSceneFiles(filesToDelete)
+	}, nil
+}
+
+// mergePlayHistory adds the play counts and durations of each user in toAdd
+// to the play history of the same user in vs, using the latest last played
+// time. The resume time of existing history is retained.
+func mergePlayHistory(sceneID int, vs []*models.ScenePlayHistory, toAdd []*models.ScenePlayHistory) []*models.ScenePlayHistory {
+	for _, h := range toAdd {
+		var existing *models.ScenePlayHistory
+		for _, v := range vs {
+			if v.UserID == h.UserID {
+				existing = v
+				break
+			}
+		}
+
+		if existing == nil {
+			existing = &models.ScenePlayHistory{
+				SceneID: sceneID,
+				UserID:  h.UserID,
+			}
+			vs = append(vs, existing)
+		}
+
+		existing.PlayCount += h.PlayCount
+		existing.PlayDuration += h.PlayDuration
+		if h.LastPlayedAt.Valid && (!existing.LastPlayedAt.Valid || h.LastPlayedAt.Timestamp.After(existing.LastPlayedAt.Timestamp)) {
+			existing.LastPlayedAt = h.LastPlayedAt
+		}
+	}
+
+	return vs
+}
+
+// appendUniqueMovies appends the movies in toAdd that are not already in vs.
+// The scene index of existing movies is retained.
+func appendUniqueMovies(vs []models.MoviesScenes, toAdd []models.MoviesScenes) []models.MoviesScenes {
+	for _, m := range toAdd {
+		found := false
+		for _, v := range vs {
+			if v.MovieID == m.MovieID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			vs = append(vs, m)
+		}
+	}
+
+	return vs
+}
+
+// appendUniqueStashIDs appends the stash IDs in toAdd that are not already in
+// vs.
+func appendUniqueStashIDs(vs []*models.StashID, toAdd []*models.StashID) []*models.StashID {
+	for _, s := range toAdd {
+		found := false
+		for _, v := range vs {
+			if v.Endpoint == s.Endpoint && v.StashID == s.StashID {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			vs = append(vs, s)
+		}
+	}
+
+	return vs
+}
+
+// moveSceneMarkerFiles moves the generated files of the provided markers from
+// the source scene hash to the destination scene hash.
+func moveSceneMarkerFiles(sourceHash string, destinationHash string, markers []*models.SceneMarker) {
+	if sourceHash == "" || destinationHash == "" || sourceHash == destinationHash {
+		return
+	}
+
+	markerPaths := GetInstance().Paths.SceneMarkers
+	for _, m := range markers {
+		seconds := int(m.Seconds)
+		moveGeneratedFile(markerPaths.GetStreamPath(sourceHash, seconds), markerPaths.GetStreamPath(destinationHash, seconds))
+		moveGeneratedFile(markerPaths.GetStreamPreviewImagePath(sourceHash, seconds), markerPaths.GetStreamPreviewImagePath(destinationHash, seconds))
+	}
+}
+
+func moveGeneratedFile(oldPath string, newPath string) {
+	exists, _ := utils.FileExists(oldPath)
+	if !exists {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		logger.Warnf("Could not create directory %s: %s", filepath.Dir(newPath), err.Error())
+		return
+	}
+
+	if err := os.Rename(oldPath, newPath); err != nil {
+		logger.Warnf("Could not move file %s to %s: %s", oldPath, newPath, err.Error())
 	}
 }
 
@@ -233,10 +554,12 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL string, maxStreami
 	var ret []*models.SceneStreamEndpoint
 	mimeWebm := ffmpeg.MimeWebm
 	mimeHLS := ffmpeg.MimeHLS
+	mimeDASH := ffmpeg.MimeDASH
 	mimeMp4 := ffmpeg.MimeMp4
 
 	labelWebm := "webm"
 	labelHLS := "HLS"
+	labelDASH := "DASH"
 
 	// direct stream should only apply when the audio codec is supported
 	audioCodec := ffmpeg.MissingUnsupported
@@ -274,6 +597,13 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL string, maxStreami
 	}
 	ret = append(ret, &hls)
 
+	dash := models.SceneStreamEndpoint{
+		URL:      directStreamURL + ".mpd",
+		MimeType: &mimeDASH,
+		Label:    &labelDASH,
+	}
+	ret = append(ret, &dash)
+
 	// WEBM quality transcoding options
 	// Note: These have the wrong mime type intentionally to allow jwplayer to selection between mp4/webm
 	webmLabelFourK := "WEBM 4K (2160p)"         // "FOUR_K"
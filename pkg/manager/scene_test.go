@@ -0,0 +1,160 @@
+package manager
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestAppendUniqueMovies(t *testing.T) {
+	index := func(i int64) sql.NullInt64 {
+		return sql.NullInt64{Int64: i, Valid: true}
+	}
+
+	vs := []models.MoviesScenes{
+		{MovieID: 1, SceneIndex: index(1)},
+	}
+	toAdd := []models.MoviesScenes{
+		{MovieID: 1, SceneIndex: index(2)},
+		{MovieID: 2, SceneIndex: index(3)},
+	}
+
+	assert.Equal(t, []models.MoviesScenes{
+		{MovieID: 1, SceneIndex: index(1)},
+		{MovieID: 2, SceneIndex: index(3)},
+	}, appendUniqueMovies(vs, toAdd))
+}
+
+func TestAppendUniqueStashIDs(t *testing.T) {
+	const endpoint1 = "endpoint1"
+	const endpoint2 = "endpoint2"
+
+	vs := []*models.StashID{
+		{Endpoint: endpoint1, StashID: "a"},
+	}
+	toAdd := []*models.StashID{
+		{Endpoint: endpoint1, StashID: "a"},
+		{Endpoint: endpoint2, StashID: "a"},
+		{Endpoint: endpoint1, StashID: "b"},
+	}
+
+	assert.Equal(t, []*models.StashID{
+		{Endpoint: endpoint1, StashID: "a"},
+		{Endpoint: endpoint2, StashID: "a"},
+		{Endpoint: endpoint1, StashID: "b"},
+	}, appendUniqueStashIDs(vs, toAdd))
+}
+
+func TestMergeScenes(t *testing.T) {
+	const (
+		destinationID = iota + 1
+		sourceID
+		emptySourceID
+		missingID
+	)
+
+	const (
+		markerID = 10
+		fileID   = 20
+		endpoint = "endpoint"
+	)
+
+	repo := mocks.NewTransactionManager()
+	qb := repo.Scene().(*mocks.SceneReaderWriter)
+	mqb := repo.SceneMarker().(*mocks.SceneMarkerReaderWriter)
+	fqb := repo.File().(*mocks.FileReaderWriter)
+
+	qb.On("Find", destinationID).Return(&models.Scene{ID: destinationID, OCounter: 1}, nil)
+	qb.On("Find", sourceID).Return(&models.Scene{ID: sourceID, OCounter: 2}, nil)
+	qb.On("Find", emptySourceID).Return(&models.Scene{ID: emptySourceID, OCounter: 3}, nil)
+
+	qb.On("GetPerformerIDs", destinationID).Return([]int{1}, nil)
+	qb.On("GetPerformerIDs", sourceID).Return([]int{1, 2}, nil)
+	qb.On("GetTagIDs", destinationID).Return([]int{3}, nil)
+	qb.On("GetTagIDs", sourceID).Return([]int{4}, nil)
+	qb.On("GetGalleryIDs", destinationID).Return(nil, nil)
+	qb.On("GetGalleryIDs", sourceID).Return([]int{5}, nil)
+	qb.On("GetMovies", destinationID).Return([]models.MoviesScenes{{MovieID: 6}}, nil)
+	qb.On("GetMovies", sourceID).Return([]models.MoviesScenes{{MovieID: 6}, {MovieID: 7}}, nil)
+	qb.On("GetStashIDs", destinationID).Return([]*models.StashID{{Endpoint: endpoint, StashID: "a"}}, nil)
+	qb.On("GetStashIDs", sourceID).Return([]*models.StashID{{Endpoint: endpoint, StashID: "b"}}, nil)
+
+	userID := sql.NullInt64{Int64: 1, Valid: true}
+	earlier := models.NullSQLiteTimestamp{Timestamp: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	later := models.NullSQLiteTimestamp{Timestamp: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	qb.On("GetAllPlayHistory", destinationID).Return([]*models.ScenePlayHistory{
+		{SceneID: destinationID, UserID: userID, PlayCount: 1, PlayDuration: 10, ResumeTime: 5, LastPlayedAt: earlier},
+	}, nil)
+	qb.On("GetAllPlayHistory", sourceID).Return([]*models.ScenePlayHistory{
+		{SceneID: sourceID, UserID: userID, PlayCount: 2, PlayDuration: 20, ResumeTime: 15, LastPlayedAt: later},
+		{SceneID: sourceID, PlayCount: 3, PlayDuration: 30, LastPlayedAt: earlier},
+	}, nil)
+
+	for _, method := range []string{"GetPerformerIDs", "GetTagIDs", "GetGalleryIDs", "GetMovies", "GetStashIDs", "GetAllPlayHistory"} {
+		qb.On(method, emptySourceID).Return(nil, nil)
+	}
+
+	// markers are moved to the destination before the sources are destroyed
+	mqb.On("FindBySceneID", sourceID).Return([]*models.SceneMarker{
+		{ID: markerID, SceneID: sql.NullInt64{Int64: sourceID, Valid: true}},
+	}, nil).Once()
+	mqb.On("FindBySceneID", sourceID).Return(nil, nil).Once()
+	mqb.On("FindBySceneID", emptySourceID).Return(nil, nil)
+	mqb.On("Update", mock.MatchedBy(func(m models.SceneMarker) bool {
+		return m.ID == markerID && m.SceneID.Int64 == destinationID
+	})).Return(nil, nil).Once()
+
+	// files are moved to the destination as secondary files
+	fqb.On("FindBySceneID", sourceID).Return([]*models.File{
+		{ID: fileID, SceneID: sourceID, Primary: true},
+	}, nil)
+	fqb.On("FindBySceneID", emptySourceID).Return(nil, nil)
+	fqb.On("Update", mock.MatchedBy(func(f models.File) bool {
+		return f.ID == fileID && f.SceneID == destinationID && !f.Primary
+	})).Return(nil, nil).Once()
+
+	qb.On("Destroy", sourceID).Return(nil).Once()
+	qb.On("Destroy", emptySourceID).Return(nil).Once()
+
+	qb.On("UpdatePerformers", destinationID, []int{1, 2}).Return(nil).Once()
+	qb.On("UpdateTags", destinationID, []int{3, 4}).Return(nil).Once()
+	qb.On("UpdateGalleries", destinationID, []int{5}).Return(nil).Once()
+	qb.On("UpdateMovies", destinationID, []models.MoviesScenes{{MovieID: 6}, {MovieID: 7}}).Return(nil).Once()
+	qb.On("UpdateStashIDs", destinationID, []models.StashID{
+		{Endpoint: endpoint, StashID: "a"},
+		{Endpoint: endpoint, StashID: "b"},
+	}).Return(nil).Once()
+	// play history is merged per user, retaining the destination resume time
+	qb.On("UpdatePlayHistory", models.ScenePlayHistory{
+		SceneID: destinationID, UserID: userID, PlayCount: 3, PlayDuration: 30, ResumeTime: 5, LastPlayedAt: later,
+	}).Return(nil).Once()
+	qb.On("UpdatePlayHistory", models.ScenePlayHistory{
+		SceneID: destinationID, PlayCount: 3, PlayDuration: 30, LastPlayedAt: earlier,
+	}).Return(nil).Once()
+	qb.On("Update", mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.ID == destinationID && p.OCounter != nil && *p.OCounter == 6
+	})).Return(nil, nil).Once()
+
+	_, err := MergeScenes(repo, []int{sourceID, emptySourceID}, destinationID, false)
+	assert.Nil(t, err)
+
+	qb.AssertExpectations(t)
+	mqb.AssertExpectations(t)
+	fqb.AssertExpectations(t)
+
+	// invalid merges
+	qb.On("Find", missingID).Return(nil, nil)
+
+	_, err = MergeScenes(repo, []int{destinationID}, destinationID, false)
+	assert.NotNil(t, err)
+	_, err = MergeScenes(repo, []int{missingID}, destinationID, false)
+	assert.NotNil(t, err)
+	_, err = MergeScenes(repo, []int{sourceID}, missingID, false)
+	assert.NotNil(t, err)
+}
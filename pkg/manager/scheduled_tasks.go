@@ -0,0 +1,206 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/database"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/savedfilter"
+	"github.com/stashapp/stash/pkg/scheduler"
+)
+
+// ValidateScheduledTask returns an error if the schedule of the task is
+// invalid, if the options required by the task type are not set, or if the
+// saved filter referenced by the task is not found or is not valid for the
+// task.
+func ValidateScheduledTask(qb models.SavedFilterReader, t *models.ScheduledTask) error {
+	if t.Name == "" {
+		return errors.New("scheduled task name cannot be blank")
+	}
+
+	if _, err := scheduler.ParseSchedule(t.Schedule); err != nil {
+		return err
+	}
+
+	if !t.Type.IsValid() {
+		return fmt.Errorf("invalid scheduled task type: %s", t.Type)
+	}
+
+	switch t.Type {
+	case models.ScheduledTaskTypeGenerate:
+		if t.Generate == nil {
+			return errors.New("generate options are required for generate tasks")
+		}
+		if t.Generate.SavedFilterID != nil {
+			if _, err := savedfilter.Find(qb, *t.Generate.SavedFilterID, models.FilterModeScenes); err != nil {
+				return err
+			}
+		}
+	case models.ScheduledTaskTypeAutoTag:
+		if t.AutoTag == nil {
+			return errors.New("auto tag options are required for auto tag tasks")
+		}
+	case models.ScheduledTaskTypePlugin:
+		if t.Plugin == nil || t.Plugin.PluginID == "" || t.Plugin.TaskName == "" {
+			return errors.New("plugin id and task name are required for plugin tasks")
+		}
+		if t.Plugin.SavedFilterID != nil {
+			if _, err := savedfilter.FindAny(qb, *t.Plugin.SavedFilterID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// UpdateScheduledTasks calls fn with the scheduled tasks from the
+// configuration, then saves and reschedules the tasks returned by fn. Updates
+// are serialized so that concurrent changes are not lost.
+func (s *singleton) UpdateScheduledTasks(fn func(tasks []*models.ScheduledTask) ([]*models.ScheduledTask, error)) error {
+	s.scheduledTasksMutex.Lock()
+	defer s.scheduledTasksMutex.Unlock()
+
+	tasks, err := fn(config.GetScheduledTasks())
+	if err != nil {
+		return err
+	}
+
+	// remove the last run times of deleted tasks, since IDs may be reused
+	ids := make(map[int]bool)
+	for _, t := range tasks {
+		ids[t.ID] = true
+	}
+	lastRun := config.GetScheduledTasksLastRun()
+	for id := range lastRun {
+		if !ids[id] {
+			delete(lastRun, id)
+		}
+	}
+
+	config.Set(config.ScheduledTasks, tasks)
+	config.SetScheduledTasksLastRun(lastRun)
+	if err := config.Write(); err != nil {
+		return err
+	}
+
+	s.RefreshScheduledTasks()
+	return nil
+}
+
+// saveScheduledTaskLastRun stores the last run time of the scheduled task in
+// the configuration, so that it is retained when the server is restarted.
+func (s *singleton) saveScheduledTaskLastRun(id int, lastRun time.Time) {
+	s.scheduledTasksMutex.Lock()
+	defer s.scheduledTasksMutex.Unlock()
+
+	m := config.GetScheduledTasksLastRun()
+	m[id] = lastRun
+	config.SetScheduledTasksLastRun(m)
+	if err := config.Write(); err != nil {
+		logger.Errorf("Error saving last run time of scheduled task %d: %s", id, err.Error())
+	}
+}
+
+// RefreshScheduledTasks reschedules the enabled scheduled tasks from the
+// configuration. Call this when the scheduled tasks are changed.
+func (s *singleton) RefreshScheduledTasks() {
+	tasks := config.GetScheduledTasks()
+	lastRun := config.GetScheduledTasksLastRun()
+
+	enabled := make(map[int]bool)
+	for _, t := range tasks {
+		if !t.Enabled {
+			continue
+		}
+
+		schedule, err := scheduler.ParseSchedule(t.Schedule)
+		if err != nil {
+			logger.Errorf("Error parsing schedule for scheduled task %s: %s", t.Name, err.Error())
+			continue
+		}
+
+		task := *t
+		s.Scheduler.Set(t.ID, schedule, func() {
+			s.runScheduledTask(task)
+		})
+		if r, found := lastRun[t.ID]; found && s.Scheduler.LastRun(t.ID) == nil {
+			s.Scheduler.SetLastRun(t.ID, r)
+		}
+		enabled[t.ID] = true
+	}
+
+	// remove tasks that were deleted or disabled
+	for _, id := range s.Scheduler.IDs() {
+		if !enabled[id] {
+			s.Scheduler.Remove(id)
+		}
+	}
+}
+
+func (s *singleton) runScheduledTask(t models.ScheduledTask) {
+	if !config.IsValid() || database.NeedsMigration() {
+		logger.Warnf("Skipping scheduled task %s: system is not ready", t.Name)
+		return
+	}
+
+	logger.Infof("Running scheduled task %s", t.Name)
+
+	// scheduled tasks are not initiated by a request
+	ctx := context.TODO()
+
+	switch t.Type {
+	case models.ScheduledTaskTypeScan:
+		input := models.ScanMetadataInput{}
+		if t.Scan != nil {
+			input = *t.Scan
+		}
+		s.Scan(ctx, input)
+	case models.ScheduledTaskTypeGenerate:
+		s.Generate(ctx, *t.Generate)
+	case models.ScheduledTaskTypeAutoTag:
+		s.AutoTag(ctx, *t.AutoTag)
+	case models.ScheduledTaskTypeClean:
+		input := models.CleanMetadataInput{}
+		if t.Clean != nil {
+			input = *t.Clean
+		}
+		s.Clean(ctx, input)
+	case models.ScheduledTaskTypeBackup:
+		s.Backup(ctx)
+	case models.ScheduledTaskTypePlugin:
+		var args []*models.PluginArgInput
+		if t.Plugin.SavedFilterID != nil {
+			args = append(args, &models.PluginArgInput{
+				Key:   plugin.SavedFilterIDArg,
+				Value: &models.PluginValueInput{Str: t.Plugin.SavedFilterID},
+			})
+		}
+
+		serverConnection := s.PluginCache.ServerConnection(ctx)
+		s.RunPluginTask(ctx, t.Plugin.PluginID, t.Plugin.TaskName, args, serverConnection)
+	}
+}
+
+// Backup queues a job to back up the database to the default backup path.
+// Returns the job ID.
+func (s *singleton) Backup(ctx context.Context) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		backupPath := database.DatabaseBackupPath()
+		if err := database.Backup(database.DB, backupPath); err != nil {
+			return err
+		}
+
+		logger.Infof("Successfully backed up database to: %s", backupPath)
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Backing up database...", j)
+}
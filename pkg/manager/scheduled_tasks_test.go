@@ -0,0 +1,86 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateScheduledTask(t *testing.T) {
+	const (
+		sceneFilterID     = 1
+		performerFilterID = 2
+		missingFilterID   = 3
+	)
+
+	qb := &mocks.SavedFilterReaderWriter{}
+	qb.On("Find", sceneFilterID).Return(&models.SavedFilter{
+		ID:     sceneFilterID,
+		Mode:   models.FilterModeScenes,
+		Filter: `{}`,
+	}, nil)
+	qb.On("Find", performerFilterID).Return(&models.SavedFilter{
+		ID:     performerFilterID,
+		Mode:   models.FilterModePerformers,
+		Filter: `{}`,
+	}, nil)
+	qb.On("Find", missingFilterID).Return(nil, nil)
+
+	valid := func() *models.ScheduledTask {
+		return &models.ScheduledTask{
+			Name:     "task",
+			Schedule: "0 3 * * *",
+			Type:     models.ScheduledTaskTypeScan,
+		}
+	}
+
+	assert.NoError(t, ValidateScheduledTask(qb, valid()))
+
+	task := valid()
+	task.Name = ""
+	assert.Error(t, ValidateScheduledTask(qb, task))
+
+	task = valid()
+	task.Schedule = "every day"
+	assert.Error(t, ValidateScheduledTask(qb, task))
+
+	task = valid()
+	task.Type = "INVALID"
+	assert.Error(t, ValidateScheduledTask(qb, task))
+
+	task = valid()
+	task.Type = models.ScheduledTaskTypeGenerate
+	assert.Error(t, ValidateScheduledTask(qb, task))
+	task.Generate = &models.GenerateMetadataInput{Sprites: true}
+	assert.NoError(t, ValidateScheduledTask(qb, task))
+	filterID := "1"
+	task.Generate.SavedFilterID = &filterID
+	assert.NoError(t, ValidateScheduledTask(qb, task))
+	// generate tasks require a scenes filter
+	filterID = "2"
+	assert.Error(t, ValidateScheduledTask(qb, task))
+	filterID = "3"
+	assert.Error(t, ValidateScheduledTask(qb, task))
+
+	task = valid()
+	task.Type = models.ScheduledTaskTypeAutoTag
+	assert.Error(t, ValidateScheduledTask(qb, task))
+	task.AutoTag = &models.AutoTagMetadataInput{Performers: []string{"*"}}
+	assert.NoError(t, ValidateScheduledTask(qb, task))
+
+	task = valid()
+	task.Type = models.ScheduledTaskTypePlugin
+	assert.Error(t, ValidateScheduledTask(qb, task))
+	task.Plugin = &models.ScheduledPluginTaskInput{PluginID: "plugin"}
+	assert.Error(t, ValidateScheduledTask(qb, task))
+	task.Plugin.TaskName = "task"
+	assert.NoError(t, ValidateScheduledTask(qb, task))
+	// plugin tasks accept a filter of any mode
+	filterID = "2"
+	task.Plugin.SavedFilterID = &filterID
+	assert.NoError(t, ValidateScheduledTask(qb, task))
+	filterID = "3"
+	assert.Error(t, ValidateScheduledTask(qb, task))
+}
@@ -0,0 +1,527 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+const (
+	// the maximum time to wait for a segment to be written
+	segmentWaitTimeout = 30 * time.Second
+
+	// requests for segments further ahead of the segmenter than this restart
+	// the segmenter at the requested segment
+	maxSegmentLookahead = 5
+
+	// segment index used for the initialization segment
+	initSegment = -1
+)
+
+// segmentedStream is a segmenter writing the segments of a scene at a
+// single rendition.
+type segmentedStream struct {
+	*ffmpeg.Segmenter
+	dir     string
+	session *streamSession
+
+	// the first segment that has not been started by the segmenter
+	next int
+	// the first segment of the next segmenter in the same directory. The
+	// segmenter is stopped when it reaches it, unless it was started with
+	// it as its end segment.
+	end int
+}
+
+func (s *segmentedStream) updateNext() {
+	for s.next < s.end && segmentExists(s.dir, s.next) {
+		s.next++
+	}
+}
+
+// covers returns true if the segment will be written soon by the segmenter.
+func (s *segmentedStream) covers(segment int) bool {
+	if segment == initSegment {
+		return true
+	}
+
+	return segment >= s.Options.StartSegment && segment < s.end && segment <= s.next+maxSegmentLookahead
+}
+
+var (
+	// segmented streams by segment directory. Viewers watching different
+	// parts of the same scene and rendition have their own segmenters.
+	segmentedStreams      = make(map[string][]*segmentedStream)
+	segmentedStreamsMutex = sync.Mutex{}
+
+	// prevents the segment cache from being pruned concurrently
+	pruneSegmentsMutex = sync.Mutex{}
+)
+
+// StreamVideoOnly returns true if the audio of the scene must be removed
+// when transcoding, because the audio codec is not supported by ffmpeg.
+func StreamVideoOnly(scene *models.Scene) bool {
+	audioCodec := ffmpeg.MissingUnsupported
+	if scene.AudioCodec.Valid {
+		audioCodec = ffmpeg.AudioCodec(scene.AudioCodec.String)
+	}
+
+	return audioCodec == ffmpeg.MissingUnsupported
+}
+
+// GetStreamInitSegment returns the path to the initialization segment of the
+// scene stream at the rendition, starting a segmenter if required.
+func GetStreamInitSegment(r *http.Request, scene *models.Scene, videoFile ffmpeg.VideoFile, rendition ffmpeg.StreamRendition) (string, error) {
+	return getStreamSegment(r, scene, videoFile, rendition, initSegment)
+}
+
+// GetStreamSegment returns the path to the media segment of the scene stream
+// at the rendition. Segments are cached in the generated segments directory.
+// If the segment has not been written, then it waits for a running segmenter
+// to write it, or starts a new segmenter at the segment. Segmenters are
+// shared between viewers watching the same part of the scene at the same
+// rendition.
+func GetStreamSegment(r *http.Request, scene *models.Scene, videoFile ffmpeg.VideoFile, rendition ffmpeg.StreamRendition, segment int) (string, error) {
+	if segment < 0 || segment >= ffmpeg.GetStreamSegmentCount(videoFile) {
+		return "", fmt.Errorf("invalid segment %d", segment)
+	}
+
+	return getStreamSegment(r, scene, videoFile, rendition, segment)
+}
+
+func getStreamSegment(r *http.Request, scene *models.Scene, videoFile ffmpeg.VideoFile, rendition ffmpeg.StreamRendition, segment int) (string, error) {
+	sceneHash := scene.GetHash(config.GetVideoFileNamingAlgorithm())
+	dir := filepath.Join(instance.Paths.Scene.GetStreamSegmentsPath(sceneHash), rendition.Resolution.String())
+
+	options := ffmpeg.SegmenterOptions{
+		ProbeResult: videoFile,
+		Rendition:   rendition,
+		OutputDir:   dir,
+		VideoOnly:   StreamVideoOnly(scene),
+	}
+
+	if err := ensureSegmenter(r, scene, options, segment); err != nil {
+		return "", err
+	}
+
+	// the modification time of the directory is used to evict the least
+	// recently watched segments from the cache
+	now := time.Now()
+	if err := os.Chtimes(dir, now, now); err != nil && !os.IsNotExist(err) {
+		logger.Warnf("Could not update modification time of %s: %s", dir, err.Error())
+	}
+
+	return waitForSegment(r.Context(), dir, segment)
+}
+
+func segmentFilename(dir string, segment int) string {
+	if segment == initSegment {
+		return filepath.Join(dir, ffmpeg.StreamInitSegment)
+	}
+
+	return filepath.Join(dir, ffmpeg.GetStreamSegmentFilename(segment))
+}
+
+func segmentExists(dir string, segment int) bool {
+	exists, _ := utils.FileExists(segmentFilename(dir, segment))
+	return exists
+}
+
+func isSegmenterDone(s *segmentedStream) bool {
+	select {
+	case <-s.Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// findSegmenter returns the segmenter that will write the segment soon, if
+// any. Must be called with the segmentedStreamsMutex held.
+func findSegmenter(streams []*segmentedStream, segment int) *segmentedStream {
+	for _, s := range streams {
+		s.updateNext()
+		if s.covers(segment) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// writingSegmenter returns the segmenter that has started or will start
+// writing the segment, if any. Must be called with the segmentedStreamsMutex
+// held.
+func writingSegmenter(streams []*segmentedStream, segment int) *segmentedStream {
+	for _, s := range streams {
+		if segment == initSegment || (segment >= s.Options.StartSegment && segment < s.end) {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// isSegmentComplete returns true if the segment has been completely written.
+// streams are the segmenters writing to the directory. Must be called with
+// the segmentedStreamsMutex held.
+func isSegmentComplete(dir string, segment int, streams []*segmentedStream) bool {
+	if !segmentExists(dir, segment) {
+		return false
+	}
+
+	// segments are written in order, so the segment is complete once the
+	// next segment has been started
+	for _, s := range streams {
+		if segment == initSegment {
+			if !segmentExists(dir, s.Options.StartSegment) && !isSegmenterDone(s) {
+				return false
+			}
+			continue
+		}
+
+		s.updateNext()
+		if segment >= s.Options.StartSegment && segment == s.next-1 && !(isSegmenterDone(s) && s.Err() == nil) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ensureSegmenter starts a segmenter for the segment if it has not been
+// written and it will not be written soon by a running segmenter. Running
+// segmenters for other parts of the scene are left running. Requests are
+// recorded as accesses to the session of the segmenter serving them.
+func ensureSegmenter(r *http.Request, scene *models.Scene, options ffmpeg.SegmenterOptions, segment int) error {
+	// segmenters that have caught up with a later segmenter are stopped once
+	// the mutex has been released
+	var overtaken []*segmentedStream
+	defer func() {
+		for _, s := range overtaken {
+			stopSegmenter(s)
+		}
+	}()
+
+	segmentedStreamsMutex.Lock()
+	defer segmentedStreamsMutex.Unlock()
+
+	dir := options.OutputDir
+	for _, s := range segmentedStreams[dir] {
+		// segmenters started before a later segmenter stop by themselves
+		s.updateNext()
+		if s.next >= s.end && s.end != s.Options.EndSegment {
+			detachSegmenter(s)
+			overtaken = append(overtaken, s)
+		}
+	}
+
+	streams := segmentedStreams[dir]
+	s := findSegmenter(streams, segment)
+	if s != nil {
+		s.session.touch(r)
+	}
+
+	if s != nil || isSegmentComplete(dir, segment, streams) {
+		return nil
+	}
+
+	if segment == initSegment {
+		options.StartSegment = 0
+	} else {
+		options.StartSegment = segment
+	}
+
+	// stop at the segments that are written by a later segmenter
+	for _, other := range streams {
+		start := other.Options.StartSegment
+		if start > options.StartSegment && (options.EndSegment == 0 || start < options.EndSegment) {
+			options.EndSegment = start
+		}
+	}
+
+	if err := utils.EnsureDirAll(dir); err != nil {
+		return err
+	}
+
+	session, err := streamSessions.start(scene, "HLS/DASH", options.Rendition.Resolution.String())
+	if err != nil {
+		return err
+	}
+	session.touch(r)
+
+	encoder := ffmpeg.NewEncoder(instance.FFMPEGPath)
+	segmenter, err := encoder.StartSegmenter(options)
+	if err != nil {
+		streamSessions.end(session)
+		return err
+	}
+
+	s = &segmentedStream{
+		Segmenter: segmenter,
+		dir:       dir,
+		session:   session,
+		next:      options.StartSegment,
+	}
+	segmentedStreams[dir] = append(segmentedStreams[dir], s)
+	updateSegmenterEnds(dir)
+
+	session.setStop(func() {
+		segmentedStreamsMutex.Lock()
+		detached := detachSegmenter(s)
+		segmentedStreamsMutex.Unlock()
+
+		if detached {
+			stopSegmenter(s)
+		}
+	})
+
+	go func() {
+		<-s.Done()
+		streamSessions.end(session)
+
+		segmentedStreamsMutex.Lock()
+		// the segmenter may already have been stopped
+		detached := detachSegmenter(s)
+		segmentedStreamsMutex.Unlock()
+
+		if detached {
+			removePartialSegment(s)
+		}
+
+		go pruneStreamSegments()
+	}()
+
+	return nil
+}
+
+// updateSegmenterEnds sets the segment at which each segmenter in the
+// directory reaches the segments written by the next segmenter. Must be
+// called with the segmentedStreamsMutex held.
+func updateSegmenterEnds(dir string) {
+	streams := segmentedStreams[dir]
+	for _, s := range streams {
+		s.end = math.MaxInt32
+		for _, other := range streams {
+			start := other.Options.StartSegment
+			if start > s.Options.StartSegment && start < s.end {
+				s.end = start
+			}
+		}
+	}
+}
+
+// detachSegmenter removes the segmenter from the running segmenters, so that
+// it can be stopped without holding the mutex. Returns false if it has
+// already been removed. Must be called with the segmentedStreamsMutex held.
+func detachSegmenter(s *segmentedStream) bool {
+	streams := segmentedStreams[s.dir]
+	for i, other := range streams {
+		if other != s {
+			continue
+		}
+
+		streams = append(streams[:i:i], streams[i+1:]...)
+		if len(streams) == 0 {
+			delete(segmentedStreams, s.dir)
+		} else {
+			segmentedStreams[s.dir] = streams
+		}
+
+		// the end of the segmenter is kept, so that it does not delete the
+		// segments of the next segmenter
+		end := s.end
+		updateSegmenterEnds(s.dir)
+		s.end = end
+		return true
+	}
+
+	return false
+}
+
+// stopSegmenter stops the detached segmenter and deletes the segment that it
+// was writing. Must be called without the segmentedStreamsMutex held, since
+// it waits for the process to exit.
+func stopSegmenter(s *segmentedStream) {
+	logger.Debugf("[stream] stopping segmenter for %s at segment %d", s.dir, s.Options.StartSegment)
+	s.Stop()
+	streamSessions.end(s.session)
+	removePartialSegment(s)
+}
+
+// removePartialSegment deletes the segment that the exited segmenter was
+// writing if it did not finish.
+func removePartialSegment(s *segmentedStream) {
+	if isSegmenterDone(s) && s.Err() == nil {
+		return
+	}
+
+	s.updateNext()
+	if s.next > s.Options.StartSegment {
+		partial := segmentFilename(s.dir, s.next-1)
+		if err := os.Remove(partial); err != nil && !os.IsNotExist(err) {
+			logger.Warnf("Could not delete file %s: %s", partial, err.Error())
+		}
+	}
+}
+
+func waitForSegment(ctx context.Context, dir string, segment int) (string, error) {
+	timeout := time.After(segmentWaitTimeout)
+	for {
+		segmentedStreamsMutex.Lock()
+		streams := segmentedStreams[dir]
+		complete := isSegmentComplete(dir, segment, streams)
+		s := writingSegmenter(streams, segment)
+		segmentedStreamsMutex.Unlock()
+
+		if complete {
+			return segmentFilename(dir, segment), nil
+		}
+
+		if s == nil {
+			return "", errors.New("segmenter exited before writing segment")
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-timeout:
+			return "", fmt.Errorf("timed out waiting for segment %d", segment)
+		case <-s.Done():
+			if err := s.Err(); err != nil {
+				return "", fmt.Errorf("segmenter error: %s", err.Error())
+			}
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// pruneStreamSegments deletes the least recently watched segment directories
+// until the segment cache is no larger than the configured size.
+func pruneStreamSegments() {
+	maxSize := int64(config.GetStreamSegmentCacheSize()) << 20
+	if maxSize <= 0 {
+		return
+	}
+
+	pruneSegmentsMutex.Lock()
+	defer pruneSegmentsMutex.Unlock()
+
+	pruneSegmentsDir(instance.Paths.Generated.Segments, maxSize, func(dir string) bool {
+		segmentedStreamsMutex.Lock()
+		defer segmentedStreamsMutex.Unlock()
+
+		// directories being written by a segmenter are not deleted
+		if len(segmentedStreams[dir]) > 0 {
+			return false
+		}
+
+		logger.Debugf("[stream] deleting cached segments %s", dir)
+		if err := os.RemoveAll(dir); err != nil {
+			logger.Warnf("Could not delete folder %s: %s", dir, err.Error())
+			return false
+		}
+
+		return true
+	})
+}
+
+type segmentsDir struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// pruneSegmentsDir calls remove on the rendition directories of the segments
+// directory in order of modification time until the total size of the
+// remaining directories is no larger than maxSize. remove returns false if
+// the directory was not removed. Scene directories left empty are deleted.
+func pruneSegmentsDir(root string, maxSize int64, remove func(dir string) bool) {
+	scenes, err := ioutil.ReadDir(root)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warnf("Could not read segments folder %s: %s", root, err.Error())
+		}
+		return
+	}
+
+	var dirs []segmentsDir
+	var total int64
+	for _, scene := range scenes {
+		if !scene.IsDir() {
+			continue
+		}
+
+		scenePath := filepath.Join(root, scene.Name())
+		renditions, err := ioutil.ReadDir(scenePath)
+		if err != nil {
+			logger.Warnf("Could not read segments folder %s: %s", scenePath, err.Error())
+			continue
+		}
+
+		for _, rendition := range renditions {
+			if !rendition.IsDir() {
+				continue
+			}
+
+			dir := segmentsDir{
+				path:    filepath.Join(scenePath, rendition.Name()),
+				modTime: rendition.ModTime(),
+			}
+			dir.size = getDirSize(dir.path)
+			total += dir.size
+			dirs = append(dirs, dir)
+		}
+	}
+
+	if total <= maxSize {
+		return
+	}
+
+	sort.Slice(dirs, func(i, j int) bool {
+		return dirs[i].modTime.Before(dirs[j].modTime)
+	})
+
+	for _, dir := range dirs {
+		if total <= maxSize {
+			break
+		}
+
+		if !remove(dir.path) {
+			continue
+		}
+		total -= dir.size
+
+		// delete the scene directory if it has no other renditions
+		scenePath := filepath.Dir(dir.path)
+		if files, err := ioutil.ReadDir(scenePath); err == nil && len(files) == 0 {
+			os.Remove(scenePath)
+		}
+	}
+}
+
+func getDirSize(dir string) int64 {
+	var ret int64
+	_ = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			ret += info.Size()
+		}
+		return nil
+	})
+
+	return ret
+}
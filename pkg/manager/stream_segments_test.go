@@ -0,0 +1,129 @@
+package manager
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+func TestPruneSegmentsDir(t *testing.T) {
+	root, err := ioutil.TempDir("", "segments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	const segmentSize = 100
+	now := time.Now()
+
+	createDir := func(scene string, rendition string, age time.Duration) string {
+		dir := filepath.Join(root, scene, rendition)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, "0.m4s"), make([]byte, segmentSize), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modTime := now.Add(-age)
+		if err := os.Chtimes(dir, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		return dir
+	}
+
+	oldest := createDir("a", "LOW", 3*time.Hour)
+	inUse := createDir("b", "LOW", 2*time.Hour)
+	older := createDir("b", "STANDARD", time.Hour)
+	newest := createDir("c", "LOW", 0)
+
+	var removed []string
+	remove := func(dir string) bool {
+		if dir == inUse {
+			return false
+		}
+		removed = append(removed, dir)
+		os.RemoveAll(dir)
+		return true
+	}
+
+	// within the limit
+	pruneSegmentsDir(root, 4*segmentSize, remove)
+	assert.Len(t, removed, 0)
+
+	// least recently modified directories that are not in use are removed
+	// first
+	pruneSegmentsDir(root, 2*segmentSize, remove)
+	assert.Equal(t, []string{oldest, older}, removed)
+
+	// empty scene directories are removed
+	exists, _ := utils.DirExists(filepath.Join(root, "a"))
+	assert.False(t, exists)
+	exists, _ = utils.DirExists(newest)
+	assert.True(t, exists)
+	exists, _ = utils.DirExists(inUse)
+	assert.True(t, exists)
+}
+
+func TestSegmentersInSameDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "segments")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, segment := range []int{0, 1, 2, 100, 101} {
+		if err := ioutil.WriteFile(segmentFilename(dir, segment), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	newStream := func(start int) *segmentedStream {
+		return &segmentedStream{
+			Segmenter: &ffmpeg.Segmenter{
+				Options: ffmpeg.SegmenterOptions{StartSegment: start},
+			},
+			dir:  dir,
+			next: start,
+		}
+	}
+
+	first := newStream(0)
+	second := newStream(100)
+
+	segmentedStreamsMutex.Lock()
+	defer segmentedStreamsMutex.Unlock()
+	defer delete(segmentedStreams, dir)
+
+	segmentedStreams[dir] = []*segmentedStream{first, second}
+	updateSegmenterEnds(dir)
+
+	assert.Equal(t, 100, first.end)
+
+	streams := segmentedStreams[dir]
+
+	// each viewer is served by the segmenter of their part of the scene
+	assert.Equal(t, first, findSegmenter(streams, 3))
+	assert.Equal(t, second, findSegmenter(streams, 102))
+	assert.Nil(t, findSegmenter(streams, 50))
+	assert.Equal(t, 3, first.next)
+	assert.Equal(t, 102, second.next)
+
+	// the segment being written by a segmenter is not complete
+	assert.False(t, isSegmentComplete(dir, 2, streams))
+	assert.True(t, isSegmentComplete(dir, 1, streams))
+	assert.False(t, isSegmentComplete(dir, 101, streams))
+
+	// removing a segmenter does not affect the others
+	assert.True(t, detachSegmenter(second))
+	assert.False(t, detachSegmenter(second))
+	assert.Equal(t, []*segmentedStream{first}, segmentedStreams[dir])
+	assert.Equal(t, math.MaxInt32, first.end)
+}
@@ -0,0 +1,311 @@
+package manager
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ErrTooManyStreamSessions is returned when a live transcode is requested
+// and the maximum number of concurrent transcodes are running.
+var ErrTooManyStreamSessions = errors.New("maximum number of concurrent transcodes reached")
+
+// streamSessionStopTimeout is the maximum time to wait for a stopped session
+// to end.
+const streamSessionStopTimeout = 5 * time.Second
+
+// streamSession is a live transcode of a scene. Each session has a single
+// ffmpeg process, which is shared between viewers.
+type streamSession struct {
+	id      string
+	sceneID int
+	// path of the scene file being transcoded
+	path       string
+	format     string
+	resolution string
+	startedAt  time.Time
+	// closed when the session ends
+	done chan struct{}
+
+	mutex sync.Mutex
+	// stops the ffmpeg process. The session is ended when the process
+	// exits.
+	stopFn     func()
+	lastAccess time.Time
+	// last access time by viewer address
+	viewers map[string]time.Time
+	// number of open connections by viewer address
+	connections map[string]int
+}
+
+func (s *streamSession) setStop(stop func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stopFn = stop
+}
+
+func (s *streamSession) stop() {
+	s.mutex.Lock()
+	stop := s.stopFn
+	s.mutex.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+}
+
+// touch records an access to the session by the viewer of the request.
+func (s *streamSession) touch(r *http.Request) {
+	now := time.Now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.lastAccess = now
+	if r != nil {
+		s.viewers[getViewerAddress(r)] = now
+	}
+}
+
+// connect records an open connection to the session by the viewer of the
+// request. The returned function must be called when the connection is
+// closed.
+func (s *streamSession) connect(r *http.Request) func() {
+	addr := getViewerAddress(r)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.connections[addr]++
+	s.lastAccess = time.Now()
+	s.viewers[addr] = s.lastAccess
+
+	return func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.connections[addr]--
+		if s.connections[addr] == 0 {
+			delete(s.connections, addr)
+		}
+		s.lastAccess = time.Now()
+		s.viewers[addr] = s.lastAccess
+	}
+}
+
+// idleSince returns the last access time of the session. Open connections
+// access the session when output is written to them, so connections that
+// are open but not reading, such as those of paused viewers, are idle.
+func (s *streamSession) idleSince() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.lastAccess
+}
+
+func (s *streamSession) toModel(idleTimeout time.Duration) *models.StreamSession {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// only count viewers that are connected or have accessed the session
+	// recently
+	viewers := 0
+	for addr, t := range s.viewers {
+		if s.connections[addr] > 0 || time.Since(t) < idleTimeout {
+			viewers++
+		}
+	}
+
+	ret := &models.StreamSession{
+		ID:             s.id,
+		SceneID:        strconv.Itoa(s.sceneID),
+		Format:         s.format,
+		Viewers:        viewers,
+		StartTime:      s.startedAt,
+		LastAccessTime: s.lastAccess,
+	}
+
+	if s.resolution != "" {
+		resolution := s.resolution
+		ret.Resolution = &resolution
+	}
+
+	return ret
+}
+
+func getViewerAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+type streamSessionManager struct {
+	mutex    sync.Mutex
+	sessions map[string]*streamSession
+	lastID   int
+	reaping  bool
+}
+
+var streamSessions = &streamSessionManager{
+	sessions: make(map[string]*streamSession),
+}
+
+// start adds a session for a new ffmpeg process. It returns
+// ErrTooManyStreamSessions if the maximum number of sessions are running.
+// stop must be set on the returned session once the process has started.
+func (m *streamSessionManager) start(scene *models.Scene, format string, resolution string) (*streamSession, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	maxSessions := config.GetMaxTranscodeSessions()
+	if maxSessions > 0 && len(m.sessions) >= maxSessions {
+		return nil, ErrTooManyStreamSessions
+	}
+
+	m.lastID++
+	now := time.Now()
+	ret := &streamSession{
+		id:          strconv.Itoa(m.lastID),
+		sceneID:     scene.ID,
+		path:        scene.Path,
+		format:      format,
+		resolution:  resolution,
+		startedAt:   now,
+		lastAccess:  now,
+		done:        make(chan struct{}),
+		viewers:     make(map[string]time.Time),
+		connections: make(map[string]int),
+	}
+	m.sessions[ret.id] = ret
+
+	logger.Debugf("[stream] started %s session %s for scene %d", format, ret.id, scene.ID)
+
+	if !m.reaping {
+		m.reaping = true
+		go m.reap()
+	}
+
+	return ret, nil
+}
+
+// end removes the session. It is called when the ffmpeg process of the
+// session exits.
+func (m *streamSessionManager) end(s *streamSession) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.sessions[s.id] == s {
+		delete(m.sessions, s.id)
+		close(s.done)
+		logger.Debugf("[stream] ended session %s", s.id)
+	}
+}
+
+// stopPath stops the sessions transcoding the file with the provided path,
+// and waits for them to end.
+func (m *streamSessionManager) stopPath(path string) {
+	for _, s := range m.list() {
+		if s.path != path {
+			continue
+		}
+
+		logger.Infof("[stream] stopping session %s for %s", s.id, path)
+		s.stop()
+
+		select {
+		case <-s.done:
+		case <-time.After(streamSessionStopTimeout):
+			logger.Warnf("[stream] session %s did not end after being stopped", s.id)
+		}
+	}
+}
+
+func (m *streamSessionManager) get(id string) *streamSession {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.sessions[id]
+}
+
+func (m *streamSessionManager) list() []*streamSession {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var ret []*streamSession
+	for _, s := range m.sessions {
+		ret = append(ret, s)
+	}
+
+	// sort in the order that the sessions were started
+	sort.Slice(ret, func(i, j int) bool {
+		idI, _ := strconv.Atoi(ret[i].id)
+		idJ, _ := strconv.Atoi(ret[j].id)
+		return idI < idJ
+	})
+
+	return ret
+}
+
+// reap stops idle sessions until there are no sessions remaining.
+func (m *streamSessionManager) reap() {
+	for {
+		idleTimeout := getTranscodeIdleTimeout()
+		time.Sleep(idleTimeout / 4)
+
+		for _, s := range m.list() {
+			if time.Since(s.idleSince()) > idleTimeout {
+				logger.Infof("[stream] stopping idle session %s for scene %d", s.id, s.sceneID)
+				s.stop()
+			}
+		}
+
+		m.mutex.Lock()
+		if len(m.sessions) == 0 {
+			m.reaping = false
+			m.mutex.Unlock()
+			return
+		}
+		m.mutex.Unlock()
+	}
+}
+
+func getTranscodeIdleTimeout() time.Duration {
+	return time.Duration(config.GetTranscodeIdleTimeout()) * time.Second
+}
+
+// ListStreamSessions returns the running live transcodes.
+func ListStreamSessions() []*models.StreamSession {
+	idleTimeout := getTranscodeIdleTimeout()
+
+	ret := []*models.StreamSession{}
+	for _, s := range streamSessions.list() {
+		ret = append(ret, s.toModel(idleTimeout))
+	}
+
+	return ret
+}
+
+// KillStreamSession stops the ffmpeg process of the live transcode with the
+// provided ID.
+func KillStreamSession(id string) error {
+	s := streamSessions.get(id)
+	if s == nil {
+		return errors.New("stream session " + id + " not found")
+	}
+
+	logger.Infof("[stream] killing session %s for scene %d", s.id, s.sceneID)
+	s.stop()
+	return nil
+}
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestStreamSessions(t *testing.T) {
+	config.Set(config.MaxTranscodeSessions, 2)
+	defer config.Set(config.MaxTranscodeSessions, 0)
+
+	m := &streamSessionManager{
+		sessions: make(map[string]*streamSession),
+		// don't start the reaper
+		reaping: true,
+	}
+
+	scene := &models.Scene{ID: 1}
+
+	s1, err := m.start(scene, "mp4", "LOW")
+	assert.Nil(t, err)
+	s2, err := m.start(scene, "HLS/DASH", "STANDARD")
+	assert.Nil(t, err)
+	assert.NotEqual(t, s1.id, s2.id)
+
+	// the number of concurrent sessions is limited
+	_, err = m.start(scene, "mp4", "LOW")
+	assert.Equal(t, ErrTooManyStreamSessions, err)
+
+	s1.touch(httptest.NewRequest("GET", "/", nil))
+
+	// viewers are counted by address
+	for _, addr := range []string{"10.0.0.1:1000", "10.0.0.1:1001", "10.0.0.2:1000"} {
+		r := httptest.NewRequest("GET", "/", nil)
+		r.RemoteAddr = addr
+		s2.touch(r)
+	}
+
+	sessions := m.list()
+	if assert.Len(t, sessions, 2) {
+		assert.Equal(t, 1, sessions[0].toModel(time.Minute).Viewers)
+		assert.Equal(t, 2, sessions[1].toModel(time.Minute).Viewers)
+	}
+
+	stopped := false
+	s1.setStop(func() {
+		stopped = true
+	})
+	s1.stop()
+	assert.True(t, stopped)
+
+	m.end(s1)
+	_, err = m.start(scene, "mp4", "LOW")
+	assert.Nil(t, err)
+}
+
+func TestStreamSessionConnections(t *testing.T) {
+	s := &streamSession{
+		lastAccess:  time.Now().Add(-time.Hour),
+		viewers:     make(map[string]time.Time),
+		connections: make(map[string]int),
+	}
+	assert.True(t, time.Since(s.idleSince()) > time.Minute)
+
+	r := httptest.NewRequest("GET", "/", nil)
+	disconnect := s.connect(r)
+
+	assert.True(t, time.Since(s.idleSince()) < time.Minute)
+
+	// paused viewers are connected but do not access the session, so the
+	// session is idle even though the connection is open
+	s.mutex.Lock()
+	s.lastAccess = time.Now().Add(-time.Hour)
+	s.viewers[getViewerAddress(r)] = s.lastAccess
+	s.mutex.Unlock()
+
+	assert.True(t, time.Since(s.idleSince()) > time.Minute)
+	assert.Equal(t, 1, s.toModel(time.Minute).Viewers)
+
+	// writing output to the connection accesses the session
+	s.touch(nil)
+	assert.True(t, time.Since(s.idleSince()) < time.Minute)
+
+	disconnect()
+	assert.Len(t, s.connections, 0)
+	assert.True(t, time.Since(s.idleSince()) < time.Minute)
+}
+
+func TestStreamSessionsStopPath(t *testing.T) {
+	m := &streamSessionManager{
+		sessions: make(map[string]*streamSession),
+		// don't start the reaper
+		reaping: true,
+	}
+
+	const path = "/path/scene.mp4"
+
+	s1, err := m.start(&models.Scene{ID: 1, Path: path}, "mp4", "LOW")
+	assert.Nil(t, err)
+	s2, err := m.start(&models.Scene{ID: 2, Path: "/path/other.mp4"}, "mp4", "LOW")
+	assert.Nil(t, err)
+
+	var stopped []string
+	for _, s := range []*streamSession{s1, s2} {
+		s := s
+		s.setStop(func() {
+			stopped = append(stopped, s.id)
+			m.end(s)
+		})
+	}
+
+	m.stopPath(path)
+
+	assert.Equal(t, []string{s1.id}, stopped)
+	if assert.Len(t, m.list(), 1) {
+		assert.Equal(t, s2.id, m.list()[0].id)
+	}
+}
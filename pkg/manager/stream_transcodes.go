@@ -0,0 +1,278 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// the maximum amount of live transcode output that is buffered. Viewers
+	// may join a live transcode until it has written this much, and the
+	// transcode is paused while the slowest viewer is this far behind.
+	liveTranscodeBufferSize = 16 << 20
+
+	liveTranscodeReadSize = 32 << 10
+)
+
+// liveTranscode writes the output of a live transcode ffmpeg process to each
+// of its viewers.
+type liveTranscode struct {
+	key     string
+	session *streamSession
+	stdout  io.Reader
+
+	mutex sync.Mutex
+	cond  *sync.Cond
+	// output that has not been written to all viewers, starting at base
+	buf  []byte
+	base int64
+	// written offset by viewer
+	viewers    map[int]int64
+	lastViewer int
+	// true once the process has exited or is being stopped
+	done bool
+}
+
+var (
+	// live transcodes by options key
+	liveTranscodes      = make(map[string]*liveTranscode)
+	liveTranscodesMutex = sync.Mutex{}
+)
+
+func newLiveTranscode(key string, session *streamSession, stdout io.Reader) *liveTranscode {
+	ret := &liveTranscode{
+		key:     key,
+		session: session,
+		stdout:  stdout,
+		viewers: make(map[int]int64),
+	}
+	ret.cond = sync.NewCond(&ret.mutex)
+	return ret
+}
+
+// join adds a viewer to the transcode. Viewers can only join the transcode
+// until its output is too large to be buffered. Returns false if the viewer
+// could not join.
+func (t *liveTranscode) join() (int, bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.done || t.base > 0 || len(t.buf) >= liveTranscodeBufferSize {
+		return 0, false
+	}
+
+	t.lastViewer++
+	t.viewers[t.lastViewer] = 0
+	return t.lastViewer, true
+}
+
+// leave removes the viewer from the transcode. The transcode is stopped when
+// there are no viewers remaining.
+func (t *liveTranscode) leave(viewer int) {
+	t.mutex.Lock()
+	delete(t.viewers, viewer)
+	stop := len(t.viewers) == 0 && !t.done
+	if stop {
+		t.done = true
+	}
+	t.cond.Broadcast()
+	t.mutex.Unlock()
+
+	if stop {
+		t.session.stop()
+	}
+}
+
+// end returns the offset of the end of the output. Must be called with the
+// mutex held.
+func (t *liveTranscode) end() int64 {
+	return t.base + int64(len(t.buf))
+}
+
+// unread returns the amount of output that has not been written to the
+// slowest viewer. Must be called with the mutex held.
+func (t *liveTranscode) unread() int64 {
+	min := t.end()
+	for _, offset := range t.viewers {
+		if offset < min {
+			min = offset
+		}
+	}
+
+	return t.end() - min
+}
+
+// run reads the output of the process until it exits. The process is paused
+// while the slowest viewer is too far behind.
+func (t *liveTranscode) run() {
+	b := make([]byte, liveTranscodeReadSize)
+	for {
+		n, err := t.stdout.Read(b)
+
+		t.mutex.Lock()
+		if n > 0 {
+			t.buf = append(t.buf, b[:n]...)
+
+			// discard output written to all viewers once no more viewers
+			// can join
+			if t.end() >= liveTranscodeBufferSize {
+				discard := int64(len(t.buf)) - t.unread()
+				t.buf = t.buf[discard:]
+				t.base += discard
+			}
+			t.cond.Broadcast()
+		}
+
+		if err != nil {
+			if err != io.EOF {
+				logger.Debugf("[stream] error reading transcode output: %s", err.Error())
+			}
+			t.done = true
+			t.cond.Broadcast()
+			t.mutex.Unlock()
+			break
+		}
+
+		for !t.done && t.unread() >= liveTranscodeBufferSize {
+			t.cond.Wait()
+		}
+		t.mutex.Unlock()
+	}
+
+	streamSessions.end(t.session)
+
+	liveTranscodesMutex.Lock()
+	defer liveTranscodesMutex.Unlock()
+
+	if liveTranscodes[t.key] == t {
+		delete(liveTranscodes, t.key)
+	}
+}
+
+// serve writes the output of the transcode to the viewer until the output
+// ends or the context is cancelled.
+func (t *liveTranscode) serve(ctx context.Context, w io.Writer, viewer int) error {
+	defer t.leave(viewer)
+
+	// wake the viewer when the context is cancelled
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.mutex.Lock()
+			t.cond.Broadcast()
+			t.mutex.Unlock()
+		case <-finished:
+		}
+	}()
+
+	for {
+		t.mutex.Lock()
+		offset := t.viewers[viewer]
+		for offset >= t.end() && !t.done && ctx.Err() == nil {
+			t.cond.Wait()
+		}
+
+		if ctx.Err() != nil || offset >= t.end() {
+			t.mutex.Unlock()
+			return nil
+		}
+
+		// the buffer is only appended to or resliced, so the data is not
+		// modified while it is written
+		data := t.buf[offset-t.base:]
+		t.mutex.Unlock()
+
+		n, err := w.Write(data)
+		if n > 0 {
+			t.session.touch(nil)
+		}
+
+		t.mutex.Lock()
+		t.viewers[viewer] += int64(n)
+		t.cond.Broadcast()
+		t.mutex.Unlock()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func liveTranscodeKey(scene *models.Scene, format string, options ffmpeg.TranscodeStreamOptions) string {
+	return fmt.Sprintf("%d/%s/%s/%s/%s/%t", scene.ID, format, options.Codec.Codec, options.MaxTranscodeSize, options.StartTime, options.VideoOnly)
+}
+
+// joinLiveTranscode adds a viewer to the running transcode of the scene with
+// the same options, or starts a new transcode if there is none that can be
+// joined.
+func joinLiveTranscode(scene *models.Scene, format string, options ffmpeg.TranscodeStreamOptions) (*liveTranscode, int, error) {
+	key := liveTranscodeKey(scene, format, options)
+
+	liveTranscodesMutex.Lock()
+	defer liveTranscodesMutex.Unlock()
+
+	if t := liveTranscodes[key]; t != nil {
+		if viewer, ok := t.join(); ok {
+			return t, viewer, nil
+		}
+	}
+
+	session, err := streamSessions.start(scene, format, options.MaxTranscodeSize.String())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	encoder := ffmpeg.NewEncoder(instance.FFMPEGPath)
+	stream, err := encoder.GetTranscodeStream(options)
+	if err != nil {
+		streamSessions.end(session)
+		return nil, 0, err
+	}
+
+	session.setStop(func() {
+		stream.Process.Kill()
+	})
+
+	t := newLiveTranscode(key, session, stream.Stdout)
+	viewer, _ := t.join()
+	liveTranscodes[key] = t
+	go t.run()
+
+	return t, viewer, nil
+}
+
+// ServeLiveTranscode transcodes the scene to the response. format is the
+// name of the output format shown in the session list. Viewers requesting
+// the same scene, format and options share the transcode if it has not
+// progressed too far. The transcode is stopped when all of its viewers have
+// disconnected, or when no output is written to its viewers for the idle
+// timeout.
+func ServeLiveTranscode(w http.ResponseWriter, r *http.Request, scene *models.Scene, format string, options ffmpeg.TranscodeStreamOptions) error {
+	t, viewer, err := joinLiveTranscode(scene, format, options)
+	if err != nil {
+		return err
+	}
+
+	disconnect := t.session.connect(r)
+	defer disconnect()
+
+	w.Header().Set("Content-Type", options.Codec.MimeType)
+	w.WriteHeader(http.StatusOK)
+
+	logger.Infof("[stream] transcoding video file to %s", options.Codec.MimeType)
+
+	if err := t.serve(r.Context(), w, viewer); err != nil {
+		logger.Errorf("[stream] error serving transcoded video file: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package manager
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveTranscode(t *testing.T) {
+	r, w := io.Pipe()
+
+	stopped := false
+	session := &streamSession{}
+	session.setStop(func() {
+		stopped = true
+		w.Close()
+	})
+
+	lt := newLiveTranscode("key", session, r)
+	v1, ok := lt.join()
+	assert.True(t, ok)
+	v2, ok := lt.join()
+	assert.True(t, ok)
+
+	go lt.run()
+
+	output := []byte("transcoded")
+	go func() {
+		w.Write(output)
+		w.Close()
+	}()
+
+	// both viewers receive the full output
+	var out1, out2 bytes.Buffer
+	done := make(chan error)
+	go func() {
+		done <- lt.serve(context.Background(), &out1, v1)
+	}()
+	assert.Nil(t, lt.serve(context.Background(), &out2, v2))
+	assert.Nil(t, <-done)
+
+	assert.Equal(t, output, out1.Bytes())
+	assert.Equal(t, output, out2.Bytes())
+
+	// finished transcodes cannot be joined
+	_, ok = lt.join()
+	assert.False(t, ok)
+	assert.False(t, stopped)
+}
+
+func TestLiveTranscodeStop(t *testing.T) {
+	r, w := io.Pipe()
+
+	session := &streamSession{}
+	session.setStop(func() {
+		w.Close()
+	})
+
+	lt := newLiveTranscode("key", session, r)
+	viewer, _ := lt.join()
+
+	runDone := make(chan struct{})
+	go func() {
+		lt.run()
+		close(runDone)
+	}()
+
+	// transcodes that have output too much to be buffered cannot be joined
+	go w.Write(make([]byte, liveTranscodeBufferSize))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var out bytes.Buffer
+	serveDone := make(chan error)
+	go func() {
+		serveDone <- lt.serve(ctx, &out, viewer)
+	}()
+
+	for {
+		lt.mutex.Lock()
+		end := lt.end()
+		lt.mutex.Unlock()
+		if end >= liveTranscodeBufferSize {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, ok := lt.join()
+	assert.False(t, ok)
+
+	// the transcode is stopped when the last viewer disconnects
+	cancel()
+	assert.Nil(t, <-serveDone)
+	<-runDone
+}
@@ -1,9 +1,13 @@
 package manager
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func EnsureTagNameUnique(tag models.Tag, qb models.TagReader) error {
@@ -17,5 +21,174 @@ func EnsureTagNameUnique(tag models.Tag, qb models.TagReader) error {
 		return fmt.Errorf("Tag with name '%s' already exists", tag.Name)
 	}
 
+	// ensure name is not an alias of another tag
+	sameAliasTag, err := qb.FindByAlias(tag.Name, true)
+	if err != nil {
+		return err
+	}
+
+	if sameAliasTag != nil && tag.ID != sameAliasTag.ID {
+		return fmt.Errorf("Name '%s' is used as an alias of tag '%s'", tag.Name, sameAliasTag.Name)
+	}
+
+	return nil
+}
+
+// EnsureAliasesUnique returns an error if any of the aliases is used as the
+// name or alias of a tag other than the tag with the provided id.
+func EnsureAliasesUnique(id int, aliases []string, qb models.TagReader) error {
+	for _, a := range aliases {
+		sameNameTag, err := qb.FindByName(a, true)
+		if err != nil {
+			return err
+		}
+
+		if sameNameTag != nil && id != sameNameTag.ID {
+			return fmt.Errorf("Alias '%s' is used as the name of tag '%s'", a, sameNameTag.Name)
+		}
+
+		sameAliasTag, err := qb.FindByAlias(a, true)
+		if err != nil {
+			return err
+		}
+
+		if sameAliasTag != nil && id != sameAliasTag.ID {
+			return fmt.Errorf("Alias '%s' is used as an alias of tag '%s'", a, sameAliasTag.Name)
+		}
+	}
+
+	return nil
+}
+
+// CleanTagAliases trims the provided aliases and removes empty aliases,
+// duplicate aliases and aliases that are the same as the tag name.
+func CleanTagAliases(name string, aliases []string) []string {
+	seen := map[string]bool{
+		strings.ToLower(name): true,
+	}
+
+	var ret []string
+	for _, a := range aliases {
+		a = strings.TrimSpace(a)
+		key := strings.ToLower(a)
+		if a == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		ret = append(ret, a)
+	}
+
+	return ret
+}
+
+// ValidateTagHierarchy returns an error if setting the provided parent and
+// child tags on the tag with the provided id would create a loop in the tag
+// hierarchy. The existing parent and child tags of the tag are ignored, since
+// they are replaced by the provided tags.
+func ValidateTagHierarchy(id int, parentIDs []int, childIDs []int, qb models.TagReader) error {
+	for _, parentID := range parentIDs {
+		if parentID == id {
+			return errors.New("tag cannot be a parent of itself")
+		}
+
+		if utils.IntInclude(childIDs, parentID) {
+			return fmt.Errorf("tag '%s' cannot be both a parent and a child", getTagName(parentID, qb))
+		}
+	}
+
+	if utils.IntInclude(childIDs, id) {
+		return errors.New("tag cannot be a child of itself")
+	}
+
+	// walk the descendants of the new child tags. A loop is created if any
+	// of the new parent tags is a descendant.
+	visited := make(map[int]bool)
+	queue := append([]int{}, childIDs...)
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == id || visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		if utils.IntInclude(parentIDs, current) {
+			return fmt.Errorf("tag '%s' cannot be a parent because it is a descendant of a child tag", getTagName(current, qb))
+		}
+
+		children, err := qb.GetChildIDs(current)
+		if err != nil {
+			return err
+		}
+		queue = append(queue, children...)
+	}
+
 	return nil
 }
+
+func getTagName(id int, qb models.TagReader) string {
+	t, err := qb.Find(id)
+	if err != nil || t == nil {
+		return strconv.Itoa(id)
+	}
+
+	return t.Name
+}
+
+// MergeTags moves the objects that reference the source tags to the
+// destination tag, and then destroys the source tags. The aliases, names and
+// parent and child tags of the source tags are added to the destination tag.
+// Returns the number of rows moved for each table.
+func MergeTags(qb models.TagReaderWriter, sourceIDs []int, destinationID int) ([]*models.TagsMergeCount, error) {
+	destination, err := qb.Find(destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if destination == nil {
+		return nil, fmt.Errorf("tag with id %d not found", destinationID)
+	}
+
+	sources, err := qb.FindMany(sourceIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := qb.Merge(sourceIDs, destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the names of the source tags become aliases of the destination tag
+	aliases, err := qb.GetAliases(destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sources {
+		aliases = append(aliases, s.Name)
+	}
+
+	if err := qb.UpdateAliases(destinationID, CleanTagAliases(destination.Name, aliases)); err != nil {
+		return nil, err
+	}
+
+	// ensure that the merged relationships did not create a loop
+	parentIDs, err := qb.GetParentIDs(destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	childIDs, err := qb.GetChildIDs(destinationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ValidateTagHierarchy(destinationID, parentIDs, childIDs, qb); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
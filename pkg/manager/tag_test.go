@@ -0,0 +1,52 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestValidateTagHierarchy(t *testing.T) {
+	const (
+		tagID = iota + 1
+		parentID
+		childID
+		grandchildID
+		otherID
+	)
+
+	qb := &mocks.TagReaderWriter{}
+	qb.On("GetChildIDs", childID).Return([]int{grandchildID}, nil)
+	qb.On("GetChildIDs", grandchildID).Return(nil, nil)
+	// existing relationships of the tag are ignored
+	qb.On("GetChildIDs", otherID).Return([]int{tagID}, nil)
+	qb.On("Find", mock.AnythingOfType("int")).Return(&models.Tag{Name: "tag"}, nil)
+
+	tests := []struct {
+		name      string
+		parentIDs []int
+		childIDs  []int
+		wantErr   bool
+	}{
+		{"valid", []int{parentID}, []int{childID}, false},
+		{"existing child of tag", []int{parentID}, []int{otherID}, false},
+		{"own parent", []int{tagID}, nil, true},
+		{"own child", nil, []int{tagID}, true},
+		{"parent and child", []int{childID}, []int{childID}, true},
+		{"descendant as parent", []int{grandchildID}, []int{childID}, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateTagHierarchy(tagID, tt.parentIDs, tt.childIDs, qb)
+		assert.Equal(t, tt.wantErr, err != nil, tt.name)
+	}
+}
+
+func TestCleanTagAliases(t *testing.T) {
+	aliases := []string{" alias ", "", "Alias", "name", "other"}
+	assert.Equal(t, []string{"alias", "other"}, CleanTagAliases("Name", aliases))
+}
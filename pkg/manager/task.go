@@ -4,5 +4,5 @@ import "sync"
 
 type Task interface {
 	Start(wg *sync.WaitGroup)
-	GetStatus() JobStatus
+	GetDescription() string
 }
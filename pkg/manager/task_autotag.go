@@ -173,25 +173,34 @@ func (t *AutoTagTagTask) Start(wg *sync.WaitGroup) {
 }
 
 func (t *AutoTagTagTask) autoTagTag() {
-	regex := t.getQueryRegex(t.tag.Name)
-
 	if err := t.txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
-		qb := r.Scene()
-		scenes, _, err := qb.Query(t.getQueryFilter(regex), t.getFindFilter())
-
+		aliases, err := r.Tag().GetAliases(t.tag.ID)
 		if err != nil {
-			return fmt.Errorf("Error querying scenes with regex '%s': %s", regex, err.Error())
+			return fmt.Errorf("Error getting aliases of tag '%s': %s", t.tag.Name, err.Error())
 		}
 
-		for _, s := range scenes {
-			added, err := scene.AddTag(qb, s.ID, t.tag.ID)
+		// match on the tag name and all of its aliases
+		names := append([]string{t.tag.Name}, aliases...)
+
+		qb := r.Scene()
+		for _, name := range names {
+			regex := t.getQueryRegex(name)
+			scenes, _, err := qb.Query(t.getQueryFilter(regex), t.getFindFilter())
 
 			if err != nil {
-				return fmt.Errorf("Error adding tag '%s' to scene '%s': %s", t.tag.Name, s.GetTitle(), err.Error())
+				return fmt.Errorf("Error querying scenes with regex '%s': %s", regex, err.Error())
 			}
 
-			if added {
-				logger.Infof("Added tag '%s' to scene '%s'", t.tag.Name, s.GetTitle())
+			for _, s := range scenes {
+				added, err := scene.AddTag(qb, s.ID, t.tag.ID)
+
+				if err != nil {
+					return fmt.Errorf("Error adding tag '%s' to scene '%s': %s", t.tag.Name, s.GetTitle(), err.Error())
+				}
+
+				if added {
+					logger.Infof("Added tag '%s' to scene '%s'", t.tag.Name, s.GetTitle())
+				}
 			}
 		}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -24,8 +25,8 @@ type CleanTask struct {
 func (t *CleanTask) Start(wg *sync.WaitGroup, dryRun bool) {
 	defer wg.Done()
 
-	if t.Scene != nil && t.shouldCleanScene(t.Scene) && !dryRun {
-		t.deleteScene(t.Scene.ID)
+	if t.Scene != nil {
+		t.cleanScene(dryRun)
 	}
 
 	if t.Gallery != nil && t.shouldCleanGallery(t.Gallery) && !dryRun {
@@ -51,30 +52,108 @@ func (t *CleanTask) shouldClean(path string) bool {
 	return false
 }
 
-func (t *CleanTask) shouldCleanScene(s *models.Scene) bool {
-	if t.shouldClean(s.Path) {
+func (t *CleanTask) shouldCleanSceneFile(path string) bool {
+	if t.shouldClean(path) {
 		return true
 	}
 
-	stash := getStashFromPath(s.Path)
+	stash := getStashFromPath(path)
 	if stash.ExcludeVideo {
-		logger.Infof("File in stash library that excludes video. Cleaning: \"%s\"", s.Path)
+		logger.Infof("File in stash library that excludes video. Cleaning: \"%s\"", path)
 		return true
 	}
 
-	if !matchExtension(s.Path, config.GetVideoExtensions()) {
-		logger.Infof("File extension does not match video extensions. Cleaning: \"%s\"", s.Path)
+	if !matchExtension(path, config.GetVideoExtensions()) {
+		logger.Infof("File extension does not match video extensions. Cleaning: \"%s\"", path)
 		return true
 	}
 
-	if matchFile(s.Path, config.GetExcludes()) {
-		logger.Infof("File matched regex. Cleaning: \"%s\"", s.Path)
+	if matchFile(path, config.GetExcludes()) {
+		logger.Infof("File matched regex. Cleaning: \"%s\"", path)
 		return true
 	}
 
 	return false
 }
 
+// cleanScene removes the files of the scene that should be cleaned. If the
+// primary file is removed, then the first remaining file becomes the primary
+// file. The scene is deleted if none of its files remain.
+func (t *CleanTask) cleanScene(dryRun bool) {
+	var files []*models.File
+	if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		files, err = r.File().FindBySceneID(t.Scene.ID)
+		return err
+	}); err != nil {
+		logger.Errorf("Error getting files for scene %s: %s", t.Scene.Path, err.Error())
+		return
+	}
+
+	cleanPrimary := t.shouldCleanSceneFile(t.Scene.Path)
+
+	var primary *models.File
+	var remaining []*models.File
+	var clean []*models.File
+	for _, f := range files {
+		switch {
+		case f.Primary:
+			primary = f
+		case t.shouldCleanSceneFile(f.Path):
+			clean = append(clean, f)
+		default:
+			remaining = append(remaining, f)
+		}
+	}
+
+	if dryRun {
+		return
+	}
+
+	if cleanPrimary && len(remaining) == 0 {
+		t.deleteScene(t.Scene.ID)
+		return
+	}
+
+	if !cleanPrimary && len(clean) == 0 {
+		return
+	}
+
+	if cleanPrimary && primary != nil {
+		clean = append(clean, primary)
+	}
+
+	if err := t.TxnManager.WithTxn(context.TODO(), func(repo models.Repository) error {
+		if cleanPrimary {
+			logger.Infof("Setting primary file of scene to \"%s\"", remaining[0].Path)
+			if _, err := scene.SetPrimaryFile(repo.Scene(), repo.File(), t.Scene.ID, remaining[0].ID); err != nil {
+				return err
+			}
+		}
+
+		fqb := repo.File()
+		for _, f := range clean {
+			if err := fqb.Destroy(f.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("Error deleting scene files from database: %s", err.Error())
+		return
+	}
+
+	// generated files are named using the hash of the primary file, so
+	// rename them rather than orphaning them
+	if cleanPrimary {
+		oldHash := t.Scene.GetHash(t.fileNamingAlgorithm)
+		if newHash := remaining[0].GetHash(t.fileNamingAlgorithm); newHash != "" && newHash != oldHash {
+			MigrateHash(oldHash, newHash)
+		}
+	}
+}
+
 func (t *CleanTask) shouldCleanGallery(g *models.Gallery) bool {
 	// never clean manually created galleries
 	if !g.Zip {
@@ -198,6 +277,57 @@ func (t *CleanTask) fileExists(filename string) (bool, error) {
 	return !info.IsDir(), nil
 }
 
+// isPathInCleanPaths returns true if the path is one of, or is contained in,
+// the provided paths.
+func isPathInCleanPaths(p string, paths []string) bool {
+	// zip file image paths are treated as being contained in the zip file
+	p = image.PathDisplayName(p)
+
+	for _, cleanPath := range paths {
+		if utils.IsPathInDir(cleanPath, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterCleanPaths returns the scenes, images and galleries with paths in the
+// provided paths. Scenes are returned if any of the provided scene files,
+// including secondary files, are in the paths.
+func filterCleanPaths(paths []string, scenes []*models.Scene, sceneFiles []*models.File, images []*models.Image, galleries []*models.Gallery) ([]*models.Scene, []*models.Image, []*models.Gallery) {
+	sceneIDsInPaths := make(map[int]bool)
+	for _, f := range sceneFiles {
+		if isPathInCleanPaths(f.Path, paths) {
+			sceneIDsInPaths[f.SceneID] = true
+		}
+	}
+
+	var retScenes []*models.Scene
+	for _, s := range scenes {
+		if s != nil && (sceneIDsInPaths[s.ID] || isPathInCleanPaths(s.Path, paths)) {
+			retScenes = append(retScenes, s)
+		}
+	}
+
+	var retImages []*models.Image
+	for _, i := range images {
+		if i != nil && isPathInCleanPaths(i.Path, paths) {
+			retImages = append(retImages, i)
+		}
+	}
+
+	var retGalleries []*models.Gallery
+	for _, g := range galleries {
+		// manually created galleries have no path
+		if g != nil && g.Path.Valid && isPathInCleanPaths(g.Path.String, paths) {
+			retGalleries = append(retGalleries, g)
+		}
+	}
+
+	return retScenes, retImages, retGalleries
+}
+
 func getStashFromPath(pathToCheck string) *models.StashConfig {
 	for _, s := range config.GetStashPaths() {
 		if utils.IsPathInDir(s.Path, filepath.Dir(pathToCheck)) {
@@ -0,0 +1,31 @@
+package manager
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestFilterCleanPaths(t *testing.T) {
+	cleanPath := filepath.Join("stash", "removed")
+	otherPath := filepath.Join("stash", "other")
+
+	primaryInPath := &models.Scene{ID: 1, Path: filepath.Join(cleanPath, "1.mp4")}
+	secondaryInPath := &models.Scene{ID: 2, Path: filepath.Join(otherPath, "2.mp4")}
+	notInPath := &models.Scene{ID: 3, Path: filepath.Join(otherPath, "3.mp4")}
+
+	scenes := []*models.Scene{primaryInPath, secondaryInPath, notInPath}
+	files := []*models.File{
+		{SceneID: 1, Primary: true, Path: primaryInPath.Path},
+		{SceneID: 2, Primary: true, Path: secondaryInPath.Path},
+		{SceneID: 2, Path: filepath.Join(cleanPath, "2.mp4")},
+		{SceneID: 3, Primary: true, Path: notInPath.Path},
+		{SceneID: 3, Path: filepath.Join(otherPath, "3.mkv")},
+	}
+
+	gotScenes, _, _ := filterCleanPaths([]string{cleanPath}, scenes, files, nil, nil)
+	assert.Equal(t, []*models.Scene{primaryInPath, secondaryInPath}, gotScenes)
+}
@@ -93,8 +93,8 @@ func CreateExportTask(a models.HashAlgorithm, input models.ExportObjectsInput) *
 	}
 }
 
-func (t *ExportTask) GetStatus() JobStatus {
-	return Export
+func (t *ExportTask) GetDescription() string {
+	return "Exporting..."
 }
 
 func (t *ExportTask) Start(wg *sync.WaitGroup) {
@@ -366,6 +366,7 @@ func exportScene(wg *sync.WaitGroup, jobChan <-chan *models.Scene, repo models.R
 	performerReader := repo.Performer()
 	tagReader := repo.Tag()
 	sceneMarkerReader := repo.SceneMarker()
+	userReader := repo.User()
 
 	for s := range jobChan {
 		sceneHash := s.GetHash(t.fileNamingAlgorithm)
@@ -416,6 +417,12 @@ func exportScene(wg *sync.WaitGroup, jobChan <-chan *models.Scene, repo models.R
 			continue
 		}
 
+		newSceneJSON.PlayHistory, err = scene.GetScenePlayHistoryJSON(sceneReader, userReader, s)
+		if err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene play history JSON: %s", sceneHash, err.Error())
+			continue
+		}
+
 		if t.includeDependencies {
 			if s.StudioID.Valid {
 				t.studios.IDs = utils.IntAppendUnique(t.studios.IDs, int(s.StudioID.Int64))
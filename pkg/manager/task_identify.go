@@ -0,0 +1,702 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+const (
+	identifyFieldTitle      = "title"
+	identifyFieldDetails    = "details"
+	identifyFieldURL        = "url"
+	identifyFieldDate       = "date"
+	identifyFieldStudio     = "studio"
+	identifyFieldPerformers = "performers"
+	identifyFieldTags       = "tags"
+	identifyFieldStashIDs   = "stash_ids"
+)
+
+var identifyFields = []string{
+	identifyFieldTitle,
+	identifyFieldDetails,
+	identifyFieldURL,
+	identifyFieldDate,
+	identifyFieldStudio,
+	identifyFieldPerformers,
+	identifyFieldTags,
+	identifyFieldStashIDs,
+}
+
+type identifySource struct {
+	name string
+	// stash-box endpoint. Empty for scrapers.
+	endpoint string
+	scrape   func(scene *models.Scene) (*models.ScrapedScene, error)
+	options  *models.IdentifyMetadataOptionsInput
+}
+
+// Identify queues a job to identify scenes using the provided sources.
+// Returns the job ID, or an error if the input is invalid.
+func (s *singleton) Identify(ctx context.Context, input models.IdentifyMetadataInput) (int, error) {
+	task, err := s.newIdentifyTask(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		var sceneIDs []int
+		if err := s.TxnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+			perPage := 0
+			scenes, _, err := r.Scene().Query(input.SceneFilter, &models.FindFilterType{
+				PerPage: &perPage,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, scene := range scenes {
+				sceneIDs = append(sceneIDs, scene.ID)
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error querying scenes: %s", err.Error())
+		}
+
+		progress.SetTotal(len(sceneIDs))
+
+		for _, sceneID := range sceneIDs {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				return nil
+			}
+
+			progress.ExecuteTask(fmt.Sprintf("Identifying scene %d", sceneID), func() {
+				task.identifyScene(ctx, sceneID)
+			})
+
+			progress.Increment()
+		}
+
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Identifying...", j), nil
+}
+
+// newIdentifyTask validates the input and returns the task used to identify
+// the scenes.
+func (s *singleton) newIdentifyTask(ctx context.Context, input models.IdentifyMetadataInput) (*identifyTask, error) {
+	if err := validateIdentifyOptions(input.Options); err != nil {
+		return nil, err
+	}
+
+	sources, err := s.getIdentifySources(input.Sources)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &identifyTask{
+		txnManager:     s.TxnManager,
+		pluginCache:    s.PluginCache,
+		sources:        sources,
+		defaultOptions: input.Options,
+	}
+
+	if input.IdentifiedTagID != nil {
+		if err := s.setIdentifiedTag(ctx, task, *input.IdentifiedTagID); err != nil {
+			return nil, err
+		}
+	}
+
+	return task, nil
+}
+
+// setIdentifiedTag sets the tag that is added to identified scenes. Returns
+// an error if the tag does not exist.
+func (s *singleton) setIdentifiedTag(ctx context.Context, task *identifyTask, id string) error {
+	tagID, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("invalid identified tag id %s: %s", id, err.Error())
+	}
+
+	if err := s.TxnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		tag, err := r.Tag().Find(tagID)
+		if err != nil {
+			return err
+		}
+
+		if tag == nil {
+			return fmt.Errorf("identified tag with id %d not found", tagID)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	task.identifiedTagID = &tagID
+	return nil
+}
+
+func (s *singleton) getIdentifySources(inputs []*models.IdentifySourceInput) ([]identifySource, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("at least one source is required")
+	}
+
+	var ret []identifySource
+	for _, input := range inputs {
+		if err := validateIdentifyOptions(input.Options); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case input.StashBoxEndpoint != nil && input.ScraperID != nil:
+			return nil, errors.New("only one of stash_box_endpoint and scraper_id may be set")
+		case input.StashBoxEndpoint != nil:
+			src, err := s.getStashBoxIdentifySource(*input.StashBoxEndpoint)
+			if err != nil {
+				return nil, err
+			}
+			src.options = input.Options
+			ret = append(ret, *src)
+		case input.ScraperID != nil:
+			src, err := s.getScraperIdentifySource(*input.ScraperID)
+			if err != nil {
+				return nil, err
+			}
+			src.options = input.Options
+			ret = append(ret, *src)
+		default:
+			return nil, errors.New("one of stash_box_endpoint or scraper_id must be set")
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *singleton) getStashBoxIdentifySource(endpoint string) (*identifySource, error) {
+	for _, box := range config.GetStashBoxes() {
+		if box.Endpoint != endpoint {
+			continue
+		}
+
+		client := stashbox.NewClient(*box, s.TxnManager)
+		return &identifySource{
+			name:     endpoint,
+			endpoint: endpoint,
+			scrape: func(scene *models.Scene) (*models.ScrapedScene, error) {
+				results, err := client.FindStashBoxScenesByFingerprints([]string{strconv.Itoa(scene.ID)})
+				if err != nil {
+					return nil, err
+				}
+
+				if len(results) > 1 {
+					logger.Infof("%s returned %d results for %s, skipping", endpoint, len(results), scene.Path)
+					return nil, nil
+				}
+
+				if len(results) == 0 {
+					return nil, nil
+				}
+
+				return results[0], nil
+			},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("stash-box with endpoint %s not found", endpoint)
+}
+
+func (s *singleton) getScraperIdentifySource(scraperID string) (*identifySource, error) {
+	found := false
+	for _, scraper := range s.ScraperCache.ListSceneScrapers() {
+		if scraper.ID == scraperID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("scene scraper with id %s not found", scraperID)
+	}
+
+	return &identifySource{
+		name: scraperID,
+		scrape: func(scene *models.Scene) (*models.ScrapedScene, error) {
+			return s.ScraperCache.ScrapeScene(scraperID, models.SceneUpdateInput{
+				ID: strconv.Itoa(scene.ID),
+			})
+		},
+	}, nil
+}
+
+func validateIdentifyOptions(options *models.IdentifyMetadataOptionsInput) error {
+	if options == nil {
+		return nil
+	}
+
+	for _, o := range options.FieldOptions {
+		if !utils.StrInclude(identifyFields, o.Field) {
+			return fmt.Errorf("invalid identify field: %s", o.Field)
+		}
+
+		if !o.Strategy.IsValid() {
+			return fmt.Errorf("invalid strategy for identify field %s: %s", o.Field, o.Strategy)
+		}
+	}
+
+	return nil
+}
+
+type identifyTask struct {
+	txnManager      models.TransactionManager
+	pluginCache     *plugin.Cache
+	sources         []identifySource
+	defaultOptions  *models.IdentifyMetadataOptionsInput
+	identifiedTagID *int
+}
+
+// identifyScene applies the result of the first source that returns a result
+// for the scene.
+func (t *identifyTask) identifyScene(ctx context.Context, sceneID int) {
+	var scene *models.Scene
+	if err := t.txnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+		var err error
+		scene, err = r.Scene().Find(sceneID)
+		return err
+	}); err != nil {
+		logger.Errorf("Error finding scene %d: %s", sceneID, err.Error())
+		return
+	}
+
+	if scene == nil {
+		logger.Errorf("Scene %d not found", sceneID)
+		return
+	}
+
+	for _, source := range t.sources {
+		scraped, err := source.scrape(scene)
+		if err != nil {
+			logger.Errorf("Error scraping %s using %s: %s", scene.Path, source.name, err.Error())
+			continue
+		}
+
+		if scraped == nil {
+			continue
+		}
+
+		var coverImage []byte
+		if err := t.txnManager.WithTxn(ctx, func(r models.Repository) error {
+			var err error
+			coverImage, err = t.modifyScene(r, scene, source, scraped)
+			return err
+		}); err != nil {
+			logger.Errorf("Error updating %s using %s: %s", scene.Path, source.name, err.Error())
+			return
+		}
+
+		if len(coverImage) > 0 {
+			if err := SetSceneScreenshot(scene.GetHash(config.GetVideoFileNamingAlgorithm()), coverImage); err != nil {
+				logger.Errorf("Error setting screenshot for %s: %s", scene.Path, err.Error())
+			}
+		}
+
+		if t.pluginCache != nil {
+			t.pluginCache.ExecutePostHooks(ctx, scene.ID, plugin.SceneUpdatePost, nil, nil)
+		}
+
+		logger.Infof("Identified %s using %s", scene.Path, source.name)
+		return
+	}
+
+	logger.Infof("Unable to identify %s", scene.Path)
+}
+
+// fieldOptions returns the options for the field from the source options,
+// falling back to the default options. The default strategy is MERGE.
+func (t *identifyTask) fieldOptions(source identifySource, field string) models.IdentifyFieldOptionsInput {
+	for _, options := range []*models.IdentifyMetadataOptionsInput{source.options, t.defaultOptions} {
+		if options == nil {
+			continue
+		}
+
+		for _, o := range options.FieldOptions {
+			if o.Field == field {
+				return *o
+			}
+		}
+	}
+
+	return models.IdentifyFieldOptionsInput{
+		Field:    field,
+		Strategy: models.IdentifyFieldStrategyMerge,
+	}
+}
+
+func (t *identifyTask) setCoverImage(source identifySource) bool {
+	for _, options := range []*models.IdentifyMetadataOptionsInput{source.options, t.defaultOptions} {
+		if options != nil && options.SetCoverImage != nil {
+			return *options.SetCoverImage
+		}
+	}
+
+	return true
+}
+
+// modifyScene updates the scene using the scraped scene. Returns the cover
+// image data if the cover image was set.
+func (t *identifyTask) modifyScene(r models.Repository, scene *models.Scene, source identifySource, scraped *models.ScrapedScene) ([]byte, error) {
+	qb := r.Scene()
+
+	updatedTime := time.Now()
+	partial := models.ScenePartial{
+		ID:        scene.ID,
+		UpdatedAt: &models.SQLiteTimestamp{Timestamp: updatedTime},
+	}
+
+	partial.Title = identifyString(t.fieldOptions(source, identifyFieldTitle).Strategy, scene.Title, scraped.Title)
+	partial.Details = identifyString(t.fieldOptions(source, identifyFieldDetails).Strategy, scene.Details, scraped.Details)
+	partial.URL = identifyString(t.fieldOptions(source, identifyFieldURL).Strategy, scene.URL, scraped.URL)
+
+	date := identifyString(t.fieldOptions(source, identifyFieldDate).Strategy, sql.NullString(scene.Date), scraped.Date)
+	if date != nil {
+		partial.Date = &models.SQLiteDate{String: date.String, Valid: true}
+	}
+
+	studioID, err := t.identifyStudio(r, scene, source, scraped.Studio)
+	if err != nil {
+		return nil, err
+	}
+	partial.StudioID = studioID
+
+	if _, err := qb.Update(partial); err != nil {
+		return nil, err
+	}
+
+	performerIDs, err := t.identifyPerformers(r, scene, source, scraped.Performers)
+	if err != nil {
+		return nil, err
+	}
+
+	if performerIDs != nil {
+		if err := qb.UpdatePerformers(scene.ID, performerIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	tagIDs, err := t.identifyTags(r, scene, source, scraped.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if tagIDs != nil {
+		if err := qb.UpdateTags(scene.ID, tagIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if source.endpoint != "" && scraped.RemoteSiteID != nil {
+		stashIDs, err := t.identifyStashIDs(r, scene, source, *scraped.RemoteSiteID)
+		if err != nil {
+			return nil, err
+		}
+
+		if stashIDs != nil {
+			if err := qb.UpdateStashIDs(scene.ID, stashIDs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var coverImage []byte
+	if t.setCoverImage(source) && scraped.Image != nil && *scraped.Image != "" {
+		coverImage, err = utils.ProcessImageInput(*scraped.Image)
+		if err != nil {
+			return nil, fmt.Errorf("error processing cover image: %s", err.Error())
+		}
+
+		if err := qb.UpdateCover(scene.ID, coverImage); err != nil {
+			return nil, err
+		}
+	}
+
+	return coverImage, nil
+}
+
+// identifyString returns the new value of a string field, or nil if the
+// field should not be changed.
+func identifyString(strategy models.IdentifyFieldStrategy, existing sql.NullString, value *string) *sql.NullString {
+	if value == nil || *value == "" {
+		return nil
+	}
+
+	switch strategy {
+	case models.IdentifyFieldStrategyOverwrite:
+	case models.IdentifyFieldStrategyMerge:
+		if existing.Valid && existing.String != "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	return &sql.NullString{String: *value, Valid: true}
+}
+
+// identifyIDs returns the new IDs of a multi-value field, or nil if the
+// field should not be changed.
+func identifyIDs(strategy models.IdentifyFieldStrategy, existing []int, values []int) []int {
+	switch strategy {
+	case models.IdentifyFieldStrategyOverwrite:
+		if len(values) == 0 {
+			return nil
+		}
+		return values
+	case models.IdentifyFieldStrategyMerge:
+		ret := utils.IntAppendUniques(existing, values)
+		if len(ret) == len(existing) {
+			return nil
+		}
+		return ret
+	}
+
+	return nil
+}
+
+func (t *identifyTask) identifyStudio(r models.Repository, scene *models.Scene, source identifySource, studio *models.ScrapedSceneStudio) (*sql.NullInt64, error) {
+	options := t.fieldOptions(source, identifyFieldStudio)
+	if studio == nil || options.Strategy == models.IdentifyFieldStrategyIgnore {
+		return nil, nil
+	}
+
+	if options.Strategy == models.IdentifyFieldStrategyMerge && scene.StudioID.Valid {
+		return nil, nil
+	}
+
+	var studioID int
+	if studio.ID != nil {
+		var err error
+		studioID, err = strconv.Atoi(*studio.ID)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if options.CreateMissing == nil || !*options.CreateMissing {
+			return nil, nil
+		}
+
+		created, err := createIdentifiedStudio(r.Studio(), source.endpoint, studio)
+		if err != nil {
+			return nil, err
+		}
+		studioID = created.ID
+	}
+
+	return &sql.NullInt64{Int64: int64(studioID), Valid: true}, nil
+}
+
+func createIdentifiedStudio(qb models.StudioWriter, endpoint string, studio *models.ScrapedSceneStudio) (*models.Studio, error) {
+	currentTime := time.Now()
+	newStudio := models.Studio{
+		Checksum:  utils.MD5FromString(studio.Name),
+		Name:      sql.NullString{String: studio.Name, Valid: true},
+		CreatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+	}
+	if studio.URL != nil {
+		newStudio.URL = sql.NullString{String: *studio.URL, Valid: true}
+	}
+
+	created, err := qb.Create(newStudio)
+	if err != nil {
+		return nil, fmt.Errorf("error creating studio %s: %s", studio.Name, err.Error())
+	}
+
+	if endpoint != "" && studio.RemoteSiteID != nil {
+		stashIDs := []models.StashID{{
+			Endpoint: endpoint,
+			StashID:  *studio.RemoteSiteID,
+		}}
+		if err := qb.UpdateStashIDs(created.ID, stashIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Infof("Created studio %s", studio.Name)
+	return created, nil
+}
+
+func (t *identifyTask) identifyPerformers(r models.Repository, scene *models.Scene, source identifySource, performers []*models.ScrapedScenePerformer) ([]int, error) {
+	options := t.fieldOptions(source, identifyFieldPerformers)
+	if options.Strategy == models.IdentifyFieldStrategyIgnore {
+		return nil, nil
+	}
+
+	var performerIDs []int
+	for _, p := range performers {
+		if p.ID != nil {
+			performerID, err := strconv.Atoi(*p.ID)
+			if err != nil {
+				return nil, err
+			}
+			performerIDs = append(performerIDs, performerID)
+		} else if options.CreateMissing != nil && *options.CreateMissing && p.Name != "" {
+			created, err := createIdentifiedPerformer(r.Performer(), source.endpoint, p)
+			if err != nil {
+				return nil, err
+			}
+			performerIDs = append(performerIDs, created.ID)
+		}
+	}
+
+	existing, err := r.Scene().GetPerformerIDs(scene.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return identifyIDs(options.Strategy, existing, performerIDs), nil
+}
+
+func createIdentifiedPerformer(qb models.PerformerWriter, endpoint string, performer *models.ScrapedScenePerformer) (*models.Performer, error) {
+	currentTime := time.Now()
+	newPerformer := models.Performer{
+		Checksum:  utils.MD5FromString(performer.Name),
+		Name:      sql.NullString{String: performer.Name, Valid: true},
+		Favorite:  sql.NullBool{Bool: false, Valid: true},
+		CreatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+	}
+	if performer.Gender != nil {
+		newPerformer.Gender = sql.NullString{String: *performer.Gender, Valid: true}
+	}
+	if performer.URL != nil {
+		newPerformer.URL = sql.NullString{String: *performer.URL, Valid: true}
+	}
+	if performer.Birthdate != nil {
+		newPerformer.Birthdate = models.SQLiteDate{String: *performer.Birthdate, Valid: true}
+	}
+
+	created, err := qb.Create(newPerformer)
+	if err != nil {
+		return nil, fmt.Errorf("error creating performer %s: %s", performer.Name, err.Error())
+	}
+
+	if endpoint != "" && performer.RemoteSiteID != nil {
+		stashIDs := []models.StashID{{
+			Endpoint: endpoint,
+			StashID:  *performer.RemoteSiteID,
+		}}
+		if err := qb.UpdateStashIDs(created.ID, stashIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	logger.Infof("Created performer %s", performer.Name)
+	return created, nil
+}
+
+func (t *identifyTask) identifyTags(r models.Repository, scene *models.Scene, source identifySource, tags []*models.ScrapedSceneTag) ([]int, error) {
+	options := t.fieldOptions(source, identifyFieldTags)
+
+	var tagIDs []int
+	if options.Strategy != models.IdentifyFieldStrategyIgnore {
+		for _, tag := range tags {
+			if tag.ID != nil {
+				tagID, err := strconv.Atoi(*tag.ID)
+				if err != nil {
+					return nil, err
+				}
+				tagIDs = append(tagIDs, tagID)
+			} else if options.CreateMissing != nil && *options.CreateMissing && tag.Name != "" {
+				created, err := createIdentifiedTag(r.Tag(), tag.Name)
+				if err != nil {
+					return nil, err
+				}
+				tagIDs = append(tagIDs, created.ID)
+			}
+		}
+	}
+
+	existing, err := r.Scene().GetTagIDs(scene.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy := options.Strategy
+	if t.identifiedTagID != nil {
+		// the identified tag is always added
+		if strategy == models.IdentifyFieldStrategyIgnore {
+			strategy = models.IdentifyFieldStrategyMerge
+		}
+		tagIDs = utils.IntAppendUnique(tagIDs, *t.identifiedTagID)
+	}
+
+	return identifyIDs(strategy, existing, tagIDs), nil
+}
+
+func createIdentifiedTag(qb models.TagWriter, name string) (*models.Tag, error) {
+	currentTime := time.Now()
+	newTag := models.Tag{
+		Name:      name,
+		CreatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+	}
+
+	created, err := qb.Create(newTag)
+	if err != nil {
+		return nil, fmt.Errorf("error creating tag %s: %s", name, err.Error())
+	}
+
+	logger.Infof("Created tag %s", name)
+	return created, nil
+}
+
+func (t *identifyTask) identifyStashIDs(r models.Repository, scene *models.Scene, source identifySource, remoteSiteID string) ([]models.StashID, error) {
+	options := t.fieldOptions(source, identifyFieldStashIDs)
+
+	newStashID := models.StashID{
+		Endpoint: source.endpoint,
+		StashID:  remoteSiteID,
+	}
+
+	switch options.Strategy {
+	case models.IdentifyFieldStrategyOverwrite:
+		return []models.StashID{newStashID}, nil
+	case models.IdentifyFieldStrategyMerge:
+		existing, err := r.Scene().GetStashIDs(scene.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		var ret []models.StashID
+		for _, s := range existing {
+			if s.Endpoint == source.endpoint {
+				if s.StashID == remoteSiteID {
+					// already set
+					return nil, nil
+				}
+
+				// replace the stash id for the endpoint
+				continue
+			}
+			ret = append(ret, *s)
+		}
+
+		return append(ret, newStashID), nil
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,226 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestIdentifyString(t *testing.T) {
+	existing := sql.NullString{String: "existing", Valid: true}
+	empty := sql.NullString{}
+	value := "value"
+	blank := ""
+
+	assert.Nil(t, identifyString(models.IdentifyFieldStrategyIgnore, empty, &value))
+	assert.Nil(t, identifyString(models.IdentifyFieldStrategyMerge, existing, &value))
+	assert.Nil(t, identifyString(models.IdentifyFieldStrategyOverwrite, existing, nil))
+	assert.Nil(t, identifyString(models.IdentifyFieldStrategyOverwrite, existing, &blank))
+	assert.Equal(t, "value", identifyString(models.IdentifyFieldStrategyMerge, empty, &value).String)
+	assert.Equal(t, "value", identifyString(models.IdentifyFieldStrategyOverwrite, existing, &value).String)
+}
+
+func TestIdentifyIDs(t *testing.T) {
+	assert.Nil(t, identifyIDs(models.IdentifyFieldStrategyIgnore, []int{1}, []int{2}))
+	assert.Nil(t, identifyIDs(models.IdentifyFieldStrategyMerge, []int{1, 2}, []int{2}))
+	assert.Nil(t, identifyIDs(models.IdentifyFieldStrategyOverwrite, []int{1}, nil))
+	assert.Equal(t, []int{1, 2}, identifyIDs(models.IdentifyFieldStrategyMerge, []int{1}, []int{2}))
+	assert.Equal(t, []int{2}, identifyIDs(models.IdentifyFieldStrategyOverwrite, []int{1}, []int{2}))
+}
+
+func TestNewIdentifyTask(t *testing.T) {
+	const (
+		missingTagID = 1
+		tagID        = 2
+	)
+
+	repo := mocks.NewTransactionManager()
+	tqb := repo.Tag().(*mocks.TagReaderWriter)
+	tqb.On("Find", missingTagID).Return(nil, nil)
+	tqb.On("Find", tagID).Return(&models.Tag{ID: tagID}, nil)
+
+	s := &singleton{
+		TxnManager: repo,
+	}
+	ctx := context.TODO()
+
+	// sources are required
+	_, err := s.newIdentifyTask(ctx, models.IdentifyMetadataInput{})
+	assert.NotNil(t, err)
+
+	// each source must have a stash-box endpoint or a scraper
+	_, err = s.newIdentifyTask(ctx, models.IdentifyMetadataInput{
+		Sources: []*models.IdentifySourceInput{{}},
+	})
+	assert.NotNil(t, err)
+
+	// field options are validated
+	_, err = s.newIdentifyTask(ctx, models.IdentifyMetadataInput{
+		Options: &models.IdentifyMetadataOptionsInput{
+			FieldOptions: []*models.IdentifyFieldOptionsInput{
+				{
+					Field:    "invalid",
+					Strategy: models.IdentifyFieldStrategyMerge,
+				},
+			},
+		},
+	})
+	assert.NotNil(t, err)
+
+	// the identified tag must exist
+	task := &identifyTask{txnManager: repo}
+	err = s.setIdentifiedTag(ctx, task, "invalid")
+	assert.NotNil(t, err)
+	err = s.setIdentifiedTag(ctx, task, "1")
+	assert.NotNil(t, err)
+	err = s.setIdentifiedTag(ctx, task, "2")
+	assert.Nil(t, err)
+	assert.Equal(t, tagID, *task.identifiedTagID)
+
+	tqb.AssertExpectations(t)
+}
+
+func TestIdentifyModifyScene(t *testing.T) {
+	const (
+		sceneID            = 1
+		existingPerformer  = 2
+		scrapedPerformer   = 3
+		existingTag        = 4
+		createdTag         = 5
+		identifiedTag      = 6
+		createdTagName     = "created tag"
+		scrapedTitle       = "scraped title"
+		scrapedDetails     = "scraped details"
+		existingDetails    = "existing details"
+		scrapedPerformerID = "3"
+		existingTagID      = "4"
+	)
+
+	title := scrapedTitle
+	details := scrapedDetails
+	performerID := scrapedPerformerID
+	tagID := existingTagID
+	createMissing := true
+	setCover := false
+
+	scene := &models.Scene{
+		ID:      sceneID,
+		Title:   sql.NullString{String: "existing title", Valid: true},
+		Details: sql.NullString{String: existingDetails, Valid: true},
+	}
+
+	scraped := &models.ScrapedScene{
+		Title:   &title,
+		Details: &details,
+		Performers: []*models.ScrapedScenePerformer{
+			{ID: &performerID, Name: "performer"},
+			{Name: "missing performer"},
+		},
+		Tags: []*models.ScrapedSceneTag{
+			{ID: &tagID, Name: "tag"},
+			{Name: createdTagName},
+		},
+	}
+
+	task := identifyTask{
+		defaultOptions: &models.IdentifyMetadataOptionsInput{
+			SetCoverImage: &setCover,
+			FieldOptions: []*models.IdentifyFieldOptionsInput{
+				{Field: identifyFieldTitle, Strategy: models.IdentifyFieldStrategyOverwrite},
+			},
+		},
+		identifiedTagID: &[]int{identifiedTag}[0],
+	}
+
+	source := identifySource{
+		name: "source",
+		options: &models.IdentifyMetadataOptionsInput{
+			FieldOptions: []*models.IdentifyFieldOptionsInput{
+				{Field: identifyFieldTags, Strategy: models.IdentifyFieldStrategyMerge, CreateMissing: &createMissing},
+			},
+		},
+	}
+
+	repo := mocks.NewTransactionManager()
+	sqb := repo.Scene().(*mocks.SceneReaderWriter)
+	tqb := repo.Tag().(*mocks.TagReaderWriter)
+
+	sqb.On("Update", mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.ID == sceneID && p.Title != nil && p.Title.String == scrapedTitle && p.Details == nil && p.StudioID == nil
+	})).Return(nil, nil).Once()
+
+	sqb.On("GetPerformerIDs", sceneID).Return([]int{existingPerformer}, nil).Once()
+	sqb.On("UpdatePerformers", sceneID, []int{existingPerformer, scrapedPerformer}).Return(nil).Once()
+
+	tqb.On("Create", mock.MatchedBy(func(t models.Tag) bool {
+		return t.Name == createdTagName
+	})).Return(&models.Tag{ID: createdTag, Name: createdTagName}, nil).Once()
+
+	sqb.On("GetTagIDs", sceneID).Return([]int{existingTag}, nil).Once()
+	sqb.On("UpdateTags", sceneID, []int{existingTag, createdTag, identifiedTag}).Return(nil).Once()
+
+	cover, err := task.modifyScene(repo, scene, source, scraped)
+
+	assert.Nil(t, err)
+	assert.Nil(t, cover)
+
+	sqb.AssertExpectations(t)
+	tqb.AssertExpectations(t)
+}
+
+func TestIdentifySceneSourceOrder(t *testing.T) {
+	const (
+		sceneID      = 1
+		scrapedTitle = "scraped title"
+	)
+
+	title := scrapedTitle
+	setCover := false
+
+	var called []string
+	newSource := func(name string, ret *models.ScrapedScene) identifySource {
+		return identifySource{
+			name: name,
+			scrape: func(scene *models.Scene) (*models.ScrapedScene, error) {
+				called = append(called, name)
+				return ret, nil
+			},
+		}
+	}
+
+	repo := mocks.NewTransactionManager()
+	sqb := repo.Scene().(*mocks.SceneReaderWriter)
+
+	sqb.On("Find", sceneID).Return(&models.Scene{ID: sceneID}, nil).Once()
+	sqb.On("Update", mock.MatchedBy(func(p models.ScenePartial) bool {
+		return p.ID == sceneID && p.Title != nil && p.Title.String == scrapedTitle
+	})).Return(nil, nil).Once()
+	sqb.On("GetPerformerIDs", sceneID).Return(nil, nil).Maybe()
+	sqb.On("GetTagIDs", sceneID).Return(nil, nil).Maybe()
+
+	task := identifyTask{
+		txnManager: repo,
+		sources: []identifySource{
+			newSource("first", nil),
+			newSource("second", &models.ScrapedScene{Title: &title}),
+			newSource("third", &models.ScrapedScene{}),
+		},
+		defaultOptions: &models.IdentifyMetadataOptionsInput{
+			SetCoverImage: &setCover,
+		},
+	}
+
+	task.identifyScene(context.TODO(), sceneID)
+
+	// the first source returns nothing, so the scene is identified using the
+	// second source, and the third source is not used
+	assert.Equal(t, []string{"first", "second"}, called)
+
+	sqb.AssertExpectations(t)
+}
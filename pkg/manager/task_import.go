@@ -75,8 +75,8 @@ func CreateImportTask(a models.HashAlgorithm, input models.ImportObjectsInput) (
 	}, nil
 }
 
-func (t *ImportTask) GetStatus() JobStatus {
-	return Import
+func (t *ImportTask) GetDescription() string {
+	return "Importing..."
 }
 
 func (t *ImportTask) Start(wg *sync.WaitGroup) {
@@ -126,6 +126,8 @@ func (t *ImportTask) Start(wg *sync.WaitGroup) {
 			logger.Errorf("Error resetting database: %s", err.Error())
 			return
 		}
+
+		InvalidateUsersExist()
 	}
 
 	ctx := context.TODO()
@@ -480,6 +482,7 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 			performerWriter := r.Performer()
 			studioWriter := r.Studio()
 			markerWriter := r.SceneMarker()
+			userReader := r.User()
 
 			sceneImporter := &scene.Importer{
 				ReaderWriter: readerWriter,
@@ -494,6 +497,7 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 				PerformerWriter: performerWriter,
 				StudioWriter:    studioWriter,
 				TagWriter:       tagWriter,
+				UserReader:      userReader,
 			}
 
 			if err := performImport(sceneImporter, t.DuplicateBehaviour); err != nil {
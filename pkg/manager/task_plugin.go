@@ -1,71 +1,78 @@
 package manager
 
 import (
-	"time"
+	"context"
+	"errors"
+	"fmt"
 
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/plugin/common"
 )
 
-func (s *singleton) RunPluginTask(pluginID string, taskName string, args []*models.PluginArgInput, serverConnection common.StashServerConnection) {
-	if s.Status.Status != Idle {
-		return
-	}
-	s.Status.SetStatus(PluginOperation)
-	s.Status.indefiniteProgress()
-
-	go func() {
-		defer s.returnToIdleState()
-
-		progress := make(chan float64)
-		task, err := s.PluginCache.CreateTask(pluginID, taskName, serverConnection, args, progress)
-		if err != nil {
-			logger.Errorf("Error creating plugin task: %s", err.Error())
-			return
+func (s *singleton) RunPluginTask(ctx context.Context, pluginID string, taskName string, args []*models.PluginArgInput, serverConnection common.StashServerConnection) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		// the saved filter may have been deleted since the task was queued
+		if err := s.TxnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+			return plugin.ValidateSavedFilterArg(r.SavedFilter(), args)
+		}); err != nil {
+			return fmt.Errorf("error creating plugin task: %s", err.Error())
 		}
 
-		err = task.Start()
+		pluginProgress := make(chan float64)
+		task, err := s.PluginCache.CreateTask(pluginID, taskName, serverConnection, args, pluginProgress)
 		if err != nil {
-			logger.Errorf("Error running plugin task: %s", err.Error())
-			return
+			return fmt.Errorf("error creating plugin task: %s", err.Error())
 		}
 
-		done := make(chan bool)
-		go func() {
-			defer close(done)
-			task.Wait()
+		return runPluginTask(ctx, progress, task, pluginProgress)
+	})
 
-			output := task.GetResult()
-			if output == nil {
-				logger.Debug("Plugin returned no result")
-			} else {
-				if output.Error != nil {
-					logger.Errorf("Plugin returned error: %s", *output.Error)
-				} else if output.Output != nil {
-					logger.Debugf("Plugin returned: %v", output.Output)
-				}
-			}
-		}()
+	// plugin tasks do not depend on other jobs, so run them immediately
+	return s.JobManager.Start(ctx, fmt.Sprintf("Running plugin task: %s", taskName), j)
+}
+
+// runPluginTask runs the plugin task to completion, reporting its progress.
+// Returns an error if the plugin returns an error, so that the job is marked
+// as failed.
+func runPluginTask(ctx context.Context, progress *job.Progress, task plugin.Task, pluginProgress chan float64) error {
+	if err := task.Start(); err != nil {
+		return fmt.Errorf("error running plugin task: %s", err.Error())
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		task.Wait()
 
-		// TODO - refactor stop to use channels
-		// check for stop every five seconds
-		pollingTime := time.Second * 5
-		stopPoller := time.Tick(pollingTime)
-		for {
-			select {
-			case <-done:
-				return
-			case p := <-progress:
-				s.Status.setProgressPercent(p)
-			case <-stopPoller:
-				if s.Status.stopping {
-					if err := task.Stop(); err != nil {
-						logger.Errorf("Error stopping plugin operation: %s", err.Error())
-					}
-					return
-				}
+		output := task.GetResult()
+		if output == nil {
+			logger.Debug("Plugin returned no result")
+		} else {
+			if output.Error != nil {
+				done <- errors.New(*output.Error)
+			} else if output.Output != nil {
+				logger.Debugf("Plugin returned: %v", output.Output)
 			}
 		}
 	}()
+
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				return fmt.Errorf("plugin returned error: %s", err.Error())
+			}
+			return nil
+		case p := <-pluginProgress:
+			progress.SetPercent(p)
+		case <-ctx.Done():
+			if err := task.Stop(); err != nil {
+				logger.Errorf("Error stopping plugin operation: %s", err.Error())
+			}
+			return nil
+		}
+	}
 }
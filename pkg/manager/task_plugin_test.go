@@ -0,0 +1,69 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/plugin/common"
+)
+
+// testPluginTask is a plugin task that completes immediately with the
+// provided output.
+type testPluginTask struct {
+	output *common.PluginOutput
+}
+
+func (t *testPluginTask) Start() error {
+	return nil
+}
+
+func (t *testPluginTask) Stop() error {
+	return nil
+}
+
+func (t *testPluginTask) Wait() {}
+
+func (t *testPluginTask) GetResult() *common.PluginOutput {
+	return t.output
+}
+
+func runTestPluginTask(t *testing.T, output *common.PluginOutput) *job.Job {
+	m := job.NewManager()
+	id := m.Start(context.Background(), "plugin task", job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		return runPluginTask(ctx, progress, &testPluginTask{output: output}, make(chan float64))
+	}))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		j := m.GetJob(id)
+		if j != nil && (j.Status == job.StatusFinished || j.Status == job.StatusFailed) {
+			return j
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for plugin task job to complete")
+	return nil
+}
+
+func TestRunPluginTaskError(t *testing.T) {
+	output := &common.PluginOutput{}
+	output.SetError(errors.New("plugin failed"))
+
+	j := runTestPluginTask(t, output)
+	assert.Equal(t, job.StatusFailed, j.Status)
+	if assert.NotNil(t, j.Error) {
+		assert.Contains(t, *j.Error, "plugin failed")
+	}
+}
+
+func TestRunPluginTaskSuccess(t *testing.T) {
+	j := runTestPluginTask(t, &common.PluginOutput{Output: "ok"})
+	assert.Equal(t, job.StatusFinished, j.Status)
+	assert.Nil(t, j.Error)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
@@ -19,10 +20,17 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
+// hookExecutor executes the plugin hooks triggered by changes made during a
+// scan.
+type hookExecutor interface {
+	ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string)
+}
+
 type ScanTask struct {
 	TxnManager           models.TransactionManager
 	FilePath             string
@@ -33,7 +41,33 @@ type ScanTask struct {
 	GenerateSprite       bool
 	GeneratePreview      bool
 	GenerateImagePreview bool
+	GeneratePhash        bool
 	zipGallery           *models.Gallery
+	pluginCache          hookExecutor
+	summary              *scanSummary
+}
+
+// scanSummary counts the files that were added and moved during a scan. It
+// is safe for concurrent use. Methods may be called on a nil scanSummary.
+type scanSummary struct {
+	added int64
+	moved int64
+}
+
+func (s *scanSummary) addNew() {
+	if s != nil {
+		atomic.AddInt64(&s.added, 1)
+	}
+}
+
+func (s *scanSummary) addMoved() {
+	if s != nil {
+		atomic.AddInt64(&s.moved, 1)
+	}
+}
+
+func (s *scanSummary) String() string {
+	return fmt.Sprintf("%d new files, %d moved files", atomic.LoadInt64(&s.added), atomic.LoadInt64(&s.moved))
 }
 
 func (t *ScanTask) Start(wg *sizedwaitgroup.SizedWaitGroup) {
@@ -45,6 +79,15 @@ func (t *ScanTask) Start(wg *sizedwaitgroup.SizedWaitGroup) {
 		if s != nil {
 			iwg := sizedwaitgroup.New(2)
 
+			if t.GeneratePhash {
+				iwg.Add()
+				taskPhash := GeneratePhashTask{
+					Scene:      *s,
+					txnManager: t.TxnManager,
+				}
+				go taskPhash.Start(&iwg)
+			}
+
 			if t.GenerateSprite {
 				iwg.Add()
 				taskSprite := GenerateSpriteTask{
@@ -96,12 +139,13 @@ func (t *ScanTask) scanGallery() {
 	var g *models.Gallery
 	images := 0
 	scanImages := false
+	updated := false
 
 	if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
 		var err error
 		g, err = r.Gallery().FindByPath(t.FilePath)
 
-		if g != nil && err != nil {
+		if g != nil && err == nil {
 			images, err = r.Image().CountByGalleryID(g.ID)
 			if err != nil {
 				return fmt.Errorf("error getting images for zip gallery %s: %s", t.FilePath, err.Error())
@@ -127,6 +171,7 @@ func (t *ScanTask) scanGallery() {
 		if !g.FileModTime.Valid {
 			// we will also need to rescan the zip contents
 			scanImages = true
+			updated = true
 			logger.Infof("setting file modification time on %s", t.FilePath)
 
 			if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
@@ -153,6 +198,7 @@ func (t *ScanTask) scanGallery() {
 		modified := t.isFileModified(fileModTime, g.FileModTime)
 		if modified {
 			scanImages = true
+			updated = true
 			logger.Infof("%s has been updated: rescanning", t.FilePath)
 
 			// update the checksum and the modification time
@@ -184,6 +230,11 @@ func (t *ScanTask) scanGallery() {
 
 		// scan the zip files if the gallery has no images
 		scanImages = scanImages || images == 0
+
+		// existing galleries are updated, not created
+		if updated {
+			t.executePostHooks(g.ID, plugin.GalleryUpdatePost)
+		}
 	} else {
 		// Ignore directories.
 		if isDir, _ := utils.DirExists(t.FilePath); isDir {
@@ -196,6 +247,8 @@ func (t *ScanTask) scanGallery() {
 			return
 		}
 
+		moved := false
+		created := false
 		if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
 			qb := r.Gallery()
 			g, _ = qb.FindByChecksum(checksum)
@@ -204,15 +257,11 @@ func (t *ScanTask) scanGallery() {
 				if exists {
 					logger.Infof("%s already exists.  Duplicate of %s ", t.FilePath, g.Path.String)
 				} else {
-					logger.Infof("%s already exists.  Updating path...", t.FilePath)
-					g.Path = sql.NullString{
-						String: t.FilePath,
-						Valid:  true,
-					}
-					g, err = qb.Update(*g)
+					g, err = t.moveGallery(r, g, fileModTime)
 					if err != nil {
 						return err
 					}
+					moved = true
 				}
 			} else {
 				currentTime := time.Now()
@@ -246,6 +295,8 @@ func (t *ScanTask) scanGallery() {
 						return err
 					}
 					scanImages = true
+					created = true
+					t.summary.addNew()
 				}
 			}
 
@@ -254,6 +305,15 @@ func (t *ScanTask) scanGallery() {
 			logger.Error(err.Error())
 			return
 		}
+
+		if moved {
+			t.summary.addMoved()
+			t.executePostHooks(g.ID, plugin.GalleryUpdatePost)
+		}
+
+		if created {
+			t.executePostHooks(g.ID, plugin.GalleryCreatePost)
+		}
 	}
 
 	if g != nil {
@@ -266,6 +326,36 @@ func (t *ScanTask) scanGallery() {
 	}
 }
 
+// moveGallery updates the path of a zip gallery whose file no longer exists
+// to the scanned file, which has the same checksum. The paths of the images
+// within the zip file are updated to match.
+func (t *ScanTask) moveGallery(r models.Repository, g *models.Gallery, fileModTime time.Time) (*models.Gallery, error) {
+	logger.Infof("%s has been moved or renamed from %s. Updating path...", t.FilePath, g.Path.String)
+
+	images, err := r.Image().FindByGalleryID(g.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting images for zip gallery %s: %s", g.Path.String, err.Error())
+	}
+
+	oldPath := g.Path.String
+	for _, i := range images {
+		// only images within the zip file are moved
+		if !strings.HasPrefix(i.Path, image.ZipFilename(oldPath, "")) {
+			continue
+		}
+
+		newPath := image.ChangeZipFilename(i.Path, t.FilePath)
+		if _, err := image.UpdateMovedFile(r.Image(), i.ID, newPath, i.FileModTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return gallery.UpdateMovedFile(r.Gallery(), g.ID, t.FilePath, models.NullSQLiteTimestamp{
+		Timestamp: fileModTime,
+		Valid:     true,
+	})
+}
+
 func (t *ScanTask) getFileModTime() (time.Time, error) {
 	fi, err := os.Stat(t.FilePath)
 	if err != nil {
@@ -346,16 +436,28 @@ func (t *ScanTask) scanScene() *models.Scene {
 
 	var retScene *models.Scene
 	var s *models.Scene
+	var existingFile *models.File
 
 	if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
 		var err error
 		s, err = r.Scene().FindByPath(t.FilePath)
+		if err != nil || s != nil {
+			return err
+		}
+
+		// check for a file that is not the primary file of its scene
+		existingFile, err = r.File().FindByPath(t.FilePath)
 		return err
 	}); err != nil {
 		logger.Error(err.Error())
 		return nil
 	}
 
+	if existingFile != nil {
+		// the file is already in the database
+		return nil
+	}
+
 	fileModTime, err := t.getFileModTime()
 	if err != nil {
 		return logError(err)
@@ -475,6 +577,40 @@ func (t *ScanTask) scanScene() *models.Scene {
 		return nil
 	}
 
+	var checksum string
+
+	logger.Infof("%s not found. Calculating oshash...", t.FilePath)
+	oshash, err := utils.OSHashFromFilePath(t.FilePath)
+	if err != nil {
+		return logError(err)
+	}
+
+	// check for a moved file by oshash first, so that the MD5 of moved files
+	// does not need to be calculated. Secondary files of scenes are checked
+	// as well as primary files.
+	var sameHashFiles []*models.File
+	if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		sameHashFiles, err = r.File().FindByOSHash(oshash)
+		return err
+	}); err != nil {
+		return logError(err)
+	}
+
+	if moved := findMissingFile(sameHashFiles); moved != nil {
+		// the moved scene is returned so that the scan-time generation is
+		// performed for it, as for new scenes
+		retScene, err = t.handleMovedFile(moved, fileModTime)
+		if err != nil {
+			return logError(err)
+		}
+
+		if retScene != nil {
+			t.makeScreenshots(nil, retScene.GetHash(t.fileNamingAlgorithm))
+		}
+		return retScene
+	}
+
 	videoFile, err := ffmpeg.NewVideoFile(instance.FFProbePath, t.FilePath, t.StripFileExtension)
 	if err != nil {
 		logger.Error(err.Error())
@@ -487,14 +623,6 @@ func (t *ScanTask) scanScene() *models.Scene {
 		videoFile.SetTitleFromPath(t.StripFileExtension)
 	}
 
-	var checksum string
-
-	logger.Infof("%s not found. Calculating oshash...", t.FilePath)
-	oshash, err := utils.OSHashFromFilePath(t.FilePath)
-	if err != nil {
-		return logError(err)
-	}
-
 	if t.fileNamingAlgorithm == models.HashAlgorithmMd5 || t.calculateMD5 {
 		checksum, err = t.calculateChecksum()
 		if err != nil {
@@ -502,20 +630,28 @@ func (t *ScanTask) scanScene() *models.Scene {
 		}
 	}
 
-	// check for scene by checksum and oshash - MD5 should be
-	// redundant, but check both
-	t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
-		qb := r.Scene()
-		if checksum != "" {
-			s, _ = qb.FindByChecksum(checksum)
+	// check for files by checksum as well, in case the existing file does
+	// not have an oshash
+	if len(sameHashFiles) == 0 && checksum != "" {
+		if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+			var err error
+			sameHashFiles, err = r.File().FindByChecksum(checksum)
+			return err
+		}); err != nil {
+			return logError(err)
 		}
+	}
 
-		if s == nil {
-			s, _ = qb.FindByOSHash(oshash)
+	moved := findMissingFile(sameHashFiles)
+	if moved == nil && len(sameHashFiles) > 0 {
+		if err := t.TxnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+			var err error
+			s, err = r.Scene().Find(sameHashFiles[0].SceneID)
+			return err
+		}); err != nil {
+			return logError(err)
 		}
-
-		return nil
-	})
+	}
 
 	sceneHash := oshash
 
@@ -525,22 +661,14 @@ func (t *ScanTask) scanScene() *models.Scene {
 
 	t.makeScreenshots(videoFile, sceneHash)
 
-	if s != nil {
-		exists, _ := utils.FileExists(s.Path)
-		if exists {
-			logger.Infof("%s already exists. Duplicate of %s", t.FilePath, s.Path)
-		} else {
-			logger.Infof("%s already exists. Updating path...", t.FilePath)
-			scenePartial := models.ScenePartial{
-				ID:   s.ID,
-				Path: &t.FilePath,
-			}
-			if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
-				_, err := r.Scene().Update(scenePartial)
-				return err
-			}); err != nil {
-				return logError(err)
-			}
+	if moved != nil {
+		retScene, err = t.handleMovedFile(moved, fileModTime)
+		if err != nil {
+			return logError(err)
+		}
+	} else if s != nil {
+		if err := t.addSceneFile(s, videoFile, container, checksum, oshash, fileModTime); err != nil {
+			return logError(err)
 		}
 	} else {
 		logger.Infof("%s doesn't exist. Creating new item...", t.FilePath)
@@ -579,11 +707,98 @@ func (t *ScanTask) scanScene() *models.Scene {
 		}); err != nil {
 			return logError(err)
 		}
+
+		t.summary.addNew()
+		t.executePostHooks(retScene.ID, plugin.SceneCreatePost)
 	}
 
 	return retScene
 }
 
+// addSceneFile adds the scanned file to an existing scene that has a file
+// with the same hash.
+func (t *ScanTask) addSceneFile(s *models.Scene, videoFile *ffmpeg.VideoFile, container ffmpeg.Container, checksum string, oshash string, fileModTime time.Time) error {
+	logger.Infof("%s has the same hash as %s. Adding file to existing scene...", t.FilePath, s.Path)
+
+	currentTime := time.Now()
+	newFile := models.File{
+		SceneID:    s.ID,
+		Path:       t.FilePath,
+		Checksum:   sql.NullString{String: checksum, Valid: checksum != ""},
+		OSHash:     sql.NullString{String: oshash, Valid: oshash != ""},
+		Size:       sql.NullString{String: strconv.FormatInt(videoFile.Size, 10), Valid: true},
+		Duration:   sql.NullFloat64{Float64: videoFile.Duration, Valid: true},
+		VideoCodec: sql.NullString{String: videoFile.VideoCodec, Valid: true},
+		Format:     sql.NullString{String: string(container), Valid: true},
+		AudioCodec: sql.NullString{String: videoFile.AudioCodec, Valid: true},
+		Width:      sql.NullInt64{Int64: int64(videoFile.Width), Valid: true},
+		Height:     sql.NullInt64{Int64: int64(videoFile.Height), Valid: true},
+		Framerate:  sql.NullFloat64{Float64: videoFile.FrameRate, Valid: true},
+		Bitrate:    sql.NullInt64{Int64: videoFile.Bitrate, Valid: true},
+		FileModTime: models.NullSQLiteTimestamp{
+			Timestamp: fileModTime,
+			Valid:     true,
+		},
+		CreatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: currentTime},
+	}
+
+	if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		_, err := r.File().Create(newFile)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	t.summary.addNew()
+	t.executePostHooks(s.ID, plugin.SceneUpdatePost)
+	return nil
+}
+
+// findMissingFile returns the first of the files that no longer exists on
+// the filesystem, or nil if all of the files exist.
+func findMissingFile(files []*models.File) *models.File {
+	for _, f := range files {
+		if exists, _ := utils.FileExists(f.Path); !exists {
+			return f
+		}
+	}
+
+	return nil
+}
+
+// handleMovedFile sets the path of the file, which has been moved or renamed,
+// to the path of the scanned file. f may be the primary or a secondary file
+// of its scene. Returns the updated scene if f is the primary file, so that
+// its generated files can be created if missing. Generated files are created
+// from the primary file, so nil is returned for secondary files.
+func (t *ScanTask) handleMovedFile(f *models.File, fileModTime time.Time) (*models.Scene, error) {
+	logger.Infof("%s has been moved or renamed from %s. Updating path...", t.FilePath, f.Path)
+
+	modTime := models.NullSQLiteTimestamp{
+		Timestamp: fileModTime,
+		Valid:     true,
+	}
+
+	var ret *models.Scene
+	if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		if f.Primary {
+			var err error
+			ret, err = scene.UpdateMovedFile(r.Scene(), f.SceneID, t.FilePath, modTime)
+			return err
+		}
+
+		_, err := scene.UpdateMovedSecondaryFile(r.File(), *f, t.FilePath, modTime)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	t.summary.addMoved()
+	t.executePostHooks(f.SceneID, plugin.SceneUpdatePost)
+	return ret, nil
+}
+
 func (t *ScanTask) rescanScene(s *models.Scene, fileModTime time.Time) (*models.Scene, error) {
 	logger.Infof("%s has been updated: rescanning", t.FilePath)
 
@@ -812,20 +1027,9 @@ func (t *ScanTask) scanImage() {
 			exists := image.FileExists(i.Path)
 			if exists {
 				logger.Infof("%s already exists.  Duplicate of %s ", image.PathDisplayName(t.FilePath), image.PathDisplayName(i.Path))
-			} else {
-				logger.Infof("%s already exists.  Updating path...", image.PathDisplayName(t.FilePath))
-				imagePartial := models.ImagePartial{
-					ID:   i.ID,
-					Path: &t.FilePath,
-				}
-
-				if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
-					_, err := r.Image().Update(imagePartial)
-					return err
-				}); err != nil {
-					logger.Error(err.Error())
-					return
-				}
+			} else if err := t.handleMovedImage(i, fileModTime); err != nil {
+				logger.Error(err.Error())
+				return
 			}
 		} else {
 			logger.Infof("%s doesn't exist.  Creating new item...", image.PathDisplayName(t.FilePath))
@@ -853,6 +1057,9 @@ func (t *ScanTask) scanImage() {
 				logger.Error(err.Error())
 				return
 			}
+
+			t.summary.addNew()
+			t.executePostHooks(i.ID, plugin.ImageCreatePost)
 		}
 
 		if t.zipGallery != nil {
@@ -866,12 +1073,20 @@ func (t *ScanTask) scanImage() {
 		} else if config.GetCreateGalleriesFromFolders() {
 			// create gallery from folder or associate with existing gallery
 			logger.Infof("Associating image %s with folder gallery", i.Path)
+			var galleryID int
+			var isNewGallery bool
 			if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
-				return t.associateImageWithFolderGallery(i.ID, r.Gallery())
+				var err error
+				galleryID, isNewGallery, err = t.associateImageWithFolderGallery(i.ID, r.Gallery())
+				return err
 			}); err != nil {
 				logger.Error(err.Error())
 				return
 			}
+
+			if isNewGallery {
+				t.executePostHooks(galleryID, plugin.GalleryCreatePost)
+			}
 		}
 	}
 
@@ -880,6 +1095,26 @@ func (t *ScanTask) scanImage() {
 	}
 }
 
+// handleMovedImage updates the path of an image whose file no longer exists
+// to the scanned file, which has the same checksum.
+func (t *ScanTask) handleMovedImage(i *models.Image, fileModTime time.Time) error {
+	logger.Infof("%s has been moved or renamed from %s. Updating path...", image.PathDisplayName(t.FilePath), image.PathDisplayName(i.Path))
+
+	if err := t.TxnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		_, err := image.UpdateMovedFile(r.Image(), i.ID, t.FilePath, models.NullSQLiteTimestamp{
+			Timestamp: fileModTime,
+			Valid:     true,
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	t.summary.addMoved()
+	t.executePostHooks(i.ID, plugin.ImageUpdatePost)
+	return nil
+}
+
 func (t *ScanTask) rescanImage(i *models.Image, fileModTime time.Time) (*models.Image, error) {
 	logger.Infof("%s has been updated: rescanning", t.FilePath)
 
@@ -931,14 +1166,18 @@ func (t *ScanTask) rescanImage(i *models.Image, fileModTime time.Time) (*models.
 	return ret, nil
 }
 
-func (t *ScanTask) associateImageWithFolderGallery(imageID int, qb models.GalleryReaderWriter) error {
+// associateImageWithFolderGallery adds the image to the gallery for its
+// folder, creating the gallery if necessary. Returns the gallery id and
+// whether the gallery was created.
+func (t *ScanTask) associateImageWithFolderGallery(imageID int, qb models.GalleryReaderWriter) (int, bool, error) {
 	// find a gallery with the path specified
 	path := filepath.Dir(t.FilePath)
 	g, err := qb.FindByPath(path)
 	if err != nil {
-		return err
+		return 0, false, err
 	}
 
+	created := false
 	if g == nil {
 		checksum := utils.MD5FromString(path)
 
@@ -958,13 +1197,22 @@ func (t *ScanTask) associateImageWithFolderGallery(imageID int, qb models.Galler
 		logger.Infof("Creating gallery for folder %s", path)
 		g, err = qb.Create(newGallery)
 		if err != nil {
-			return err
+			return 0, false, err
 		}
+		created = true
 	}
 
 	// associate image with gallery
 	err = gallery.AddImage(qb, g.ID, imageID)
-	return err
+	return g.ID, created, err
+}
+
+// executePostHooks executes the plugin hooks for objects created during the
+// scan.
+func (t *ScanTask) executePostHooks(id int, hookType plugin.HookTriggerEnum) {
+	if t.pluginCache != nil {
+		t.pluginCache.ExecutePostHooks(context.TODO(), id, hookType, nil, nil)
+	}
 }
 
 func (t *ScanTask) generateThumbnail(i *models.Image) {
@@ -1031,6 +1279,9 @@ func (t *ScanTask) doesPathExist() bool {
 			s, _ := r.Scene().FindByPath(t.FilePath)
 			if s != nil {
 				ret = true
+			} else {
+				f, _ := r.File().FindByPath(t.FilePath)
+				ret = f != nil
 			}
 		} else if matchExtension(t.FilePath, imgExt) {
 			i, _ := r.Image().FindByPath(t.FilePath)
@@ -0,0 +1,163 @@
+package manager
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/plugin"
+)
+
+func TestFindMissingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "stash-scan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	existingPath := filepath.Join(dir, "existing.mp4")
+	if err := ioutil.WriteFile(existingPath, []byte{}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	existing := &models.File{ID: 1, Path: existingPath}
+	missing := &models.File{ID: 2, Path: filepath.Join(dir, "missing.mp4")}
+
+	assert.Nil(t, findMissingFile(nil))
+	assert.Nil(t, findMissingFile([]*models.File{existing}))
+	assert.Equal(t, missing, findMissingFile([]*models.File{existing, missing}))
+}
+
+func TestHandleMovedFile(t *testing.T) {
+	const (
+		sceneID     = 1
+		primaryID   = 2
+		secondaryID = 3
+		newPath     = "new.mp4"
+	)
+
+	repo := mocks.NewTransactionManager()
+	qb := repo.Scene().(*mocks.SceneReaderWriter)
+	fqb := repo.File().(*mocks.FileReaderWriter)
+
+	fileModTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	modTime := models.NullSQLiteTimestamp{Timestamp: fileModTime, Valid: true}
+
+	summary := &scanSummary{}
+	task := ScanTask{
+		TxnManager: repo,
+		FilePath:   newPath,
+		summary:    summary,
+	}
+
+	// the scene path is updated when the primary file is moved
+	movedScene := &models.Scene{ID: sceneID, Path: newPath}
+	qb.On("Update", models.ScenePartial{
+		ID:          sceneID,
+		Path:        &task.FilePath,
+		FileModTime: &modTime,
+	}).Return(movedScene, nil).Once()
+
+	// the scene is returned so that its generated files are created
+	s, err := task.handleMovedFile(&models.File{ID: primaryID, SceneID: sceneID, Primary: true, Path: "old.mp4"}, fileModTime)
+	assert.Nil(t, err)
+	assert.Equal(t, movedScene, s)
+
+	// only the file is updated when a secondary file is moved
+	fqb.On("Update", mock.MatchedBy(func(f models.File) bool {
+		return f.ID == secondaryID && f.SceneID == sceneID && f.Path == newPath && f.FileModTime == modTime
+	})).Return(nil, nil).Once()
+
+	s, err = task.handleMovedFile(&models.File{ID: secondaryID, SceneID: sceneID, Path: "old.mkv"}, fileModTime)
+	assert.Nil(t, err)
+	assert.Nil(t, s)
+
+	assert.Equal(t, int64(2), summary.moved)
+	assert.Equal(t, int64(0), summary.added)
+
+	qb.AssertExpectations(t)
+	fqb.AssertExpectations(t)
+}
+
+type recordingHookExecutor struct {
+	hooks []plugin.HookTriggerEnum
+}
+
+func (e *recordingHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string) {
+	e.hooks = append(e.hooks, hookType)
+}
+
+func TestScanGalleryRescanHooks(t *testing.T) {
+	const galleryID = 1
+
+	dir, err := ioutil.TempDir("", "stash-scan")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// the zip file has no images, so that no images are scanned
+	zipPath := filepath.Join(dir, "gallery.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	if _, err := w.Create("readme.txt"); err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+	f.Close()
+
+	fi, err := os.Stat(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileModTime := fi.ModTime().Truncate(time.Second)
+
+	scan := func(g *models.Gallery) []plugin.HookTriggerEnum {
+		repo := mocks.NewTransactionManager()
+		repo.Gallery().(*mocks.GalleryReaderWriter).On("FindByPath", zipPath).Return(g, nil)
+		repo.Gallery().(*mocks.GalleryReaderWriter).On("UpdatePartial", mock.Anything).Return(g, nil)
+		repo.Image().(*mocks.ImageReaderWriter).On("CountByGalleryID", galleryID).Return(0, nil)
+
+		hooks := &recordingHookExecutor{}
+		task := ScanTask{
+			TxnManager:  repo,
+			FilePath:    zipPath,
+			pluginCache: hooks,
+		}
+		task.scanGallery()
+
+		return hooks.hooks
+	}
+
+	path := sql.NullString{String: zipPath, Valid: true}
+
+	// the images of an unchanged gallery without images are rescanned, but
+	// the gallery is not created
+	assert.Empty(t, scan(&models.Gallery{
+		ID:          galleryID,
+		Path:        path,
+		Zip:         true,
+		FileModTime: models.NullSQLiteTimestamp{Timestamp: fileModTime, Valid: true},
+	}))
+
+	// a modified gallery is updated
+	assert.Equal(t, []plugin.HookTriggerEnum{plugin.GalleryUpdatePost}, scan(&models.Gallery{
+		ID:          galleryID,
+		Path:        path,
+		Zip:         true,
+		FileModTime: models.NullSQLiteTimestamp{Timestamp: fileModTime.Add(-time.Hour), Valid: true},
+	}))
+}
@@ -0,0 +1,156 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type stashBoxPerformerRefresh struct {
+	performerID int
+	stashID     string
+	client      *stashbox.Client
+}
+
+// StashBoxRefreshPerformers queues a job to refresh every performer that has
+// a stash ID for a configured stash-box instance. Returns the job ID.
+func (s *singleton) StashBoxRefreshPerformers(ctx context.Context) int {
+	j := job.JobExecFunc(func(ctx context.Context, progress *job.Progress) error {
+		clients := make(map[string]*stashbox.Client)
+		for _, box := range config.GetStashBoxes() {
+			clients[box.Endpoint] = stashbox.NewClient(*box, s.TxnManager)
+		}
+
+		var refreshes []stashBoxPerformerRefresh
+		if err := s.TxnManager.WithReadTxn(ctx, func(r models.ReaderRepository) error {
+			qb := r.Performer()
+			performers, err := qb.All()
+			if err != nil {
+				return err
+			}
+
+			for _, performer := range performers {
+				stashIDs, err := qb.GetStashIDs(performer.ID)
+				if err != nil {
+					return err
+				}
+
+				for _, stashID := range stashIDs {
+					if client, found := clients[stashID.Endpoint]; found {
+						refreshes = append(refreshes, stashBoxPerformerRefresh{
+							performerID: performer.ID,
+							stashID:     stashID.StashID,
+							client:      client,
+						})
+					}
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("error querying performers: %s", err.Error())
+		}
+
+		progress.SetTotal(len(refreshes))
+
+		for _, refresh := range refreshes {
+			if job.IsCancelled(ctx) {
+				logger.Info("Stopping due to user request")
+				return nil
+			}
+
+			progress.ExecuteTask(fmt.Sprintf("Refreshing performer %d", refresh.performerID), func() {
+				if err := s.refreshStashBoxPerformer(ctx, refresh); err != nil {
+					logger.Errorf("Error refreshing performer %d from stash-box: %s", refresh.performerID, err.Error())
+				}
+			})
+
+			progress.Increment()
+		}
+
+		return nil
+	})
+
+	return s.JobManager.Add(ctx, "Refreshing performers from stash-box...", j)
+}
+
+func (s *singleton) refreshStashBoxPerformer(ctx context.Context, refresh stashBoxPerformerRefresh) error {
+	scraped, err := refresh.client.FindStashBoxPerformerByID(refresh.stashID)
+	if err != nil {
+		return err
+	}
+
+	if scraped == nil {
+		logger.Infof("Performer %s not found in stash-box", refresh.stashID)
+		return nil
+	}
+
+	var image []byte
+	if scraped.Image != nil {
+		image, err = utils.ProcessImageInput(*scraped.Image)
+		if err != nil {
+			logger.Warnf("Error processing image for performer %d: %s", refresh.performerID, err.Error())
+		}
+	}
+
+	return s.TxnManager.WithTxn(ctx, func(r models.Repository) error {
+		qb := r.Performer()
+
+		if _, err := qb.Update(stashBoxPerformerPartial(refresh.performerID, scraped)); err != nil {
+			return err
+		}
+
+		if len(image) > 0 {
+			if err := qb.UpdateImage(refresh.performerID, image); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// stashBoxPerformerPartial returns a partial that sets the fields that are
+// set in the scraped performer. The name of the performer is not changed.
+func stashBoxPerformerPartial(performerID int, scraped *models.ScrapedPerformer) models.PerformerPartial {
+	nullString := func(v *string) *sql.NullString {
+		if v == nil || *v == "" {
+			return nil
+		}
+		return &sql.NullString{String: *v, Valid: true}
+	}
+
+	ret := models.PerformerPartial{
+		ID:           performerID,
+		Twitter:      nullString(scraped.Twitter),
+		Ethnicity:    nullString(scraped.Ethnicity),
+		Country:      nullString(scraped.Country),
+		EyeColor:     nullString(scraped.EyeColor),
+		Height:       nullString(scraped.Height),
+		Measurements: nullString(scraped.Measurements),
+		FakeTits:     nullString(scraped.FakeTits),
+		CareerLength: nullString(scraped.CareerLength),
+		Tattoos:      nullString(scraped.Tattoos),
+		Piercings:    nullString(scraped.Piercings),
+		Aliases:      nullString(scraped.Aliases),
+		UpdatedAt:    &models.SQLiteTimestamp{Timestamp: time.Now()},
+	}
+
+	if scraped.Gender != nil && models.GenderEnum(*scraped.Gender).IsValid() {
+		ret.Gender = nullString(scraped.Gender)
+	}
+
+	if scraped.Birthdate != nil && *scraped.Birthdate != "" {
+		ret.Birthdate = &models.SQLiteDate{String: *scraped.Birthdate, Valid: true}
+	}
+
+	return ret
+}
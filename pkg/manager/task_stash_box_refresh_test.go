@@ -0,0 +1,44 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestStashBoxPerformerPartial(t *testing.T) {
+	const performerID = 1
+
+	name := "name"
+	country := "country"
+	blank := ""
+	validGender := "FEMALE"
+	invalidGender := "invalid"
+	birthdate := "2000-01-01"
+
+	p := stashBoxPerformerPartial(performerID, &models.ScrapedPerformer{
+		Name:      &name,
+		Country:   &country,
+		Tattoos:   &blank,
+		Gender:    &validGender,
+		Birthdate: &birthdate,
+	})
+
+	assert.Equal(t, performerID, p.ID)
+	assert.Nil(t, p.Name)
+	assert.Nil(t, p.Tattoos)
+	assert.Nil(t, p.Twitter)
+	assert.Equal(t, country, p.Country.String)
+	assert.Equal(t, validGender, p.Gender.String)
+	assert.Equal(t, birthdate, p.Birthdate.String)
+	assert.NotNil(t, p.UpdatedAt)
+
+	p = stashBoxPerformerPartial(performerID, &models.ScrapedPerformer{
+		Gender: &invalidGender,
+	})
+
+	assert.Nil(t, p.Gender)
+	assert.Nil(t, p.Birthdate)
+}
@@ -0,0 +1,151 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/user"
+)
+
+var (
+	// caches whether there are users, so that the users are not counted for
+	// every request
+	usersExist      *bool
+	usersExistMutex sync.Mutex
+)
+
+// UsersExist returns true if there are any users. The result is cached
+// until InvalidateUsersExist is called.
+func UsersExist(txnManager models.TransactionManager) (bool, error) {
+	usersExistMutex.Lock()
+	defer usersExistMutex.Unlock()
+
+	if usersExist != nil {
+		return *usersExist, nil
+	}
+
+	var count int
+	if err := txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		var err error
+		count, err = r.User().Count()
+		return err
+	}); err != nil {
+		return false, err
+	}
+
+	ret := count > 0
+	usersExist = &ret
+	return ret, nil
+}
+
+// InvalidateUsersExist clears the cached result of UsersExist. It must be
+// called after users are created or deleted.
+func InvalidateUsersExist() {
+	usersExistMutex.Lock()
+	defer usersExistMutex.Unlock()
+
+	usersExist = nil
+}
+
+// CreateUser creates the user. If it is the first user, the play history
+// recorded while authentication was disabled is given to the user, so that
+// it is not hidden once they log in.
+func CreateUser(r models.Repository, newUser models.User) (*models.User, error) {
+	qb := r.User()
+
+	count, err := qb.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := qb.Create(newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		if err := r.Scene().AssignPlayHistory(ret.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// SyncConfigUser adds the username, password and API key set in the
+// configuration to the users as an admin, updating the previously added
+// user if the configuration has been changed. The user is deleted if the
+// credentials have been removed from the configuration. The configured user
+// must be changed using the configuration, rather than the user mutations.
+func SyncConfigUser(txnManager models.TransactionManager) error {
+	defer InvalidateUsersExist()
+
+	return txnManager.WithTxn(context.TODO(), func(r models.Repository) error {
+		qb := r.User()
+
+		existing, err := qb.FindConfigUser()
+		if err != nil {
+			return err
+		}
+
+		if !config.HasCredentials() {
+			if existing == nil {
+				return nil
+			}
+
+			// keep the user if it is the only admin, so that the other
+			// users are not left without an admin. It is no longer
+			// managed by the configuration.
+			if err := user.ValidateAdminRemains(qb, existing.ID, nil); err != nil {
+				logger.Warnf("Keeping user %s, which was removed from the configuration: %s", existing.Username, err.Error())
+				existing.Config = false
+				existing.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+				_, err = qb.Update(*existing)
+				return err
+			}
+
+			logger.Infof("Removing user %s, which was removed from the configuration", existing.Username)
+			return qb.Destroy(existing.ID)
+		}
+
+		username, passwordHash := config.GetCredentials()
+		if existing == nil {
+			// take over an existing user with the same username
+			existing, err = qb.FindByUsername(username)
+			if err != nil {
+				return err
+			}
+		}
+
+		apiKey := sql.NullString{
+			String: config.GetAPIKey(),
+			Valid:  config.GetAPIKey() != "",
+		}
+
+		if existing == nil {
+			newUser := models.NewUser(username, passwordHash, models.UserRoleAdmin)
+			newUser.APIKey = apiKey
+			newUser.Config = true
+			_, err = CreateUser(r, *newUser)
+			return err
+		}
+
+		if existing.Config && existing.Username == username && existing.Password == passwordHash && existing.Role == models.UserRoleAdmin && existing.APIKey == apiKey {
+			return nil
+		}
+
+		existing.Username = username
+		existing.Password = passwordHash
+		existing.Role = models.UserRoleAdmin
+		existing.APIKey = apiKey
+		existing.Config = true
+		existing.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+		_, err = qb.Update(*existing)
+		return err
+	})
+}
@@ -0,0 +1,74 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestUsersExist(t *testing.T) {
+	mockTxn := mocks.NewTransactionManager()
+	mockUserReader := mockTxn.User().(*mocks.UserReaderWriter)
+
+	InvalidateUsersExist()
+	defer InvalidateUsersExist()
+
+	mockUserReader.On("Count").Return(0, nil).Once()
+	mockUserReader.On("Count").Return(1, nil).Once()
+
+	exists, err := UsersExist(mockTxn)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	// the result is cached until invalidated
+	exists, _ = UsersExist(mockTxn)
+	assert.False(t, exists)
+
+	InvalidateUsersExist()
+	exists, _ = UsersExist(mockTxn)
+	assert.True(t, exists)
+
+	mockUserReader.AssertExpectations(t)
+}
+
+func TestSyncConfigUserRemoved(t *testing.T) {
+	const (
+		configUserID = 1
+		otherUserID  = 2
+	)
+
+	// the configuration has no credentials, so the config user is removed
+	configUser := &models.User{ID: configUserID, Username: "config", Role: models.UserRoleAdmin, Config: true}
+
+	// the config user is kept if it is the only admin
+	mockTxn := mocks.NewTransactionManager()
+	mockUserReader := mockTxn.User().(*mocks.UserReaderWriter)
+	mockUserReader.On("FindConfigUser").Return(configUser, nil).Once()
+	mockUserReader.On("All").Return([]*models.User{
+		configUser,
+		{ID: otherUserID, Role: models.UserRoleEditor},
+	}, nil).Once()
+	mockUserReader.On("Update", mock.MatchedBy(func(u models.User) bool {
+		return u.ID == configUserID && !u.Config && u.Role == models.UserRoleAdmin
+	})).Return(nil, nil).Once()
+
+	assert.Nil(t, SyncConfigUser(mockTxn))
+	mockUserReader.AssertExpectations(t)
+
+	// the config user is deleted if another admin remains
+	mockTxn = mocks.NewTransactionManager()
+	mockUserReader = mockTxn.User().(*mocks.UserReaderWriter)
+	mockUserReader.On("FindConfigUser").Return(configUser, nil).Once()
+	mockUserReader.On("All").Return([]*models.User{
+		configUser,
+		{ID: otherUserID, Role: models.UserRoleAdmin},
+	}, nil).Once()
+	mockUserReader.On("Destroy", configUserID).Return(nil).Once()
+
+	assert.Nil(t, SyncConfigUser(mockTxn))
+	mockUserReader.AssertExpectations(t)
+}
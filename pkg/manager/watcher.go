@@ -0,0 +1,295 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// the amount of time that a path must be unchanged before it is processed.
+// Files that are being copied receive regular write events, so they are not
+// processed until the copy is complete.
+const watcherDebounce = 10 * time.Second
+
+// the interval at which pending paths are checked
+const watcherInterval = time.Second
+
+// watcher watches the stash paths for changes. Changed paths are passed to
+// the process function once they have not changed for the debounce period.
+type watcher struct {
+	fsw      *fsnotify.Watcher
+	debounce time.Duration
+	process  func(paths []string)
+
+	mutex   sync.Mutex
+	pending map[string]time.Time
+	dirs    map[string]bool
+
+	done chan struct{}
+}
+
+func newWatcher(process func(paths []string)) (*watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	return &watcher{
+		fsw:      fsw,
+		debounce: watcherDebounce,
+		process:  process,
+		pending:  make(map[string]time.Time),
+		dirs:     make(map[string]bool),
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// addDir watches the directory and all of its subdirectories. inotify
+// watches are not recursive, so each directory must be watched separately.
+func (w *watcher) addDir(dir string) {
+	generatedPath := config.GetGeneratedPath()
+
+	err := utils.SymWalk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			logger.Warnf("error watching %s: %s", path, err.Error())
+			return nil
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		// #1102 - ignore files in generated path
+		if utils.IsPathInDir(generatedPath, path) {
+			return filepath.SkipDir
+		}
+
+		if err := w.fsw.Add(path); err != nil {
+			logger.Warnf("error watching %s: %s", path, err.Error())
+			return nil
+		}
+
+		w.mutex.Lock()
+		w.dirs[path] = true
+		w.mutex.Unlock()
+
+		return nil
+	})
+
+	if err != nil {
+		logger.Warnf("error watching %s: %s", dir, err.Error())
+	}
+}
+
+// removeDir removes the directory and its subdirectories from the set of
+// watched directories. Deleted directories are removed from the underlying
+// watcher automatically.
+func (w *watcher) removeDir(dir string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for d := range w.dirs {
+		if utils.IsPathInDir(dir, d) {
+			_ = w.fsw.Remove(d)
+			delete(w.dirs, d)
+		}
+	}
+}
+
+func (w *watcher) isDir(path string) bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.dirs[path]
+}
+
+func (w *watcher) addPending(path string, t time.Time) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.pending[path] = t
+}
+
+// popReady removes and returns the pending paths that have not changed for
+// the debounce period.
+func (w *watcher) popReady(now time.Time) []string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var ret []string
+	for path, t := range w.pending {
+		if now.Sub(t) >= w.debounce {
+			ret = append(ret, path)
+			delete(w.pending, path)
+		}
+	}
+
+	return ret
+}
+
+func (w *watcher) handleEvent(e fsnotify.Event) {
+	path := e.Name
+
+	// #1102 - ignore files in generated path
+	if utils.IsPathInDir(config.GetGeneratedPath(), path) {
+		return
+	}
+
+	switch {
+	case e.Op&fsnotify.Create != 0:
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			// files moved into the directory do not create events, so the
+			// whole directory is scanned
+			w.addDir(path)
+			w.addPending(path, time.Now())
+			return
+		}
+
+		if isWatchedFile(path) {
+			w.addPending(path, time.Now())
+		}
+	case e.Op&fsnotify.Write != 0:
+		if isWatchedFile(path) {
+			w.addPending(path, time.Now())
+		}
+	case e.Op&(fsnotify.Remove|fsnotify.Rename) != 0:
+		if w.isDir(path) {
+			w.removeDir(path)
+			w.addPending(path, time.Now())
+			return
+		}
+
+		if isWatchedFile(path) {
+			w.addPending(path, time.Now())
+		}
+	}
+}
+
+func (w *watcher) run() {
+	ticker := time.NewTicker(watcherInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case e, ok := <-w.fsw.Events:
+			if !ok {
+				return
+			}
+			w.handleEvent(e)
+		case err, ok := <-w.fsw.Errors:
+			if !ok {
+				return
+			}
+			logger.Errorf("error watching stash paths: %s", err.Error())
+		case <-ticker.C:
+			if ready := w.popReady(time.Now()); len(ready) > 0 {
+				w.process(ready)
+			}
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *watcher) close() {
+	close(w.done)
+	if err := w.fsw.Close(); err != nil {
+		logger.Errorf("error closing stash path watcher: %s", err.Error())
+	}
+}
+
+// isWatchedFile returns true if the file at path would be scanned, based on
+// the file extension, the configuration of the stash path that contains it
+// and the exclude patterns.
+func isWatchedFile(path string) bool {
+	s := getStashFromPath(path)
+	if s == nil {
+		return false
+	}
+
+	if !s.ExcludeVideo && matchExtension(path, config.GetVideoExtensions()) {
+		return !matchFile(path, config.GetExcludes())
+	}
+
+	if !s.ExcludeImage && (matchExtension(path, config.GetImageExtensions()) || matchExtension(path, config.GetGalleryExtensions())) {
+		return !matchFile(path, config.GetImageExcludes())
+	}
+
+	return false
+}
+
+var watcherMutex sync.Mutex
+
+// RefreshWatcher starts or stops watching the stash paths, depending on the
+// configuration. Call this when the stash paths or watch setting changes.
+func (s *singleton) RefreshWatcher() {
+	watcherMutex.Lock()
+	defer watcherMutex.Unlock()
+
+	if s.watcher != nil {
+		s.watcher.close()
+		s.watcher = nil
+	}
+
+	if !config.GetWatchStashPaths() || !config.IsValid() {
+		return
+	}
+
+	w, err := newWatcher(s.processWatchedPaths)
+	if err != nil {
+		logger.Errorf("error creating stash path watcher: %s", err.Error())
+		return
+	}
+
+	for _, sp := range config.GetStashPaths() {
+		w.addDir(sp.Path)
+	}
+
+	logger.Infof("Watching stash paths for changes")
+	go w.run()
+
+	s.watcher = w
+}
+
+// processWatchedPaths scans the changed paths that exist, and cleans the
+// paths that no longer exist.
+func (s *singleton) processWatchedPaths(paths []string) {
+	var scanPaths []string
+	var cleanPaths []string
+
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			scanPaths = append(scanPaths, p)
+		} else {
+			cleanPaths = append(cleanPaths, p)
+		}
+	}
+
+	// changes are not initiated by a request
+	ctx := context.TODO()
+
+	// scan before cleaning, so that moved files are detected by the scan
+	// before their old paths are cleaned
+	if len(scanPaths) > 0 {
+		logger.Infof("Scanning %d changed path(s)", len(scanPaths))
+		s.Scan(ctx, models.ScanMetadataInput{
+			Paths: scanPaths,
+		})
+	}
+
+	if len(cleanPaths) > 0 {
+		logger.Infof("Cleaning %d removed path(s)", len(cleanPaths))
+		s.Clean(ctx, models.CleanMetadataInput{
+			Paths: cleanPaths,
+		})
+	}
+}
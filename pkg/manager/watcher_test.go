@@ -0,0 +1,70 @@
+package manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatcherPopReady(t *testing.T) {
+	w, err := newWatcher(func([]string) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.close()
+
+	now := time.Now()
+
+	w.addPending("a", now)
+	w.addPending("b", now.Add(5*time.Second))
+
+	assert.Len(t, w.popReady(now.Add(time.Second)), 0)
+	assert.Equal(t, []string{"a"}, w.popReady(now.Add(w.debounce)))
+
+	// a further change to a pending path resets its debounce time
+	w.addPending("b", now.Add(w.debounce))
+	assert.Len(t, w.popReady(now.Add(w.debounce+5*time.Second)), 0)
+	assert.Equal(t, []string{"b"}, w.popReady(now.Add(2*w.debounce)))
+
+	assert.Len(t, w.popReady(now.Add(10*w.debounce)), 0)
+}
+
+func TestWatcherDirs(t *testing.T) {
+	root, err := ioutil.TempDir("", "stash-watcher")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	sub := filepath.Join(root, "sub")
+	subsub := filepath.Join(sub, "subsub")
+	other := filepath.Join(root, "other")
+	for _, d := range []string{subsub, other} {
+		if err := os.MkdirAll(d, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w, err := newWatcher(func([]string) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.close()
+
+	w.addDir(root)
+
+	for _, d := range []string{root, sub, subsub, other} {
+		assert.True(t, w.isDir(d), d)
+	}
+
+	w.removeDir(sub)
+
+	assert.False(t, w.isDir(sub))
+	assert.False(t, w.isDir(subsub))
+	assert.True(t, w.isDir(root))
+	assert.True(t, w.isDir(other))
+}
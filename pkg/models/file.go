@@ -0,0 +1,21 @@
+package models
+
+type FileReader interface {
+	Find(id int) (*File, error)
+	FindBySceneID(sceneID int) ([]*File, error)
+	FindByPath(path string) (*File, error)
+	FindByChecksum(checksum string) ([]*File, error)
+	FindByOSHash(oshash string) ([]*File, error)
+	All() ([]*File, error)
+}
+
+type FileWriter interface {
+	Create(newFile File) (*File, error)
+	Update(updatedFile File) (*File, error)
+	Destroy(id int) error
+}
+
+type FileReaderWriter interface {
+	FileReader
+	FileWriter
+}
@@ -35,6 +35,20 @@ func (_m *SceneReaderWriter) All() ([]*models.Scene, error) {
 	return r0, r1
 }
 
+// AssignPlayHistory provides a mock function with given fields: userID
+func (_m *SceneReaderWriter) AssignPlayHistory(userID int) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Count provides a mock function with given fields:
 func (_m *SceneReaderWriter) Count() (int, error) {
 	ret := _m.Called()
@@ -415,6 +429,29 @@ func (_m *SceneReaderWriter) FindByPerformerID(performerID int) ([]*models.Scene
 	return r0, r1
 }
 
+// FindDuplicates provides a mock function with given fields: distance
+func (_m *SceneReaderWriter) FindDuplicates(distance int) ([][]*models.Scene, error) {
+	ret := _m.Called(distance)
+
+	var r0 [][]*models.Scene
+	if rf, ok := ret.Get(0).(func(int) [][]*models.Scene); ok {
+		r0 = rf(distance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([][]*models.Scene)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(distance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindMany provides a mock function with given fields: ids
 func (_m *SceneReaderWriter) FindMany(ids []int) ([]*models.Scene, error) {
 	ret := _m.Called(ids)
@@ -438,6 +475,29 @@ func (_m *SceneReaderWriter) FindMany(ids []int) ([]*models.Scene, error) {
 	return r0, r1
 }
 
+// GetAllPlayHistory provides a mock function with given fields: sceneID
+func (_m *SceneReaderWriter) GetAllPlayHistory(sceneID int) ([]*models.ScenePlayHistory, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 []*models.ScenePlayHistory
+	if rf, ok := ret.Get(0).(func(int) []*models.ScenePlayHistory); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ScenePlayHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCover provides a mock function with given fields: sceneID
 func (_m *SceneReaderWriter) GetCover(sceneID int) ([]byte, error) {
 	ret := _m.Called(sceneID)
@@ -530,6 +590,29 @@ func (_m *SceneReaderWriter) GetPerformerIDs(sceneID int) ([]int, error) {
 	return r0, r1
 }
 
+// GetPlayHistory provides a mock function with given fields: sceneID
+func (_m *SceneReaderWriter) GetPlayHistory(sceneID int) (*models.ScenePlayHistory, error) {
+	ret := _m.Called(sceneID)
+
+	var r0 *models.ScenePlayHistory
+	if rf, ok := ret.Get(0).(func(int) *models.ScenePlayHistory); ok {
+		r0 = rf(sceneID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ScenePlayHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(sceneID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStashIDs provides a mock function with given fields: sceneID
 func (_m *SceneReaderWriter) GetStashIDs(sceneID int) ([]*models.StashID, error) {
 	ret := _m.Called(sceneID)
@@ -597,6 +680,27 @@ func (_m *SceneReaderWriter) IncrementOCounter(id int) (int, error) {
 	return r0, r1
 }
 
+// IncrementPlayCount provides a mock function with given fields: id
+func (_m *SceneReaderWriter) IncrementPlayCount(id int) (int, error) {
+	ret := _m.Called(id)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(int) int); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: sceneFilter, findFilter
 func (_m *SceneReaderWriter) Query(sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType) ([]*models.Scene, int, error) {
 	ret := _m.Called(sceneFilter, findFilter)
@@ -627,29 +731,6 @@ func (_m *SceneReaderWriter) Query(sceneFilter *models.SceneFilterType, findFilt
 	return r0, r1, r2
 }
 
-// QueryForAutoTag provides a mock function with given fields: regex, pathPrefixes
-func (_m *SceneReaderWriter) QueryForAutoTag(regex string, pathPrefixes []string) ([]*models.Scene, error) {
-	ret := _m.Called(regex, pathPrefixes)
-
-	var r0 []*models.Scene
-	if rf, ok := ret.Get(0).(func(string, []string) []*models.Scene); ok {
-		r0 = rf(regex, pathPrefixes)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Scene)
-		}
-	}
-
-	var r1 error
-	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
-		r1 = rf(regex, pathPrefixes)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
 // ResetOCounter provides a mock function with given fields: id
 func (_m *SceneReaderWriter) ResetOCounter(id int) (int, error) {
 	ret := _m.Called(id)
@@ -671,6 +752,20 @@ func (_m *SceneReaderWriter) ResetOCounter(id int) (int, error) {
 	return r0, r1
 }
 
+// SaveActivity provides a mock function with given fields: id, resumeTime, playDuration
+func (_m *SceneReaderWriter) SaveActivity(id int, resumeTime *float64, playDuration *float64) error {
+	ret := _m.Called(id, resumeTime, playDuration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, *float64, *float64) error); ok {
+		r0 = rf(id, resumeTime, playDuration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Size provides a mock function with given fields:
 func (_m *SceneReaderWriter) Size() (float64, error) {
 	ret := _m.Called()
@@ -808,6 +903,20 @@ func (_m *SceneReaderWriter) UpdatePerformers(sceneID int, performerIDs []int) e
 	return r0
 }
 
+// UpdatePlayHistory provides a mock function with given fields: history
+func (_m *SceneReaderWriter) UpdatePlayHistory(history models.ScenePlayHistory) error {
+	ret := _m.Called(history)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(models.ScenePlayHistory) error); ok {
+		r0 = rf(history)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateStashIDs provides a mock function with given fields: sceneID, stashIDs
 func (_m *SceneReaderWriter) UpdateStashIDs(sceneID int, stashIDs []models.StashID) error {
 	ret := _m.Called(sceneID, stashIDs)
@@ -130,6 +130,52 @@ func (_m *TagReaderWriter) Find(id int) (*models.Tag, error) {
 	return r0, r1
 }
 
+// FindByAlias provides a mock function with given fields: alias, nocase
+func (_m *TagReaderWriter) FindByAlias(alias string, nocase bool) (*models.Tag, error) {
+	ret := _m.Called(alias, nocase)
+
+	var r0 *models.Tag
+	if rf, ok := ret.Get(0).(func(string, bool) *models.Tag); ok {
+		r0 = rf(alias, nocase)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, bool) error); ok {
+		r1 = rf(alias, nocase)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByChildTagID provides a mock function with given fields: childID
+func (_m *TagReaderWriter) FindByChildTagID(childID int) ([]*models.Tag, error) {
+	ret := _m.Called(childID)
+
+	var r0 []*models.Tag
+	if rf, ok := ret.Get(0).(func(int) []*models.Tag); ok {
+		r0 = rf(childID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(childID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByGalleryID provides a mock function with given fields: galleryID
 func (_m *TagReaderWriter) FindByGalleryID(galleryID int) ([]*models.Tag, error) {
 	ret := _m.Called(galleryID)
@@ -222,6 +268,29 @@ func (_m *TagReaderWriter) FindByNames(names []string, nocase bool) ([]*models.T
 	return r0, r1
 }
 
+// FindByParentTagID provides a mock function with given fields: parentID
+func (_m *TagReaderWriter) FindByParentTagID(parentID int) ([]*models.Tag, error) {
+	ret := _m.Called(parentID)
+
+	var r0 []*models.Tag
+	if rf, ok := ret.Get(0).(func(int) []*models.Tag); ok {
+		r0 = rf(parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Tag)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByPerformerID provides a mock function with given fields: performerID
 func (_m *TagReaderWriter) FindByPerformerID(performerID int) ([]*models.Tag, error) {
 	ret := _m.Called(performerID)
@@ -314,6 +383,52 @@ func (_m *TagReaderWriter) FindMany(ids []int) ([]*models.Tag, error) {
 	return r0, r1
 }
 
+// GetAliases provides a mock function with given fields: tagID
+func (_m *TagReaderWriter) GetAliases(tagID int) ([]string, error) {
+	ret := _m.Called(tagID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(int) []string); ok {
+		r0 = rf(tagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChildIDs provides a mock function with given fields: tagID
+func (_m *TagReaderWriter) GetChildIDs(tagID int) ([]int, error) {
+	ret := _m.Called(tagID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(int) []int); ok {
+		r0 = rf(tagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: tagID
 func (_m *TagReaderWriter) GetImage(tagID int) ([]byte, error) {
 	ret := _m.Called(tagID)
@@ -337,6 +452,52 @@ func (_m *TagReaderWriter) GetImage(tagID int) ([]byte, error) {
 	return r0, r1
 }
 
+// GetParentIDs provides a mock function with given fields: tagID
+func (_m *TagReaderWriter) GetParentIDs(tagID int) ([]int, error) {
+	ret := _m.Called(tagID)
+
+	var r0 []int
+	if rf, ok := ret.Get(0).(func(int) []int); ok {
+		r0 = rf(tagID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(tagID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Merge provides a mock function with given fields: source, destination
+func (_m *TagReaderWriter) Merge(source []int, destination int) ([]*models.TagsMergeCount, error) {
+	ret := _m.Called(source, destination)
+
+	var r0 []*models.TagsMergeCount
+	if rf, ok := ret.Get(0).(func([]int, int) []*models.TagsMergeCount); ok {
+		r0 = rf(source, destination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TagsMergeCount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]int, int) error); ok {
+		r1 = rf(source, destination)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: tagFilter, findFilter
 func (_m *TagReaderWriter) Query(tagFilter *models.TagFilterType, findFilter *models.FindFilterType) ([]*models.Tag, int, error) {
 	ret := _m.Called(tagFilter, findFilter)
@@ -390,6 +551,34 @@ func (_m *TagReaderWriter) Update(updatedTag models.Tag) (*models.Tag, error) {
 	return r0, r1
 }
 
+// UpdateAliases provides a mock function with given fields: tagID, aliases
+func (_m *TagReaderWriter) UpdateAliases(tagID int, aliases []string) error {
+	ret := _m.Called(tagID, aliases)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []string) error); ok {
+		r0 = rf(tagID, aliases)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateChildTags provides a mock function with given fields: tagID, childIDs
+func (_m *TagReaderWriter) UpdateChildTags(tagID int, childIDs []int) error {
+	ret := _m.Called(tagID, childIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []int) error); ok {
+		r0 = rf(tagID, childIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateImage provides a mock function with given fields: tagID, image
 func (_m *TagReaderWriter) UpdateImage(tagID int, image []byte) error {
 	ret := _m.Called(tagID, image)
@@ -403,3 +592,17 @@ func (_m *TagReaderWriter) UpdateImage(tagID int, image []byte) error {
 
 	return r0
 }
+
+// UpdateParentTags provides a mock function with given fields: tagID, parentIDs
+func (_m *TagReaderWriter) UpdateParentTags(tagID int, parentIDs []int) error {
+	ret := _m.Called(tagID, parentIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, []int) error); ok {
+		r0 = rf(tagID, parentIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
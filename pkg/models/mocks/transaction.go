@@ -7,28 +7,34 @@ import (
 )
 
 type TransactionManager struct {
+	file        models.FileReaderWriter
 	gallery     models.GalleryReaderWriter
 	image       models.ImageReaderWriter
 	movie       models.MovieReaderWriter
 	performer   models.PerformerReaderWriter
+	savedFilter models.SavedFilterReaderWriter
 	scene       models.SceneReaderWriter
 	sceneMarker models.SceneMarkerReaderWriter
 	scrapedItem models.ScrapedItemReaderWriter
 	studio      models.StudioReaderWriter
 	tag         models.TagReaderWriter
+	user        models.UserReaderWriter
 }
 
 func NewTransactionManager() *TransactionManager {
 	return &TransactionManager{
+		file:        &FileReaderWriter{},
 		gallery:     &GalleryReaderWriter{},
 		image:       &ImageReaderWriter{},
 		movie:       &MovieReaderWriter{},
 		performer:   &PerformerReaderWriter{},
+		savedFilter: &SavedFilterReaderWriter{},
 		scene:       &SceneReaderWriter{},
 		sceneMarker: &SceneMarkerReaderWriter{},
 		scrapedItem: &ScrapedItemReaderWriter{},
 		studio:      &StudioReaderWriter{},
 		tag:         &TagReaderWriter{},
+		user:        &UserReaderWriter{},
 	}
 }
 
@@ -36,6 +42,10 @@ func (t *TransactionManager) WithTxn(ctx context.Context, fn func(r models.Repos
 	return fn(t)
 }
 
+func (t *TransactionManager) File() models.FileReaderWriter {
+	return t.file
+}
+
 func (t *TransactionManager) Gallery() models.GalleryReaderWriter {
 	return t.gallery
 }
@@ -52,6 +62,10 @@ func (t *TransactionManager) Performer() models.PerformerReaderWriter {
 	return t.performer
 }
 
+func (t *TransactionManager) SavedFilter() models.SavedFilterReaderWriter {
+	return t.savedFilter
+}
+
 func (t *TransactionManager) SceneMarker() models.SceneMarkerReaderWriter {
 	return t.sceneMarker
 }
@@ -72,6 +86,10 @@ func (t *TransactionManager) Tag() models.TagReaderWriter {
 	return t.tag
 }
 
+func (t *TransactionManager) User() models.UserReaderWriter {
+	return t.user
+}
+
 type ReadTransaction struct {
 	t *TransactionManager
 }
@@ -80,6 +98,10 @@ func (t *TransactionManager) WithReadTxn(ctx context.Context, fn func(r models.R
 	return fn(&ReadTransaction{t: t})
 }
 
+func (r *ReadTransaction) File() models.FileReader {
+	return r.t.file
+}
+
 func (r *ReadTransaction) Gallery() models.GalleryReader {
 	return r.t.gallery
 }
@@ -96,6 +118,10 @@ func (r *ReadTransaction) Performer() models.PerformerReader {
 	return r.t.performer
 }
 
+func (r *ReadTransaction) SavedFilter() models.SavedFilterReader {
+	return r.t.savedFilter
+}
+
 func (r *ReadTransaction) SceneMarker() models.SceneMarkerReader {
 	return r.t.sceneMarker
 }
@@ -115,3 +141,7 @@ func (r *ReadTransaction) Studio() models.StudioReader {
 func (r *ReadTransaction) Tag() models.TagReader {
 	return r.t.tag
 }
+
+func (r *ReadTransaction) User() models.UserReader {
+	return r.t.user
+}
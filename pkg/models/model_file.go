@@ -0,0 +1,98 @@
+package models
+
+import (
+	"database/sql"
+)
+
+// File stores the details of a video file belonging to a scene. A scene may
+// have multiple files. The details of the primary file, which is the file
+// that is streamed, are also stored in the scene.
+type File struct {
+	ID          int                 `db:"id" json:"id"`
+	SceneID     int                 `db:"scene_id" json:"scene_id"`
+	Primary     bool                `db:"is_primary" json:"is_primary"`
+	Path        string              `db:"path" json:"path"`
+	Checksum    sql.NullString      `db:"checksum" json:"checksum"`
+	OSHash      sql.NullString      `db:"oshash" json:"oshash"`
+	Size        sql.NullString      `db:"size" json:"size"`
+	Duration    sql.NullFloat64     `db:"duration" json:"duration"`
+	VideoCodec  sql.NullString      `db:"video_codec" json:"video_codec"`
+	Format      sql.NullString      `db:"format" json:"format_name"`
+	AudioCodec  sql.NullString      `db:"audio_codec" json:"audio_codec"`
+	Width       sql.NullInt64       `db:"width" json:"width"`
+	Height      sql.NullInt64       `db:"height" json:"height"`
+	Framerate   sql.NullFloat64     `db:"framerate" json:"framerate"`
+	Bitrate     sql.NullInt64       `db:"bitrate" json:"bitrate"`
+	FileModTime NullSQLiteTimestamp `db:"file_mod_time" json:"file_mod_time"`
+	CreatedAt   SQLiteTimestamp     `db:"created_at" json:"created_at"`
+	UpdatedAt   SQLiteTimestamp     `db:"updated_at" json:"updated_at"`
+}
+
+// GetHash returns the hash of the file, based on the hash algorithm provided.
+func (f File) GetHash(hashAlgorithm HashAlgorithm) string {
+	if hashAlgorithm == HashAlgorithmMd5 {
+		return f.Checksum.String
+	} else if hashAlgorithm == HashAlgorithmOshash {
+		return f.OSHash.String
+	}
+
+	panic("unknown hash algorithm")
+}
+
+// SetFileDetails sets the file details of the file to those of the scene.
+// The ID, scene ID and primary flag of the file are not changed.
+func (f *File) SetFileDetails(s Scene) {
+	f.Path = s.Path
+	f.Checksum = s.Checksum
+	f.OSHash = s.OSHash
+	f.Size = s.Size
+	f.Duration = s.Duration
+	f.VideoCodec = s.VideoCodec
+	f.Format = s.Format
+	f.AudioCodec = s.AudioCodec
+	f.Width = s.Width
+	f.Height = s.Height
+	f.Framerate = s.Framerate
+	f.Bitrate = s.Bitrate
+	f.FileModTime = s.FileModTime
+}
+
+// ScenePartial returns a ScenePartial that sets the file details of the
+// scene with the provided id to those of the file.
+func (f File) ScenePartial(sceneID int) ScenePartial {
+	return ScenePartial{
+		ID:          sceneID,
+		Path:        &f.Path,
+		Checksum:    &f.Checksum,
+		OSHash:      &f.OSHash,
+		Size:        &f.Size,
+		Duration:    &f.Duration,
+		VideoCodec:  &f.VideoCodec,
+		Format:      &f.Format,
+		AudioCodec:  &f.AudioCodec,
+		Width:       &f.Width,
+		Height:      &f.Height,
+		Framerate:   &f.Framerate,
+		Bitrate:     &f.Bitrate,
+		FileModTime: &f.FileModTime,
+	}
+}
+
+// SetsFileDetails returns true if the partial sets any of the file details of
+// the scene.
+func (p ScenePartial) SetsFileDetails() bool {
+	return p.Path != nil || p.Checksum != nil || p.OSHash != nil || p.Size != nil ||
+		p.Duration != nil || p.VideoCodec != nil || p.Format != nil || p.AudioCodec != nil ||
+		p.Width != nil || p.Height != nil || p.Framerate != nil || p.Bitrate != nil ||
+		p.FileModTime != nil
+}
+
+type Files []*File
+
+func (f *Files) Append(o interface{}) {
+	*f = append(*f, o.(*File))
+}
+
+func (f *Files) New() interface{} {
+	return &File{}
+}
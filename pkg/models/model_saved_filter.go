@@ -0,0 +1,21 @@
+package models
+
+// SavedFilter is a named filter for a filter mode. The filter is stored as
+// a JSON string. Each mode may have one default filter, which is not named.
+type SavedFilter struct {
+	ID      int        `db:"id" json:"id"`
+	Mode    FilterMode `db:"mode" json:"mode"`
+	Name    string     `db:"name" json:"name"`
+	Filter  string     `db:"filter" json:"filter"`
+	Default bool       `db:"is_default" json:"-"`
+}
+
+type SavedFilters []*SavedFilter
+
+func (m *SavedFilters) Append(o interface{}) {
+	*m = append(*m, o.(*SavedFilter))
+}
+
+func (m *SavedFilters) New() interface{} {
+	return &SavedFilter{}
+}
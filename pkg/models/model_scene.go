@@ -10,6 +10,7 @@ type Scene struct {
 	ID          int                 `db:"id" json:"id"`
 	Checksum    sql.NullString      `db:"checksum" json:"checksum"`
 	OSHash      sql.NullString      `db:"oshash" json:"oshash"`
+	Phash       sql.NullInt64       `db:"phash" json:"phash"`
 	Path        string              `db:"path" json:"path"`
 	Title       sql.NullString      `db:"title" json:"title"`
 	Details     sql.NullString      `db:"details" json:"details"`
@@ -39,6 +40,7 @@ type ScenePartial struct {
 	ID          int                  `db:"id" json:"id"`
 	Checksum    *sql.NullString      `db:"checksum" json:"checksum"`
 	OSHash      *sql.NullString      `db:"oshash" json:"oshash"`
+	Phash       *sql.NullInt64       `db:"phash" json:"phash"`
 	Path        *string              `db:"path" json:"path"`
 	Title       *sql.NullString      `db:"title" json:"title"`
 	Details     *sql.NullString      `db:"details" json:"details"`
@@ -46,6 +48,7 @@ type ScenePartial struct {
 	Date        *SQLiteDate          `db:"date" json:"date"`
 	Rating      *sql.NullInt64       `db:"rating" json:"rating"`
 	Organized   *bool                `db:"organized" json:"organized"`
+	OCounter    *int                 `db:"o_counter" json:"o_counter"`
 	Size        *sql.NullString      `db:"size" json:"size"`
 	Duration    *sql.NullFloat64     `db:"duration" json:"duration"`
 	VideoCodec  *sql.NullString      `db:"video_codec" json:"video_codec"`
@@ -113,3 +116,15 @@ func (s *Scenes) Append(o interface{}) {
 func (s *Scenes) New() interface{} {
 	return &Scene{}
 }
+
+// ScenePlayHistory stores the play activity of a user for a scene. UserID is
+// null for activity recorded while authentication is not required.
+type ScenePlayHistory struct {
+	ID           int                 `db:"id" json:"id"`
+	SceneID      int                 `db:"scene_id" json:"scene_id"`
+	UserID       sql.NullInt64       `db:"user_id" json:"user_id"`
+	PlayCount    int                 `db:"play_count" json:"play_count"`
+	LastPlayedAt NullSQLiteTimestamp `db:"last_played_at" json:"last_played_at"`
+	ResumeTime   float64             `db:"resume_time" json:"resume_time"`
+	PlayDuration float64             `db:"play_duration" json:"play_duration"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// ScheduledTask is a task that is executed according to a cron-style
+// schedule. Scheduled tasks are stored in the configuration file.
+type ScheduledTask struct {
+	ID       int               `json:"id" yaml:"id" mapstructure:"id"`
+	Name     string            `json:"name" yaml:"name" mapstructure:"name"`
+	Schedule string            `json:"schedule" yaml:"schedule" mapstructure:"schedule"`
+	Enabled  bool              `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+	Type     ScheduledTaskType `json:"type" yaml:"type" mapstructure:"type"`
+
+	// task options. Only the options for the task type are used.
+	Scan     *ScanMetadataInput        `json:"scan" yaml:"scan,omitempty" mapstructure:"scan"`
+	Generate *GenerateMetadataInput    `json:"generate" yaml:"generate,omitempty" mapstructure:"generate"`
+	AutoTag  *AutoTagMetadataInput     `json:"auto_tag" yaml:"auto_tag,omitempty" mapstructure:"auto_tag"`
+	Clean    *CleanMetadataInput       `json:"clean" yaml:"clean,omitempty" mapstructure:"clean"`
+	Plugin   *ScheduledPluginTaskInput `json:"plugin" yaml:"plugin,omitempty" mapstructure:"plugin"`
+}
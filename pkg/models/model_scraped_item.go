@@ -42,6 +42,7 @@ type ScrapedPerformer struct {
 	Aliases      *string            `graphql:"aliases" json:"aliases"`
 	Tags         []*ScrapedSceneTag `graphql:"tags" json:"tags"`
 	Image        *string            `graphql:"image" json:"image"`
+	RemoteSiteID *string            `graphql:"remote_site_id" json:"remote_site_id"`
 }
 
 // this type has no Image field
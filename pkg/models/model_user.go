@@ -0,0 +1,100 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// User is an account that may log in to the server. The password is stored
+// as a bcrypt hash.
+type User struct {
+	ID       int            `db:"id" json:"id"`
+	Username string         `db:"username" json:"username"`
+	Password string         `db:"password" json:"-"`
+	Role     UserRole       `db:"role" json:"role"`
+	APIKey   sql.NullString `db:"api_key" json:"-"`
+	// true if the user was added from the credentials in the configuration
+	Config    bool            `db:"is_config" json:"is_config"`
+	CreatedAt SQLiteTimestamp `db:"created_at" json:"created_at"`
+	UpdatedAt SQLiteTimestamp `db:"updated_at" json:"updated_at"`
+}
+
+func NewUser(username string, passwordHash string, role UserRole) *User {
+	currentTime := time.Now()
+	return &User{
+		Username:  username,
+		Password:  passwordHash,
+		Role:      role,
+		CreatedAt: SQLiteTimestamp{Timestamp: currentTime},
+		UpdatedAt: SQLiteTimestamp{Timestamp: currentTime},
+	}
+}
+
+type userContextKey struct{}
+
+// WithCurrentUser returns a copy of ctx with the user that made the request.
+func WithCurrentUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, u)
+}
+
+// CurrentUser returns the user that made the request. Returns nil if
+// authentication is not required, or if the database has not been migrated.
+func CurrentUser(ctx context.Context) *User {
+	if ctx == nil {
+		return nil
+	}
+
+	u, _ := ctx.Value(userContextKey{}).(*User)
+	return u
+}
+
+// CurrentUserID returns the ID of the user that made the request, or nil if
+// there is no current user.
+func CurrentUserID(ctx context.Context) *int {
+	u := CurrentUser(ctx)
+	if u == nil {
+		return nil
+	}
+
+	return &u.ID
+}
+
+// HasRole returns true if the role of the user grants the permissions of
+// the provided role.
+func (u User) HasRole(role UserRole) bool {
+	return u.Role.Includes(role)
+}
+
+// roles in increasing order of permissions
+var userRoleOrder = []UserRole{
+	UserRoleReadOnly,
+	UserRoleEditor,
+	UserRoleAdmin,
+}
+
+func (r UserRole) level() int {
+	for i, rr := range userRoleOrder {
+		if rr == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Includes returns true if the role has at least the permissions of the
+// other role.
+func (r UserRole) Includes(other UserRole) bool {
+	return r.IsValid() && r.level() >= other.level()
+}
+
+type Users []*User
+
+func (u *Users) Append(o interface{}) {
+	*u = append(*u, o.(*User))
+}
+
+func (u *Users) New() interface{} {
+	return &User{}
+}
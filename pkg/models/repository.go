@@ -1,25 +1,31 @@
 package models
 
 type Repository interface {
+	File() FileReaderWriter
 	Gallery() GalleryReaderWriter
 	Image() ImageReaderWriter
 	Movie() MovieReaderWriter
 	Performer() PerformerReaderWriter
+	SavedFilter() SavedFilterReaderWriter
 	Scene() SceneReaderWriter
 	SceneMarker() SceneMarkerReaderWriter
 	ScrapedItem() ScrapedItemReaderWriter
 	Studio() StudioReaderWriter
 	Tag() TagReaderWriter
+	User() UserReaderWriter
 }
 
 type ReaderRepository interface {
+	File() FileReader
 	Gallery() GalleryReader
 	Image() ImageReader
 	Movie() MovieReader
 	Performer() PerformerReader
+	SavedFilter() SavedFilterReader
 	Scene() SceneReader
 	SceneMarker() SceneMarkerReader
 	ScrapedItem() ScrapedItemReader
 	Studio() StudioReader
 	Tag() TagReader
+	User() UserReader
 }
@@ -8,6 +8,7 @@ type SceneReader interface {
 	FindByPath(path string) (*Scene, error)
 	FindByPerformerID(performerID int) ([]*Scene, error)
 	FindByGalleryID(performerID int) ([]*Scene, error)
+	FindDuplicates(distance int) ([][]*Scene, error)
 	CountByPerformerID(performerID int) (int, error)
 	// FindByStudioID(studioID int) ([]*Scene, error)
 	FindByMovieID(movieID int) ([]*Scene, error)
@@ -28,6 +29,10 @@ type SceneReader interface {
 	GetGalleryIDs(sceneID int) ([]int, error)
 	GetPerformerIDs(sceneID int) ([]int, error)
 	GetStashIDs(sceneID int) ([]*StashID, error)
+	// GetPlayHistory returns the play history of the scene for the current
+	// user. Returns nil if the scene has not been played by the user.
+	GetPlayHistory(sceneID int) (*ScenePlayHistory, error)
+	GetAllPlayHistory(sceneID int) ([]*ScenePlayHistory, error)
 }
 
 type SceneWriter interface {
@@ -37,6 +42,14 @@ type SceneWriter interface {
 	IncrementOCounter(id int) (int, error)
 	DecrementOCounter(id int) (int, error)
 	ResetOCounter(id int) (int, error)
+	IncrementPlayCount(id int) (int, error)
+	SaveActivity(id int, resumeTime *float64, playDuration *float64) error
+	// UpdatePlayHistory replaces the play history of the scene for the user
+	// of the provided history.
+	UpdatePlayHistory(history ScenePlayHistory) error
+	// AssignPlayHistory sets the user of the play history that was recorded
+	// without a user to the user with the provided id.
+	AssignPlayHistory(userID int) error
 	UpdateFileModTime(id int, modTime NullSQLiteTimestamp) error
 	Destroy(id int) error
 	UpdateCover(sceneID int, cover []byte) error
@@ -8,20 +8,30 @@ type TagReader interface {
 	FindBySceneMarkerID(sceneMarkerID int) ([]*Tag, error)
 	FindByImageID(imageID int) ([]*Tag, error)
 	FindByGalleryID(galleryID int) ([]*Tag, error)
+	FindByParentTagID(parentID int) ([]*Tag, error)
+	FindByChildTagID(childID int) ([]*Tag, error)
 	FindByName(name string, nocase bool) (*Tag, error)
 	FindByNames(names []string, nocase bool) ([]*Tag, error)
+	FindByAlias(alias string, nocase bool) (*Tag, error)
 	Count() (int, error)
 	All() ([]*Tag, error)
 	Query(tagFilter *TagFilterType, findFilter *FindFilterType) ([]*Tag, int, error)
 	GetImage(tagID int) ([]byte, error)
+	GetAliases(tagID int) ([]string, error)
+	GetParentIDs(tagID int) ([]int, error)
+	GetChildIDs(tagID int) ([]int, error)
 }
 
 type TagWriter interface {
 	Create(newTag Tag) (*Tag, error)
 	Update(updatedTag Tag) (*Tag, error)
 	Destroy(id int) error
+	Merge(source []int, destination int) ([]*TagsMergeCount, error)
 	UpdateImage(tagID int, image []byte) error
 	DestroyImage(tagID int) error
+	UpdateAliases(tagID int, aliases []string) error
+	UpdateParentTags(tagID int, parentIDs []int) error
+	UpdateChildTags(tagID int, childIDs []int) error
 }
 
 type TagReaderWriter interface {
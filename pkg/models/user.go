@@ -0,0 +1,20 @@
+package models
+
+type UserReader interface {
+	Find(id int) (*User, error)
+	FindByUsername(username string) (*User, error)
+	FindConfigUser() (*User, error)
+	Count() (int, error)
+	All() ([]*User, error)
+}
+
+type UserWriter interface {
+	Create(newUser User) (*User, error)
+	Update(updatedUser User) (*User, error)
+	Destroy(id int) error
+}
+
+type UserReaderWriter interface {
+	UserReader
+	UserWriter
+}
@@ -1,9 +1,33 @@
 package plugin
 
 import (
+	"fmt"
+
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/savedfilter"
 )
 
+// SavedFilterIDArg is the argument that scheduled plugin tasks are passed
+// the ID of their saved filter in.
+const SavedFilterIDArg = "saved_filter_id"
+
+// ValidateSavedFilterArg returns an error if the saved filter passed in the
+// saved_filter_id argument does not exist or is not a valid filter for its
+// mode. Returns nil if the argument is not set.
+func ValidateSavedFilterArg(qb models.SavedFilterReader, args []*models.PluginArgInput) error {
+	arg := findArg(args, SavedFilterIDArg)
+	if arg == nil {
+		return nil
+	}
+
+	if arg.Value == nil || arg.Value.Str == nil {
+		return fmt.Errorf("%s argument must be a string", SavedFilterIDArg)
+	}
+
+	_, err := savedfilter.FindAny(qb, *arg.Value.Str)
+	return err
+}
+
 func findArg(args []*models.PluginArgInput, name string) *models.PluginArgInput {
 	for _, v := range args {
 		if v.Key == name {
@@ -73,6 +73,27 @@ func (m ArgsMap) Float(key string) float64 {
 	return ret
 }
 
+// HookContextKey is the key of the argument containing the HookContext for
+// plugin operations triggered by a hook.
+const HookContextKey = "hookContext"
+
+// HookContext describes the event that triggered a plugin hook.
+type HookContext struct {
+	// ID of the object that the event relates to.
+	ID int `json:"id"`
+
+	// The type of hook that was triggered, eg "Scene.Create.Post".
+	Type string `json:"type"`
+
+	// The input used to create or update the object, if applicable.
+	Input interface{} `json:"input"`
+
+	// The names of the input fields that were provided. This is used to
+	// differentiate between fields that were not set and fields that were
+	// set to an empty value.
+	InputFields []string `json:"inputFields"`
+}
+
 // PluginInput is the data structure that is sent to plugin instances when they
 // are spawned.
 type PluginInput struct {
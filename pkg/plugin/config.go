@@ -54,6 +54,9 @@ type Config struct {
 
 	// The task configurations for tasks provided by this plugin.
 	Tasks []*OperationConfig `yaml:"tasks"`
+
+	// The hooks configurations for hooks registered by this plugin.
+	Hooks []*HookConfig `yaml:"hooks"`
 }
 
 func (c Config) getPluginTasks(includePlugin bool) []*models.PluginTask {
@@ -74,6 +77,31 @@ func (c Config) getPluginTasks(includePlugin bool) []*models.PluginTask {
 	return ret
 }
 
+func (c Config) getPluginHooks() []*models.PluginHook {
+	var ret []*models.PluginHook
+
+	for _, o := range c.Hooks {
+		hook := &models.PluginHook{
+			Name:        o.Name,
+			Description: &o.Description,
+			Hooks:       convertHooks(o.TriggeredBy),
+		}
+
+		ret = append(ret, hook)
+	}
+
+	return ret
+}
+
+func convertHooks(hooks []HookTriggerEnum) []string {
+	var ret []string
+	for _, h := range hooks {
+		ret = append(ret, h.String())
+	}
+
+	return ret
+}
+
 func (c Config) getName() string {
 	if c.Name != "" {
 		return c.Name
@@ -90,6 +118,7 @@ func (c Config) toPlugin() *models.Plugin {
 		URL:         c.URL,
 		Version:     c.Version,
 		Tasks:       c.getPluginTasks(false),
+		Hooks:       c.getPluginHooks(),
 	}
 }
 
@@ -103,6 +132,19 @@ func (c Config) getTask(name string) *OperationConfig {
 	return nil
 }
 
+func (c Config) getHooks(hookType HookTriggerEnum) []*HookConfig {
+	var ret []*HookConfig
+	for _, h := range c.Hooks {
+		for _, t := range h.TriggeredBy {
+			if hookType == t {
+				ret = append(ret, h)
+			}
+		}
+	}
+
+	return ret
+}
+
 func (c Config) getConfigPath() string {
 	return filepath.Dir(c.path)
 }
@@ -188,6 +230,16 @@ type OperationConfig struct {
 	DefaultArgs map[string]string `yaml:"defaultArgs"`
 }
 
+// HookConfig describes the configuration for a single plugin hook provided by
+// a plugin. A hook is an operation that is executed when one of the events in
+// TriggeredBy occurs.
+type HookConfig struct {
+	OperationConfig `yaml:",inline"`
+
+	// A list of events that trigger the operation.
+	TriggeredBy []HookTriggerEnum `yaml:"triggeredBy"`
+}
+
 func loadPluginFromYAML(reader io.Reader) (*Config, error) {
 	ret := &Config{}
 
@@ -206,6 +258,14 @@ func loadPluginFromYAML(reader io.Reader) (*Config, error) {
 		return nil, fmt.Errorf("invalid interface type %s", ret.Interface)
 	}
 
+	for _, h := range ret.Hooks {
+		for _, t := range h.TriggeredBy {
+			if !t.IsValid() {
+				return nil, fmt.Errorf("invalid hook type %s in hook %s", t, h.Name)
+			}
+		}
+	}
+
 	return ret, nil
 }
 
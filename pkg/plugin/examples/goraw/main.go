@@ -65,6 +65,8 @@ func Run(input common.PluginInput, output *common.PluginOutput) error {
 		err = doLongTask()
 	} else if modeArg == "indef" {
 		err = doIndefiniteTask()
+	} else if modeArg == "hook" {
+		log.Infof("Hook context: %v", input.Args[common.HookContextKey])
 	}
 
 	if err != nil {
@@ -0,0 +1,118 @@
+package plugin
+
+import "context"
+
+// HookTriggerEnum identifies the event that triggers a plugin hook.
+type HookTriggerEnum string
+
+// Valid HookTriggerEnum values
+const (
+	SceneMarkerCreatePost  HookTriggerEnum = "SceneMarker.Create.Post"
+	SceneMarkerUpdatePost  HookTriggerEnum = "SceneMarker.Update.Post"
+	SceneMarkerDestroyPost HookTriggerEnum = "SceneMarker.Destroy.Post"
+
+	SceneCreatePost  HookTriggerEnum = "Scene.Create.Post"
+	SceneUpdatePost  HookTriggerEnum = "Scene.Update.Post"
+	SceneDestroyPost HookTriggerEnum = "Scene.Destroy.Post"
+
+	ImageCreatePost  HookTriggerEnum = "Image.Create.Post"
+	ImageUpdatePost  HookTriggerEnum = "Image.Update.Post"
+	ImageDestroyPost HookTriggerEnum = "Image.Destroy.Post"
+
+	GalleryCreatePost  HookTriggerEnum = "Gallery.Create.Post"
+	GalleryUpdatePost  HookTriggerEnum = "Gallery.Update.Post"
+	GalleryDestroyPost HookTriggerEnum = "Gallery.Destroy.Post"
+
+	MovieCreatePost  HookTriggerEnum = "Movie.Create.Post"
+	MovieUpdatePost  HookTriggerEnum = "Movie.Update.Post"
+	MovieDestroyPost HookTriggerEnum = "Movie.Destroy.Post"
+
+	PerformerCreatePost  HookTriggerEnum = "Performer.Create.Post"
+	PerformerUpdatePost  HookTriggerEnum = "Performer.Update.Post"
+	PerformerDestroyPost HookTriggerEnum = "Performer.Destroy.Post"
+
+	StudioCreatePost  HookTriggerEnum = "Studio.Create.Post"
+	StudioUpdatePost  HookTriggerEnum = "Studio.Update.Post"
+	StudioDestroyPost HookTriggerEnum = "Studio.Destroy.Post"
+
+	TagCreatePost  HookTriggerEnum = "Tag.Create.Post"
+	TagUpdatePost  HookTriggerEnum = "Tag.Update.Post"
+	TagDestroyPost HookTriggerEnum = "Tag.Destroy.Post"
+)
+
+// AllHookTriggerEnum contains all of the valid HookTriggerEnum values.
+var AllHookTriggerEnum = []HookTriggerEnum{
+	SceneMarkerCreatePost,
+	SceneMarkerUpdatePost,
+	SceneMarkerDestroyPost,
+
+	SceneCreatePost,
+	SceneUpdatePost,
+	SceneDestroyPost,
+
+	ImageCreatePost,
+	ImageUpdatePost,
+	ImageDestroyPost,
+
+	GalleryCreatePost,
+	GalleryUpdatePost,
+	GalleryDestroyPost,
+
+	MovieCreatePost,
+	MovieUpdatePost,
+	MovieDestroyPost,
+
+	PerformerCreatePost,
+	PerformerUpdatePost,
+	PerformerDestroyPost,
+
+	StudioCreatePost,
+	StudioUpdatePost,
+	StudioDestroyPost,
+
+	TagCreatePost,
+	TagUpdatePost,
+	TagDestroyPost,
+}
+
+func (e HookTriggerEnum) IsValid() bool {
+	for _, v := range AllHookTriggerEnum {
+		if v == e {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e HookTriggerEnum) String() string {
+	return string(e)
+}
+
+type visitedHooksKey struct{}
+
+// hookKey returns the value used to identify a hook in the visited hooks.
+func hookKey(pluginID string, hookName string) string {
+	return pluginID + "/" + hookName
+}
+
+// VisitedHooks returns the hooks that triggered the operation being
+// performed with ctx.
+func VisitedHooks(ctx context.Context) []string {
+	ret, _ := ctx.Value(visitedHooksKey{}).([]string)
+	return ret
+}
+
+// WithVisitedHooks returns a copy of ctx carrying the provided visited
+// hooks. This is used to restore the visited hooks of requests made by
+// plugin hooks.
+func WithVisitedHooks(ctx context.Context, hooks []string) context.Context {
+	if len(hooks) == 0 {
+		return ctx
+	}
+
+	// copy the slice so that contexts do not share the backing array
+	v := make([]string, len(hooks))
+	copy(v, hooks)
+	return context.WithValue(ctx, visitedHooksKey{}, v)
+}
@@ -8,6 +8,7 @@
 package plugin
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,12 +16,19 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/common"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
+// ServerConnectionFunc returns the details needed by a plugin instance to
+// connect to the stash server, on behalf of the provided context.
+type ServerConnectionFunc func(ctx context.Context) common.StashServerConnection
+
 // Cache stores plugin details.
 type Cache struct {
 	path    string
 	plugins []Config
+
+	serverConnectionFunc ServerConnectionFunc
 }
 
 // NewCache returns a new Cache loading plugin configurations
@@ -54,6 +62,24 @@ func (c *Cache) ReloadPlugins() error {
 	return nil
 }
 
+// RegisterServerConnectionFunc sets the function used to generate the server
+// connection details for plugin operations that are not initiated by a
+// request, such as hooks and scheduled tasks.
+func (c *Cache) RegisterServerConnectionFunc(f ServerConnectionFunc) {
+	c.serverConnectionFunc = f
+}
+
+// ServerConnection returns the details needed by a plugin instance to
+// connect to the stash server, on behalf of the provided context. Returns
+// empty connection details if no ServerConnectionFunc is registered.
+func (c Cache) ServerConnection(ctx context.Context) common.StashServerConnection {
+	if c.serverConnectionFunc == nil {
+		return common.StashServerConnection{}
+	}
+
+	return c.serverConnectionFunc(ctx)
+}
+
 func loadPlugins(path string) ([]Config, error) {
 	plugins := make([]Config, 0)
 
@@ -129,6 +155,86 @@ func (c Cache) CreateTask(pluginID string, operationName string, serverConnectio
 	return task.createTask(), nil
 }
 
+// ExecutePostHooks executes the plugin hooks triggered by hookType. The hook
+// operations are passed a HookContext containing the object id, hook type,
+// input and the names of the input fields that were set. ExecutePostHooks
+// must be called after the transaction that changed the object has been
+// committed.
+//
+// Hooks are executed in a separate goroutine, in the order that the plugins
+// were loaded, so that slow plugins do not block the caller. Errors are
+// logged and do not prevent subsequent hooks from being executed. Hooks that
+// are already running further up the call chain - such as a Scene.Update.Post
+// hook that updates its scene - are not executed again.
+func (c Cache) ExecutePostHooks(ctx context.Context, id int, hookType HookTriggerEnum, input interface{}, inputFields []string) {
+	visited := VisitedHooks(ctx)
+	hookContext := &common.HookContext{
+		ID:          id,
+		Type:        hookType.String(),
+		Input:       input,
+		InputFields: inputFields,
+	}
+
+	var tasks []pluginTask
+	var names []string
+
+	for i := range c.plugins {
+		plugin := &c.plugins[i]
+		for _, h := range plugin.getHooks(hookType) {
+			hook := hookKey(plugin.id, h.Name)
+			if utils.StrInclude(visited, hook) {
+				logger.Debugf("Hook %s in plugin %s is already running, not executing for %s", h.Name, plugin.getName(), hookType)
+				continue
+			}
+
+			// the server connection carries the hook, so that changes made
+			// by the hook do not trigger it again
+			hookCtx := WithVisitedHooks(ctx, append(visited, hook))
+
+			tasks = append(tasks, pluginTask{
+				plugin:           plugin,
+				operation:        &h.OperationConfig,
+				serverConnection: c.ServerConnection(hookCtx),
+				hookContext:      hookContext,
+			})
+			names = append(names, h.Name)
+		}
+	}
+
+	if len(tasks) == 0 {
+		return
+	}
+
+	go func() {
+		for i, task := range tasks {
+			logger.Debugf("Executing hook %s in plugin %s for %s", names[i], task.plugin.getName(), hookType)
+
+			if err := c.executeHookTask(task.createTask()); err != nil {
+				logger.Errorf("Error executing hook %s in plugin %s: %s", names[i], task.plugin.getName(), err.Error())
+			}
+		}
+	}()
+}
+
+func (c Cache) executeHookTask(task Task) error {
+	if err := task.Start(); err != nil {
+		return err
+	}
+
+	task.Wait()
+
+	output := task.GetResult()
+	if output == nil {
+		logger.Debug("Plugin returned no result")
+	} else if output.Error != nil {
+		return fmt.Errorf("plugin returned error: %s", *output.Error)
+	} else if output.Output != nil {
+		logger.Debugf("Plugin returned: %v", output.Output)
+	}
+
+	return nil
+}
+
 func (c Cache) getPlugin(pluginID string) *Config {
 	for _, s := range c.plugins {
 		if s.id == pluginID {
@@ -33,6 +33,7 @@ type pluginTask struct {
 	operation        *OperationConfig
 	serverConnection common.StashServerConnection
 	args             []*models.PluginArgInput
+	hookContext      *common.HookContext
 
 	progress chan float64
 	result   *common.PluginOutput
@@ -49,8 +50,14 @@ func (t *pluginTask) createTask() Task {
 func (t *pluginTask) buildPluginInput() common.PluginInput {
 	args := applyDefaultArgs(t.args, t.operation.DefaultArgs)
 	t.serverConnection.PluginDir = t.plugin.getConfigPath()
-	return common.PluginInput{
+	ret := common.PluginInput{
 		ServerConnection: t.serverConnection,
 		Args:             toPluginArgs(args),
 	}
+
+	if t.hookContext != nil {
+		ret.Args[common.HookContextKey] = t.hookContext
+	}
+
+	return ret
 }
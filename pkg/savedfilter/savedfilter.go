@@ -0,0 +1,252 @@
+package savedfilter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// Filter is the decoded filter of a saved filter. Filters are stored as JSON
+// objects. The find_filter field is a FindFilterType, and the object_filter
+// field is the filter type of the mode. Other fields are used by the UI and
+// are ignored.
+type Filter struct {
+	FindFilter   *models.FindFilterType `json:"find_filter"`
+	ObjectFilter json.RawMessage        `json:"object_filter"`
+}
+
+func newObjectFilter(mode models.FilterMode) (interface{}, error) {
+	switch mode {
+	case models.FilterModeScenes:
+		return &models.SceneFilterType{}, nil
+	case models.FilterModePerformers:
+		return &models.PerformerFilterType{}, nil
+	case models.FilterModeStudios:
+		return &models.StudioFilterType{}, nil
+	case models.FilterModeGalleries:
+		return &models.GalleryFilterType{}, nil
+	case models.FilterModeSceneMarkers:
+		return &models.SceneMarkerFilterType{}, nil
+	case models.FilterModeMovies:
+		return &models.MovieFilterType{}, nil
+	case models.FilterModeTags:
+		return &models.TagFilterType{}, nil
+	case models.FilterModeImages:
+		return &models.ImageFilterType{}, nil
+	}
+
+	return nil, fmt.Errorf("invalid filter mode: %s", mode)
+}
+
+// decode decodes the filter, and the object filter into the filter type of
+// the mode.
+func decode(mode models.FilterMode, filter string) (*Filter, interface{}, error) {
+	var ret Filter
+	if err := json.Unmarshal([]byte(filter), &ret); err != nil {
+		return nil, nil, fmt.Errorf("invalid filter: %s", err.Error())
+	}
+
+	objectFilter, err := newObjectFilter(mode)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(ret.ObjectFilter) > 0 {
+		// reject unknown fields so that misspelt criteria are not ignored
+		decoder := json.NewDecoder(bytes.NewReader(ret.ObjectFilter))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(objectFilter); err != nil {
+			return nil, nil, fmt.Errorf("invalid object_filter: %s", err.Error())
+		}
+	}
+
+	return &ret, objectFilter, nil
+}
+
+// Validate returns an error if the filter is not a valid JSON filter for the
+// mode.
+func Validate(mode models.FilterMode, filter string) error {
+	_, _, err := decode(mode, filter)
+	return err
+}
+
+func find(qb models.SavedFilterReader, id string) (*models.SavedFilter, error) {
+	filterID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := qb.Find(filterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		return nil, fmt.Errorf("saved filter with id %d not found", filterID)
+	}
+
+	return ret, nil
+}
+
+// Find returns the saved filter with the provided ID. It returns an error if
+// the filter does not exist, if it is a default filter, or if it is not a
+// filter of the mode.
+func Find(qb models.SavedFilterReader, id string, mode models.FilterMode) (*models.SavedFilter, error) {
+	ret, err := find(qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret.Mode != mode {
+		return nil, fmt.Errorf("saved filter %q is a %s filter, not a %s filter", ret.Name, ret.Mode, mode)
+	}
+
+	return ret, nil
+}
+
+// FindAny returns the saved filter with the provided ID, which may be of any
+// mode. It returns an error if the filter does not exist, if it is a default
+// filter, or if it is not a valid filter for its mode.
+func FindAny(qb models.SavedFilterReader, id string) (*models.SavedFilter, error) {
+	ret, err := find(qb, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Validate(ret.Mode, ret.Filter); err != nil {
+		return nil, fmt.Errorf("saved filter %q: %s", ret.Name, err.Error())
+	}
+
+	return ret, nil
+}
+
+// getFilters returns the find filter and object filter of the saved filter.
+// The page and page size of the find filter are replaced so that all
+// results are returned.
+func getFilters(f *models.SavedFilter, mode models.FilterMode) (*models.FindFilterType, interface{}, error) {
+	if f.Mode != mode {
+		return nil, nil, fmt.Errorf("not a %s filter", mode)
+	}
+
+	filter, objectFilter, err := decode(f.Mode, f.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allFilter := models.FindFilterType{}
+	if filter.FindFilter != nil {
+		allFilter = *filter.FindFilter
+	}
+	allPages := 0
+	allFilter.Page = nil
+	allFilter.PerPage = &allPages
+
+	return &allFilter, objectFilter, nil
+}
+
+// FindSceneIDs returns the IDs of the scenes matched by the saved scenes
+// filter with the provided ID, in the order of the filter.
+func FindSceneIDs(filterReader models.SavedFilterReader, sceneReader models.SceneReader, id string) ([]int, error) {
+	f, err := Find(filterReader, id, models.FilterModeScenes)
+	if err != nil {
+		return nil, err
+	}
+
+	findFilter, objectFilter, err := getFilters(f, models.FilterModeScenes)
+	if err != nil {
+		return nil, err
+	}
+
+	scenes, _, err := sceneReader.Query(objectFilter.(*models.SceneFilterType), findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []int
+	for _, s := range scenes {
+		ret = append(ret, s.ID)
+	}
+
+	return ret, nil
+}
+
+// FindImageIDs returns the IDs of the images matched by the saved images
+// filter with the provided ID, in the order of the filter.
+func FindImageIDs(filterReader models.SavedFilterReader, imageReader models.ImageReader, id string) ([]int, error) {
+	f, err := Find(filterReader, id, models.FilterModeImages)
+	if err != nil {
+		return nil, err
+	}
+
+	findFilter, objectFilter, err := getFilters(f, models.FilterModeImages)
+	if err != nil {
+		return nil, err
+	}
+
+	images, _, err := imageReader.Query(objectFilter.(*models.ImageFilterType), findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []int
+	for _, i := range images {
+		ret = append(ret, i.ID)
+	}
+
+	return ret, nil
+}
+
+// FindGalleryIDs returns the IDs of the galleries matched by the saved
+// galleries filter with the provided ID, in the order of the filter.
+func FindGalleryIDs(filterReader models.SavedFilterReader, galleryReader models.GalleryReader, id string) ([]int, error) {
+	f, err := Find(filterReader, id, models.FilterModeGalleries)
+	if err != nil {
+		return nil, err
+	}
+
+	findFilter, objectFilter, err := getFilters(f, models.FilterModeGalleries)
+	if err != nil {
+		return nil, err
+	}
+
+	galleries, _, err := galleryReader.Query(objectFilter.(*models.GalleryFilterType), findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []int
+	for _, g := range galleries {
+		ret = append(ret, g.ID)
+	}
+
+	return ret, nil
+}
+
+// FindPerformerIDs returns the IDs of the performers matched by the saved
+// performers filter with the provided ID, in the order of the filter.
+func FindPerformerIDs(filterReader models.SavedFilterReader, performerReader models.PerformerReader, id string) ([]int, error) {
+	f, err := Find(filterReader, id, models.FilterModePerformers)
+	if err != nil {
+		return nil, err
+	}
+
+	findFilter, objectFilter, err := getFilters(f, models.FilterModePerformers)
+	if err != nil {
+		return nil, err
+	}
+
+	performers, _, err := performerReader.Query(objectFilter.(*models.PerformerFilterType), findFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []int
+	for _, p := range performers {
+		ret = append(ret, p.ID)
+	}
+
+	return ret, nil
+}
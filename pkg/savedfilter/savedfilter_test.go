@@ -0,0 +1,149 @@
+package savedfilter
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	sceneFilterID     = 1
+	performerFilterID = 2
+	missingFilterID   = 3
+	imageFilterID     = 4
+)
+
+const sceneFilter = `{
+	"find_filter": {"q": "query", "page": 2, "per_page": 40, "sort": "date", "direction": "DESC"},
+	"object_filter": {"rating": {"value": 3, "modifier": "GREATER_THAN"}},
+	"ui_options": {"display_mode": 0}
+}`
+
+func TestValidate(t *testing.T) {
+	assert.Nil(t, Validate(models.FilterModeScenes, sceneFilter))
+	assert.Nil(t, Validate(models.FilterModeImages, `{}`))
+	assert.Nil(t, Validate(models.FilterModeTags, `{"object_filter": {"scene_count": {"value": 1, "modifier": "EQUALS"}}}`))
+
+	assert.NotNil(t, Validate(models.FilterModeScenes, `not json`))
+	assert.NotNil(t, Validate(models.FilterModeScenes, `{"find_filter": []}`))
+	// unknown criteria are not ignored
+	assert.NotNil(t, Validate(models.FilterModeScenes, `{"object_filter": {"rtaing": {"value": 3, "modifier": "EQUALS"}}}`))
+	assert.NotNil(t, Validate(models.FilterModeScenes, `{"object_filter": {"scene_count": {"value": 1, "modifier": "EQUALS"}}}`))
+	assert.NotNil(t, Validate(models.FilterMode("INVALID"), `{}`))
+}
+
+func TestFindSceneIDs(t *testing.T) {
+	filterReader := &mocks.SavedFilterReaderWriter{}
+	sceneReader := &mocks.SceneReaderWriter{}
+
+	filterReader.On("Find", sceneFilterID).Return(&models.SavedFilter{
+		ID:     sceneFilterID,
+		Mode:   models.FilterModeScenes,
+		Name:   "scenes",
+		Filter: sceneFilter,
+	}, nil)
+	filterReader.On("Find", performerFilterID).Return(&models.SavedFilter{
+		ID:     performerFilterID,
+		Mode:   models.FilterModePerformers,
+		Name:   "performers",
+		Filter: `{}`,
+	}, nil)
+	filterReader.On("Find", missingFilterID).Return(nil, nil)
+
+	// all pages of the filter are returned
+	sceneReader.On("Query", mock.MatchedBy(func(f *models.SceneFilterType) bool {
+		return f.Rating != nil && f.Rating.Value == 3 && f.Rating.Modifier == models.CriterionModifierGreaterThan
+	}), mock.MatchedBy(func(f *models.FindFilterType) bool {
+		return *f.Q == "query" && *f.Sort == "date" && f.Page == nil && f.IsGetAll()
+	})).Return([]*models.Scene{{ID: 3}, {ID: 1}}, 2, nil)
+
+	ids, err := FindSceneIDs(filterReader, sceneReader, "1")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{3, 1}, ids)
+
+	_, err = FindSceneIDs(filterReader, sceneReader, "2")
+	assert.NotNil(t, err)
+
+	_, err = FindSceneIDs(filterReader, sceneReader, "3")
+	assert.NotNil(t, err)
+
+	_, err = FindSceneIDs(filterReader, sceneReader, "invalid")
+	assert.NotNil(t, err)
+
+	filterReader.AssertExpectations(t)
+	sceneReader.AssertExpectations(t)
+}
+
+func TestFindImageIDs(t *testing.T) {
+	filterReader := &mocks.SavedFilterReaderWriter{}
+	imageReader := &mocks.ImageReaderWriter{}
+
+	filterReader.On("Find", imageFilterID).Return(&models.SavedFilter{
+		ID:     imageFilterID,
+		Mode:   models.FilterModeImages,
+		Name:   "images",
+		Filter: `{"object_filter": {"organized": false}}`,
+	}, nil)
+	filterReader.On("Find", sceneFilterID).Return(&models.SavedFilter{
+		ID:     sceneFilterID,
+		Mode:   models.FilterModeScenes,
+		Name:   "scenes",
+		Filter: sceneFilter,
+	}, nil)
+
+	imageReader.On("Query", mock.MatchedBy(func(f *models.ImageFilterType) bool {
+		return f.Organized != nil && !*f.Organized
+	}), mock.MatchedBy(func(f *models.FindFilterType) bool {
+		return f.IsGetAll()
+	})).Return([]*models.Image{{ID: 2}}, 1, nil)
+
+	ids, err := FindImageIDs(filterReader, imageReader, "4")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2}, ids)
+
+	// scene filters cannot be used to find images
+	_, err = FindImageIDs(filterReader, imageReader, "1")
+	assert.NotNil(t, err)
+
+	filterReader.AssertExpectations(t)
+	imageReader.AssertExpectations(t)
+}
+
+func TestFindAny(t *testing.T) {
+	const invalidFilterID = 5
+
+	filterReader := &mocks.SavedFilterReaderWriter{}
+
+	filterReader.On("Find", performerFilterID).Return(&models.SavedFilter{
+		ID:     performerFilterID,
+		Mode:   models.FilterModePerformers,
+		Name:   "performers",
+		Filter: `{}`,
+	}, nil)
+	filterReader.On("Find", missingFilterID).Return(nil, nil)
+	filterReader.On("Find", invalidFilterID).Return(&models.SavedFilter{
+		ID:     invalidFilterID,
+		Mode:   models.FilterModePerformers,
+		Name:   "invalid",
+		Filter: sceneFilter,
+	}, nil)
+
+	f, err := FindAny(filterReader, "2")
+	assert.Nil(t, err)
+	assert.Equal(t, performerFilterID, f.ID)
+
+	_, err = FindAny(filterReader, "3")
+	assert.NotNil(t, err)
+
+	// the filter must be valid for its own mode
+	_, err = FindAny(filterReader, "5")
+	assert.NotNil(t, err)
+
+	_, err = FindAny(filterReader, "invalid")
+	assert.NotNil(t, err)
+
+	filterReader.AssertExpectations(t)
+}
@@ -261,6 +261,47 @@ func GetSceneMarkersJSON(markerReader models.SceneMarkerReader, tagReader models
 	return results, nil
 }
 
+// GetScenePlayHistoryJSON returns a slice of ScenePlayHistory JSON
+// representation objects corresponding to the play history of each user for
+// the provided scene.
+func GetScenePlayHistoryJSON(sceneReader models.SceneReader, userReader models.UserReader, scene *models.Scene) ([]jsonschema.ScenePlayHistory, error) {
+	playHistory, err := sceneReader.GetAllPlayHistory(scene.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting scene play history: %s", err.Error())
+	}
+
+	var results []jsonschema.ScenePlayHistory
+
+	for _, h := range playHistory {
+		historyJSON := jsonschema.ScenePlayHistory{
+			PlayCount:    h.PlayCount,
+			ResumeTime:   h.ResumeTime,
+			PlayDuration: h.PlayDuration,
+		}
+
+		if h.LastPlayedAt.Valid {
+			historyJSON.LastPlayedAt = models.JSONTime{Time: h.LastPlayedAt.Timestamp}
+		}
+
+		if h.UserID.Valid {
+			user, err := userReader.Find(int(h.UserID.Int64))
+			if err != nil {
+				return nil, fmt.Errorf("error getting play history user: %s", err.Error())
+			}
+
+			if user == nil {
+				continue
+			}
+
+			historyJSON.User = user.Username
+		}
+
+		results = append(results, historyJSON)
+	}
+
+	return results, nil
+}
+
 func getDecimalString(num float64) string {
 	if num == 0 {
 		return ""
@@ -46,6 +46,9 @@ const (
 	date         = "2001-01-01"
 	rating       = 5
 	ocounter     = 2
+	playCount    = 3
+	resumeTime   = 4.56
+	playDuration = 7.89
 	organized    = true
 	details      = "details"
 	size         = "size"
@@ -87,6 +90,7 @@ const image = "aW1hZ2VCeXRlcw=="
 
 var createTime time.Time = time.Date(2001, 01, 01, 0, 0, 0, 0, time.UTC)
 var updateTime time.Time = time.Date(2002, 01, 01, 0, 0, 0, 0, time.UTC)
+var playTime time.Time = time.Date(2003, 01, 01, 0, 0, 0, 0, time.UTC)
 
 func createFullScene(id int) models.Scene {
 	return models.Scene{
@@ -640,3 +644,104 @@ func TestGetSceneMarkersJSON(t *testing.T) {
 
 	mockTagReader.AssertExpectations(t)
 }
+
+const (
+	playHistoryUserID        = 1
+	missingPlayHistoryUserID = 2
+	playHistoryUsername      = "username"
+
+	noPlayHistoryID  = 20
+	errPlayHistoryID = 21
+)
+
+var validPlayHistory = []*models.ScenePlayHistory{
+	{
+		SceneID:      sceneID,
+		PlayCount:    playCount,
+		ResumeTime:   resumeTime,
+		PlayDuration: playDuration,
+		LastPlayedAt: models.NullSQLiteTimestamp{
+			Timestamp: playTime,
+			Valid:     true,
+		},
+	},
+	{
+		SceneID:   sceneID,
+		UserID:    models.NullInt64(playHistoryUserID),
+		PlayCount: playCount,
+	},
+	{
+		SceneID:   sceneID,
+		UserID:    models.NullInt64(missingPlayHistoryUserID),
+		PlayCount: playCount,
+	},
+}
+
+type scenePlayHistoryTestScenario struct {
+	input    models.Scene
+	expected []jsonschema.ScenePlayHistory
+	err      bool
+}
+
+var getScenePlayHistoryJSONScenarios = []scenePlayHistoryTestScenario{
+	{
+		createEmptyScene(sceneID),
+		[]jsonschema.ScenePlayHistory{
+			{
+				PlayCount:    playCount,
+				ResumeTime:   resumeTime,
+				PlayDuration: playDuration,
+				LastPlayedAt: models.JSONTime{
+					Time: playTime,
+				},
+			},
+			{
+				User:      playHistoryUsername,
+				PlayCount: playCount,
+			},
+		},
+		false,
+	},
+	{
+		createEmptyScene(noPlayHistoryID),
+		nil,
+		false,
+	},
+	{
+		createEmptyScene(errPlayHistoryID),
+		nil,
+		true,
+	},
+}
+
+func TestGetScenePlayHistoryJSON(t *testing.T) {
+	mockSceneReader := &mocks.SceneReaderWriter{}
+	mockUserReader := &mocks.UserReaderWriter{}
+
+	playHistoryErr := errors.New("error getting play history")
+
+	mockSceneReader.On("GetAllPlayHistory", sceneID).Return(validPlayHistory, nil).Once()
+	mockSceneReader.On("GetAllPlayHistory", noPlayHistoryID).Return(nil, nil).Once()
+	mockSceneReader.On("GetAllPlayHistory", errPlayHistoryID).Return(nil, playHistoryErr).Once()
+
+	mockUserReader.On("Find", playHistoryUserID).Return(&models.User{
+		Username: playHistoryUsername,
+	}, nil)
+	mockUserReader.On("Find", missingPlayHistoryUserID).Return(nil, nil)
+
+	for i, s := range getScenePlayHistoryJSONScenarios {
+		scene := s.input
+		json, err := GetScenePlayHistoryJSON(mockSceneReader, mockUserReader, &scene)
+
+		if !s.err && err != nil {
+			t.Errorf("[%d] unexpected error: %s", i, err.Error())
+		} else if s.err && err == nil {
+			t.Errorf("[%d] expected error not returned", i)
+		} else {
+			assert.Equal(t, s.expected, json, "[%d]", i)
+		}
+	}
+
+	mockSceneReader.AssertExpectations(t)
+	mockUserReader.AssertExpectations(t)
+}
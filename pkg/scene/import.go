@@ -18,6 +18,7 @@ type Importer struct {
 	PerformerWriter     models.PerformerReaderWriter
 	MovieWriter         models.MovieReaderWriter
 	TagWriter           models.TagReaderWriter
+	UserReader          models.UserReader
 	Input               jsonschema.Scene
 	Path                string
 	MissingRefBehaviour models.ImportMissingRefEnum
@@ -29,6 +30,7 @@ type Importer struct {
 	performers     []*models.Performer
 	movies         []models.MoviesScenes
 	tags           []*models.Tag
+	playHistory    []models.ScenePlayHistory
 	coverImageData []byte
 }
 
@@ -55,6 +57,10 @@ func (i *Importer) PreImport() error {
 		return err
 	}
 
+	if err := i.populatePlayHistory(); err != nil {
+		return err
+	}
+
 	var err error
 	if len(i.Input.Cover) > 0 {
 		_, i.coverImageData, err = utils.ProcessBase64Image(i.Input.Cover)
@@ -333,6 +339,43 @@ func (i *Importer) populateTags() error {
 	return nil
 }
 
+func (i *Importer) populatePlayHistory() error {
+	i.playHistory = nil
+	for _, h := range i.Input.PlayHistory {
+		history := models.ScenePlayHistory{
+			PlayCount:    h.PlayCount,
+			ResumeTime:   h.ResumeTime,
+			PlayDuration: h.PlayDuration,
+		}
+
+		if !h.LastPlayedAt.IsZero() {
+			history.LastPlayedAt = models.NullSQLiteTimestamp{Timestamp: h.LastPlayedAt.GetTime(), Valid: true}
+		}
+
+		if h.User != "" {
+			user, err := i.UserReader.FindByUsername(h.User)
+			if err != nil {
+				return fmt.Errorf("error finding user by name: %s", err.Error())
+			}
+
+			// users are not created on import
+			if user == nil {
+				if i.MissingRefBehaviour == models.ImportMissingRefEnumFail {
+					return fmt.Errorf("scene play history user '%s' not found", h.User)
+				}
+
+				continue
+			}
+
+			history.UserID = sql.NullInt64{Int64: int64(user.ID), Valid: true}
+		}
+
+		i.playHistory = append(i.playHistory, history)
+	}
+
+	return nil
+}
+
 func (i *Importer) PostImport(id int) error {
 	if len(i.coverImageData) > 0 {
 		if err := i.ReaderWriter.UpdateCover(id, i.coverImageData); err != nil {
@@ -381,6 +424,13 @@ func (i *Importer) PostImport(id int) error {
 		}
 	}
 
+	for _, h := range i.playHistory {
+		h.SceneID = id
+		if err := i.ReaderWriter.UpdatePlayHistory(h); err != nil {
+			return fmt.Errorf("failed to set play history: %s", err.Error())
+		}
+	}
+
 	return nil
 }
 
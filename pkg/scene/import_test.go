@@ -492,6 +492,57 @@ func TestImporterPreImportWithMissingTagCreateErr(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestImporterPreImportWithPlayHistory(t *testing.T) {
+	userReader := &mocks.UserReaderWriter{}
+
+	const missingUsername = "missingUsername"
+
+	i := Importer{
+		UserReader: userReader,
+		Path:       path,
+		Input: jsonschema.Scene{
+			PlayHistory: []jsonschema.ScenePlayHistory{
+				{
+					PlayCount: playCount,
+				},
+				{
+					User:      playHistoryUsername,
+					PlayCount: playCount,
+				},
+				{
+					User:      missingUsername,
+					PlayCount: playCount,
+				},
+			},
+		},
+		MissingRefBehaviour: models.ImportMissingRefEnumFail,
+	}
+
+	userReader.On("FindByUsername", playHistoryUsername).Return(&models.User{
+		ID: playHistoryUserID,
+	}, nil).Twice()
+	userReader.On("FindByUsername", missingUsername).Return(nil, nil).Twice()
+
+	err := i.PreImport()
+	assert.NotNil(t, err)
+
+	// users are not created on import, so missing users are ignored
+	i.MissingRefBehaviour = models.ImportMissingRefEnumCreate
+	err = i.PreImport()
+	assert.Nil(t, err)
+	assert.Equal(t, []models.ScenePlayHistory{
+		{
+			PlayCount: playCount,
+		},
+		{
+			UserID:    models.NullInt64(playHistoryUserID),
+			PlayCount: playCount,
+		},
+	}, i.playHistory)
+
+	userReader.AssertExpectations(t)
+}
+
 func TestImporterPostImport(t *testing.T) {
 	readerWriter := &mocks.SceneReaderWriter{}
 
@@ -623,6 +674,39 @@ func TestImporterPostImportUpdateTags(t *testing.T) {
 	sceneReaderWriter.AssertExpectations(t)
 }
 
+func TestImporterPostImportUpdatePlayHistory(t *testing.T) {
+	sceneReaderWriter := &mocks.SceneReaderWriter{}
+
+	i := Importer{
+		ReaderWriter: sceneReaderWriter,
+		playHistory: []models.ScenePlayHistory{
+			{
+				UserID:    models.NullInt64(playHistoryUserID),
+				PlayCount: playCount,
+			},
+		},
+	}
+
+	updateErr := errors.New("UpdatePlayHistory error")
+
+	sceneReaderWriter.On("UpdatePlayHistory", models.ScenePlayHistory{
+		SceneID:   sceneID,
+		UserID:    models.NullInt64(playHistoryUserID),
+		PlayCount: playCount,
+	}).Return(nil).Once()
+	sceneReaderWriter.On("UpdatePlayHistory", mock.MatchedBy(func(h models.ScenePlayHistory) bool {
+		return h.SceneID == errPlayHistoryID
+	})).Return(updateErr).Once()
+
+	err := i.PostImport(sceneID)
+	assert.Nil(t, err)
+
+	err = i.PostImport(errPlayHistoryID)
+	assert.NotNil(t, err)
+
+	sceneReaderWriter.AssertExpectations(t)
+}
+
 func TestImporterFindExistingID(t *testing.T) {
 	readerWriter := &mocks.SceneReaderWriter{}
 
@@ -2,6 +2,8 @@ package scene
 
 import (
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
@@ -103,3 +105,54 @@ func AddGallery(qb models.SceneReaderWriter, id int, galleryID int) (bool, error
 
 	return false, nil
 }
+
+// UpdateMovedFile sets the path and file modification time of the scene to
+// those of the file that it was moved or renamed to.
+func UpdateMovedFile(qb models.SceneWriter, id int, path string, modTime models.NullSQLiteTimestamp) (*models.Scene, error) {
+	return qb.Update(models.ScenePartial{
+		ID:          id,
+		Path:        &path,
+		FileModTime: &modTime,
+	})
+}
+
+// UpdateMovedSecondaryFile sets the path and modification time of a file
+// that is not the primary file of its scene.
+func UpdateMovedSecondaryFile(fqb models.FileWriter, f models.File, path string, modTime models.NullSQLiteTimestamp) (*models.File, error) {
+	f.Path = path
+	f.FileModTime = modTime
+	f.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+	return fqb.Update(f)
+}
+
+// SetPrimaryFile makes the file with the provided id the primary file of the
+// scene. The file details of the scene are set to those of the file.
+func SetPrimaryFile(qb models.SceneWriter, fqb models.FileReaderWriter, sceneID int, fileID int) (*models.Scene, error) {
+	files, err := fqb.FindBySceneID(sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	var primary *models.File
+	for _, f := range files {
+		if f.ID == fileID {
+			primary = f
+		}
+	}
+
+	if primary == nil {
+		return nil, fmt.Errorf("file with id %d not found for scene %d", fileID, sceneID)
+	}
+
+	for _, f := range files {
+		isPrimary := f.ID == fileID
+		if f.Primary != isPrimary {
+			f.Primary = isPrimary
+			if _, err := fqb.Update(*f); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return qb.Update(primary.ScenePartial(sceneID))
+}
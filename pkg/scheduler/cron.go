@@ -0,0 +1,240 @@
+// Package scheduler provides parsing of cron-style schedules, and a
+// Scheduler that executes tasks according to those schedules.
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the maximum amount of time to search for the next matching time
+const maxSearchYears = 5
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as an alias for sunday
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron-style schedule.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// set if the day of month or day of week fields are unrestricted
+	domStar bool
+	dowStar bool
+}
+
+// ParseSchedule parses a cron-style schedule in the form:
+//
+//	minute hour day-of-month month day-of-week
+//
+// Each field may be a "*", a number, a range ("1-5"), or a comma-separated
+// list of these. Any of these may be followed by a step ("*/15", "1-10/2").
+// Months and days of week may be specified by their three-letter English
+// names. The descriptors @yearly, @annually, @monthly, @weekly, @daily,
+// @midnight and @hourly are also accepted.
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if d, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = d
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, found %d", spec, len(fields))
+	}
+
+	ret := &Schedule{}
+	var err error
+
+	if ret.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if ret.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if ret.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if ret.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if ret.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+
+	// treat 7 as sunday
+	if ret.dow&(1<<7) != 0 {
+		ret.dow |= 1
+	}
+
+	ret.domStar = strings.HasPrefix(fields[2], "*")
+	ret.dowStar = strings.HasPrefix(fields[4], "*")
+
+	return ret, nil
+}
+
+func (f field) parse(s string) (uint64, error) {
+	var ret uint64
+	for _, item := range strings.Split(s, ",") {
+		bits, err := f.parseItem(item)
+		if err != nil {
+			return 0, err
+		}
+		ret |= bits
+	}
+
+	return ret, nil
+}
+
+func (f field) parseItem(s string) (uint64, error) {
+	rangeStr := s
+	step := 1
+
+	if i := strings.Index(s, "/"); i != -1 {
+		rangeStr = s[:i]
+		var err error
+		step, err = strconv.Atoi(s[i+1:])
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step in %s field: %q", f.name, s)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangeStr == "*":
+		start = f.min
+		end = f.max
+	case strings.Contains(rangeStr, "-"):
+		parts := strings.SplitN(rangeStr, "-", 2)
+		var err error
+		if start, err = f.parseValue(parts[0]); err != nil {
+			return 0, err
+		}
+		if end, err = f.parseValue(parts[1]); err != nil {
+			return 0, err
+		}
+		if end < start {
+			return 0, fmt.Errorf("invalid range in %s field: %q", f.name, s)
+		}
+	default:
+		var err error
+		if start, err = f.parseValue(rangeStr); err != nil {
+			return 0, err
+		}
+
+		end = start
+		// a single value with a step, such as 5/15, runs until the maximum
+		if step > 1 {
+			end = f.max
+		}
+	}
+
+	var ret uint64
+	for i := start; i <= end; i += step {
+		ret |= 1 << uint(i)
+	}
+
+	return ret, nil
+}
+
+func (f field) parseValue(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value in %s field: %q", f.name, s)
+	}
+
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s value %d out of range [%d-%d]", f.name, v, f.min, f.max)
+	}
+
+	return v, nil
+}
+
+func hasBit(bits uint64, i int) bool {
+	return bits&(1<<uint(i)) != 0
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	domMatch := hasBit(s.dom, t.Day())
+	dowMatch := hasBit(s.dow, int(t.Weekday()))
+
+	// if both day fields are restricted, then either may match
+	if !s.domStar && !s.dowStar {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
+
+// Next returns the first time matching the schedule that is after t, in the
+// location of t. Returns the zero time if no matching time could be found.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// start from the next whole minute
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !hasBit(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !hasBit(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if !hasBit(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
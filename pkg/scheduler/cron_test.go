@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScheduleInvalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"* * * foo *",
+		"@often",
+	}
+
+	for _, spec := range invalid {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func parseTime(t *testing.T, s string) time.Time {
+	ret, err := time.ParseInLocation("2006-01-02 15:04", s, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ret
+}
+
+func TestScheduleNext(t *testing.T) {
+	const from = "2021-03-15 10:30" // a monday
+
+	tests := []struct {
+		spec     string
+		from     string
+		expected string
+	}{
+		{"* * * * *", from, "2021-03-15 10:31"},
+		{"*/15 * * * *", from, "2021-03-15 10:45"},
+		{"0 * * * *", from, "2021-03-15 11:00"},
+		{"@hourly", from, "2021-03-15 11:00"},
+		{"30 10 * * *", from, "2021-03-16 10:30"},
+		{"0 3 * * *", from, "2021-03-16 03:00"},
+		{"@daily", from, "2021-03-16 00:00"},
+		{"0 0 * * sun", from, "2021-03-21 00:00"},
+		{"0 0 * * 7", from, "2021-03-21 00:00"},
+		{"@weekly", from, "2021-03-21 00:00"},
+		{"0 0 * * mon-fri", from, "2021-03-16 00:00"},
+		{"0 0 1 * *", from, "2021-04-01 00:00"},
+		{"@monthly", from, "2021-04-01 00:00"},
+		{"0 0 1 jan *", from, "2022-01-01 00:00"},
+		{"0 12 1,20 * *", from, "2021-03-20 12:00"},
+		{"5/20 10 * * *", from, "2021-03-15 10:45"},
+		{"0 0-23/6 * * *", from, "2021-03-15 12:00"},
+		{"0 0 31 * *", from, "2021-03-31 00:00"},
+		{"0 0 29 2 *", from, "2024-02-29 00:00"},
+		// when both day fields are restricted, either may match
+		{"0 0 1 * fri", from, "2021-03-19 00:00"},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.spec)
+		if !assert.NoError(t, err, tt.spec) {
+			continue
+		}
+
+		got := s.Next(parseTime(t, tt.from))
+		assert.Equal(t, parseTime(t, tt.expected), got, tt.spec)
+	}
+}
+
+func TestScheduleNextNever(t *testing.T) {
+	s, err := ParseSchedule("0 0 30 2 *")
+	if assert.NoError(t, err) {
+		assert.True(t, s.Next(parseTime(t, "2021-03-15 10:30")).IsZero())
+	}
+}
@@ -0,0 +1,214 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+type entry struct {
+	schedule *Schedule
+	task     func()
+	lastRun  *time.Time
+	nextRun  time.Time
+}
+
+// Scheduler executes tasks according to their schedules. Tasks are
+// identified by an integer ID. Scheduler is safe for concurrent use.
+type Scheduler struct {
+	mutex   sync.Mutex
+	entries map[int]*entry
+	wake    chan struct{}
+	now     func() time.Time
+	onRun   func(id int, lastRun time.Time)
+}
+
+// New returns a new Scheduler. The Scheduler does not execute any tasks
+// until Start is called.
+func New() *Scheduler {
+	return &Scheduler{
+		entries: make(map[int]*entry),
+		wake:    make(chan struct{}, 1),
+		now:     time.Now,
+	}
+}
+
+// Set adds or replaces the task with the provided ID. The last run time of
+// an existing task is retained.
+func (s *Scheduler) Set(id int, schedule *Schedule, task func()) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e := &entry{
+		schedule: schedule,
+		task:     task,
+		nextRun:  schedule.Next(s.now()),
+	}
+
+	if existing := s.entries[id]; existing != nil {
+		e.lastRun = existing.lastRun
+	}
+
+	s.entries[id] = e
+	s.notify()
+}
+
+// SetLastRun sets the last run time of the task with the provided ID. It is
+// used to restore the last run times of tasks when the server is restarted.
+// Does nothing if the task does not exist.
+func (s *Scheduler) SetLastRun(id int, lastRun time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if e := s.entries[id]; e != nil {
+		e.lastRun = &lastRun
+	}
+}
+
+// OnRun sets a function that is called with the ID and run time of each task
+// before it is executed. It may be used to persist the last run times.
+func (s *Scheduler) OnRun(fn func(id int, lastRun time.Time)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.onRun = fn
+}
+
+// Remove removes the task with the provided ID. Does nothing if the task
+// does not exist.
+func (s *Scheduler) Remove(id int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.entries, id)
+	s.notify()
+}
+
+// Clear removes all tasks from the scheduler.
+func (s *Scheduler) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.entries = make(map[int]*entry)
+	s.notify()
+}
+
+// IDs returns the IDs of the scheduled tasks.
+func (s *Scheduler) IDs() []int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret []int
+	for id := range s.entries {
+		ret = append(ret, id)
+	}
+
+	return ret
+}
+
+// LastRun returns the time that the task with the provided ID was last
+// executed. Returns nil if the task has not been executed.
+func (s *Scheduler) LastRun(id int) *time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if e := s.entries[id]; e != nil && e.lastRun != nil {
+		ret := *e.lastRun
+		return &ret
+	}
+
+	return nil
+}
+
+// NextRun returns the time that the task with the provided ID will next be
+// executed. Returns nil if the task is not scheduled.
+func (s *Scheduler) NextRun(id int) *time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if e := s.entries[id]; e != nil && !e.nextRun.IsZero() {
+		ret := e.nextRun
+		return &ret
+	}
+
+	return nil
+}
+
+// notify wakes the scheduler loop so that the next run time is
+// recalculated. Must be called with the mutex held.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start starts executing scheduled tasks in a new goroutine. Tasks are
+// executed in their own goroutines.
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+func (s *Scheduler) run() {
+	for {
+		next := s.nextWake()
+		if next.IsZero() {
+			// nothing scheduled, wait until tasks are changed
+			<-s.wake
+			continue
+		}
+
+		timer := time.NewTimer(next.Sub(s.now()))
+
+		select {
+		case <-timer.C:
+			s.runDue(s.now())
+		case <-s.wake:
+		}
+
+		timer.Stop()
+	}
+}
+
+// nextWake returns the earliest next run time of all tasks, or the zero time
+// if there are no scheduled tasks.
+func (s *Scheduler) nextWake() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var ret time.Time
+	for _, e := range s.entries {
+		if e.nextRun.IsZero() {
+			continue
+		}
+
+		if ret.IsZero() || e.nextRun.Before(ret) {
+			ret = e.nextRun
+		}
+	}
+
+	return ret
+}
+
+// runDue executes all tasks with a next run time at or before now, and
+// calculates their next run times.
+func (s *Scheduler) runDue(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id, e := range s.entries {
+		if e.nextRun.IsZero() || e.nextRun.After(now) {
+			continue
+		}
+
+		lastRun := now
+		e.lastRun = &lastRun
+		e.nextRun = e.schedule.Next(now)
+
+		go func(id int, task func(), onRun func(int, time.Time)) {
+			if onRun != nil {
+				onRun(id, now)
+			}
+			task()
+		}(id, e.task, s.onRun)
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerRunDue(t *testing.T) {
+	now := parseTime(t, "2021-03-15 10:30")
+
+	s := New()
+	s.now = func() time.Time { return now }
+
+	hourly, _ := ParseSchedule("@hourly")
+	daily, _ := ParseSchedule("@daily")
+
+	ran := make(chan int, 2)
+	s.Set(1, hourly, func() { ran <- 1 })
+	s.Set(2, daily, func() { ran <- 2 })
+
+	assert.ElementsMatch(t, []int{1, 2}, s.IDs())
+	assert.Nil(t, s.LastRun(1))
+	assert.Equal(t, parseTime(t, "2021-03-15 11:00"), *s.NextRun(1))
+	assert.Equal(t, parseTime(t, "2021-03-16 00:00"), *s.NextRun(2))
+	assert.Equal(t, parseTime(t, "2021-03-15 11:00"), s.nextWake())
+
+	// only the hourly task is due
+	now = parseTime(t, "2021-03-15 11:00")
+	s.runDue(now)
+
+	select {
+	case id := <-ran:
+		assert.Equal(t, 1, id)
+	case <-time.After(5 * time.Second):
+		t.Error("timed out waiting for task")
+	}
+
+	assert.Equal(t, now, *s.LastRun(1))
+	assert.Equal(t, parseTime(t, "2021-03-15 12:00"), *s.NextRun(1))
+	assert.Nil(t, s.LastRun(2))
+
+	// replacing the task keeps the last run time
+	s.Set(1, daily, func() {})
+	assert.Equal(t, now, *s.LastRun(1))
+	assert.Equal(t, parseTime(t, "2021-03-16 00:00"), *s.NextRun(1))
+
+	s.Remove(1)
+	assert.Nil(t, s.NextRun(1))
+	assert.Nil(t, s.LastRun(1))
+
+	s.Clear()
+	assert.True(t, s.nextWake().IsZero())
+}
+
+func TestSchedulerPersistLastRun(t *testing.T) {
+	now := parseTime(t, "2021-03-15 11:00")
+
+	s := New()
+	s.now = func() time.Time { return now }
+
+	hourly, _ := ParseSchedule("@hourly")
+
+	// restored last run times are only set for existing tasks
+	lastRun := parseTime(t, "2021-03-15 10:00")
+	s.SetLastRun(1, lastRun)
+	assert.Nil(t, s.LastRun(1))
+
+	s.Set(1, hourly, func() {})
+	s.SetLastRun(1, lastRun)
+	assert.Equal(t, lastRun, *s.LastRun(1))
+
+	type run struct {
+		id      int
+		lastRun time.Time
+	}
+	ran := make(chan run, 1)
+	s.OnRun(func(id int, lastRun time.Time) {
+		ran <- run{id, lastRun}
+	})
+
+	s.runDue(parseTime(t, "2021-03-15 12:00"))
+
+	select {
+	case r := <-ran:
+		assert.Equal(t, 1, r.id)
+		assert.Equal(t, parseTime(t, "2021-03-15 12:00"), r.lastRun)
+	case <-time.After(5 * time.Second):
+		t.Error("timed out waiting for task")
+	}
+}
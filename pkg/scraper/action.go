@@ -37,12 +37,18 @@ type scraper interface {
 	scrapePerformerByFragment(scrapedPerformer models.ScrapedPerformerInput) (*models.ScrapedPerformer, error)
 	scrapePerformerByURL(url string) (*models.ScrapedPerformer, error)
 
+	scrapeScenesByName(name string) ([]*models.ScrapedScene, error)
+	scrapeSceneByScene(scene models.ScrapedSceneInput) (*models.ScrapedScene, error)
 	scrapeSceneByFragment(scene models.SceneUpdateInput) (*models.ScrapedScene, error)
 	scrapeSceneByURL(url string) (*models.ScrapedScene, error)
 
+	scrapeGalleriesByName(name string) ([]*models.ScrapedGallery, error)
+	scrapeGalleryByGallery(gallery models.ScrapedGalleryInput) (*models.ScrapedGallery, error)
 	scrapeGalleryByFragment(scene models.GalleryUpdateInput) (*models.ScrapedGallery, error)
 	scrapeGalleryByURL(url string) (*models.ScrapedGallery, error)
 
+	scrapeMoviesByName(name string) ([]*models.ScrapedMovie, error)
+	scrapeMovieByFragment(movie models.ScrapedMovieInput) (*models.ScrapedMovie, error)
 	scrapeMovieByURL(url string) (*models.ScrapedMovie, error)
 }
 
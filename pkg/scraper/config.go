@@ -6,8 +6,11 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
 	"gopkg.in/yaml.v2"
 
 	"github.com/stashapp/stash/pkg/models"
@@ -29,9 +32,18 @@ type config struct {
 	// Configuration for querying a performer by a URL
 	PerformerByURL []*scrapeByURLConfig `yaml:"performerByURL"`
 
+	// Configuration for querying scenes by name
+	SceneByName *scraperTypeConfig `yaml:"sceneByName"`
+
+	// Configuration for querying a scene by a sceneByName result
+	SceneByQueryFragment *scraperTypeConfig `yaml:"sceneByQueryFragment"`
+
 	// Configuration for querying scenes by a Scene fragment
 	SceneByFragment *scraperTypeConfig `yaml:"sceneByFragment"`
 
+	// Configuration for querying galleries by name
+	GalleryByName *scraperTypeConfig `yaml:"galleryByName"`
+
 	// Configuration for querying gallery by a Gallery fragment
 	GalleryByFragment *scraperTypeConfig `yaml:"galleryByFragment"`
 
@@ -41,6 +53,12 @@ type config struct {
 	// Configuration for querying a gallery by a URL
 	GalleryByURL []*scrapeByURLConfig `yaml:"galleryByURL"`
 
+	// Configuration for querying movies by name
+	MovieByName *scraperTypeConfig `yaml:"movieByName"`
+
+	// Configuration for querying a movie by a Movie fragment
+	MovieByFragment *scraperTypeConfig `yaml:"movieByFragment"`
+
 	// Configuration for querying a movie by a URL
 	MovieByURL []*scrapeByURLConfig `yaml:"movieByURL"`
 
@@ -58,6 +76,20 @@ type config struct {
 
 	// Scraping driver options
 	DriverOptions *scraperDriverOptions `yaml:"driver"`
+
+	// Limits the rate of requests made when loading URLs
+	RateLimit *scraperRateLimit `yaml:"rateLimit"`
+
+	// Time in seconds that loaded URL responses are cached for. Responses
+	// are not cached if zero.
+	CacheTTL int `yaml:"cacheTTL"`
+
+	// Options for logging in to the site before scraping
+	Login *scraperLoginOptions `yaml:"login"`
+
+	// set by the scraper Cache
+	limiter *rateLimiter
+	session *scraperSession
 }
 
 func (c config) validate() error {
@@ -77,12 +109,42 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.SceneByName != nil {
+		if err := c.SceneByName.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.SceneByQueryFragment != nil {
+		if err := c.SceneByQueryFragment.validate(); err != nil {
+			return err
+		}
+	}
+
 	if c.SceneByFragment != nil {
 		if err := c.SceneByFragment.validate(); err != nil {
 			return err
 		}
 	}
 
+	if c.GalleryByName != nil {
+		if err := c.GalleryByName.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.MovieByName != nil {
+		if err := c.MovieByName.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.MovieByFragment != nil {
+		if err := c.MovieByFragment.validate(); err != nil {
+			return err
+		}
+	}
+
 	for _, s := range c.PerformerByURL {
 		if err := s.validate(); err != nil {
 			return err
@@ -101,9 +163,137 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.RateLimit != nil && (c.RateLimit.Requests <= 0 || c.RateLimit.Interval <= 0) {
+		return errors.New("rateLimit requests and interval must be greater than zero")
+	}
+
+	if c.CacheTTL < 0 {
+		return errors.New("cacheTTL must not be negative")
+	}
+
+	if c.Login != nil {
+		if err := c.Login.validate(); err != nil {
+			return err
+		}
+
+		if c.DriverOptions != nil && c.DriverOptions.UseCDP {
+			return errors.New("login is not supported when using CDP")
+		}
+	}
+
 	return nil
 }
 
+// check returns problems with the scraper configuration that do not prevent
+// it from being loaded, such as references to missing xpath or json
+// scrapers, and invalid selectors or regular expressions.
+func (c config) check() []error {
+	var ret []error
+
+	typeConfigs := []struct {
+		name   string
+		config *scraperTypeConfig
+		byName bool
+	}{
+		{"performerByName", c.PerformerByName, true},
+		{"performerByFragment", c.PerformerByFragment, false},
+		{"sceneByName", c.SceneByName, true},
+		{"sceneByQueryFragment", c.SceneByQueryFragment, false},
+		{"sceneByFragment", c.SceneByFragment, false},
+		{"galleryByName", c.GalleryByName, true},
+		{"galleryByFragment", c.GalleryByFragment, false},
+		{"movieByName", c.MovieByName, true},
+		{"movieByFragment", c.MovieByFragment, false},
+	}
+
+	for _, t := range typeConfigs {
+		if t.config != nil {
+			ret = append(ret, c.checkTypeConfig(t.name, *t.config, t.byName)...)
+		}
+	}
+
+	byURL := []struct {
+		name    string
+		configs []*scrapeByURLConfig
+	}{
+		{"performerByURL", c.PerformerByURL},
+		{"sceneByURL", c.SceneByURL},
+		{"galleryByURL", c.GalleryByURL},
+		{"movieByURL", c.MovieByURL},
+	}
+
+	for _, t := range byURL {
+		for i, s := range t.configs {
+			ret = append(ret, c.checkTypeConfig(fmt.Sprintf("%s[%d]", t.name, i), s.scraperTypeConfig, false)...)
+		}
+	}
+
+	ret = append(ret, checkMappedScrapers("xPathScrapers", c.XPathScrapers, checkXPathSelector)...)
+	ret = append(ret, checkMappedScrapers("jsonScrapers", c.JsonScrapers, func(string) error {
+		return nil
+	})...)
+
+	return ret
+}
+
+func (c config) checkTypeConfig(name string, t scraperTypeConfig, byName bool) []error {
+	var ret []error
+	addError := func(format string, args ...interface{}) {
+		ret = append(ret, fmt.Errorf("%s: %s", name, fmt.Sprintf(format, args...)))
+	}
+
+	var scrapers mappedScrapers
+	switch t.Action {
+	case scraperActionXPath:
+		scrapers = c.XPathScrapers
+	case scraperActionJson:
+		scrapers = c.JsonScrapers
+	case scraperActionStash:
+		if c.StashServer == nil || c.StashServer.URL == "" {
+			addError("stashServer url is mandatory for stash scraper action")
+		}
+		return ret
+	default:
+		return ret
+	}
+
+	if t.Scraper == "" {
+		addError("scraper is mandatory for %s scraper action", t.Action)
+	} else if scrapers[t.Scraper] == nil {
+		addError("scraper %s not found", t.Scraper)
+	}
+
+	if byName && t.QueryURL == "" {
+		addError("queryURL is mandatory for %s scraper action", t.Action)
+	}
+
+	return ret
+}
+
+func checkMappedScrapers(name string, scrapers mappedScrapers, checkSelector func(string) error) []error {
+	keys := make([]string, 0, len(scrapers))
+	for k := range scrapers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ret []error
+	for _, k := range keys {
+		for _, err := range scrapers[k].validate(checkSelector) {
+			ret = append(ret, fmt.Errorf("%s.%s.%s", name, k, err.Error()))
+		}
+	}
+
+	return ret
+}
+
+// checkXPathSelector returns an error if the selector is not a valid xpath
+// expression.
+func checkXPathSelector(selector string) error {
+	_, err := htmlquery.QueryAll(&html.Node{Type: html.DocumentNode}, selector)
+	return err
+}
+
 type stashServer struct {
 	URL string `yaml:"url"`
 }
@@ -201,6 +391,13 @@ func loadScraperFromYAML(id string, reader io.Reader) (*config, error) {
 	return ret, nil
 }
 
+// scraperIDFromPath returns the scraper ID for the configuration file, which
+// is the filename without the extension.
+func scraperIDFromPath(path string) string {
+	id := filepath.Base(path)
+	return id[:strings.LastIndex(id, ".")]
+}
+
 func loadScraperFromYAMLFile(path string) (*config, error) {
 	file, err := os.Open(path)
 	defer file.Close()
@@ -208,11 +405,7 @@ func loadScraperFromYAMLFile(path string) (*config, error) {
 		return nil, err
 	}
 
-	// set id to the filename
-	id := filepath.Base(path)
-	id = id[:strings.LastIndex(id, ".")]
-
-	ret, err := loadScraperFromYAML(id, file)
+	ret, err := loadScraperFromYAML(scraperIDFromPath(path), file)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +440,9 @@ func (c config) toScraper() *models.Scraper {
 	}
 
 	scene := models.ScraperSpec{}
+	if c.SceneByName != nil && c.SceneByQueryFragment != nil {
+		scene.SupportedScrapes = append(scene.SupportedScrapes, models.ScrapeTypeName)
+	}
 	if c.SceneByFragment != nil {
 		scene.SupportedScrapes = append(scene.SupportedScrapes, models.ScrapeTypeFragment)
 	}
@@ -262,6 +458,9 @@ func (c config) toScraper() *models.Scraper {
 	}
 
 	gallery := models.ScraperSpec{}
+	if c.GalleryByName != nil && (c.GalleryByFragment != nil || len(c.GalleryByURL) > 0) {
+		gallery.SupportedScrapes = append(gallery.SupportedScrapes, models.ScrapeTypeName)
+	}
 	if c.GalleryByFragment != nil {
 		gallery.SupportedScrapes = append(gallery.SupportedScrapes, models.ScrapeTypeFragment)
 	}
@@ -277,6 +476,12 @@ func (c config) toScraper() *models.Scraper {
 	}
 
 	movie := models.ScraperSpec{}
+	if c.MovieByName != nil {
+		movie.SupportedScrapes = append(movie.SupportedScrapes, models.ScrapeTypeName)
+	}
+	if c.MovieByFragment != nil {
+		movie.SupportedScrapes = append(movie.SupportedScrapes, models.ScrapeTypeFragment)
+	}
 	if len(c.MovieByURL) > 0 {
 		movie.SupportedScrapes = append(movie.SupportedScrapes, models.ScrapeTypeURL)
 		for _, v := range c.MovieByURL {
@@ -347,10 +552,11 @@ func (c config) ScrapePerformerURL(url string, txnManager models.TransactionMana
 }
 
 func (c config) supportsScenes() bool {
-	return c.SceneByFragment != nil || len(c.SceneByURL) > 0
+	return (c.SceneByName != nil && c.SceneByQueryFragment != nil) || c.SceneByFragment != nil || len(c.SceneByURL) > 0
 }
 
 func (c config) supportsGalleries() bool {
+	// galleryByName results are refined using galleryByFragment or galleryByURL
 	return c.GalleryByFragment != nil || len(c.GalleryByURL) > 0
 }
 
@@ -374,7 +580,7 @@ func (c config) matchesGalleryURL(url string) bool {
 }
 
 func (c config) supportsMovies() bool {
-	return len(c.MovieByURL) > 0
+	return c.MovieByName != nil || c.MovieByFragment != nil || len(c.MovieByURL) > 0
 }
 
 func (c config) matchesMovieURL(url string) bool {
@@ -387,6 +593,24 @@ func (c config) matchesMovieURL(url string) bool {
 	return false
 }
 
+func (c config) ScrapeSceneQuery(name string, txnManager models.TransactionManager, globalConfig GlobalConfig) ([]*models.ScrapedScene, error) {
+	if c.SceneByName != nil {
+		s := getScraper(*c.SceneByName, txnManager, c, globalConfig)
+		return s.scrapeScenesByName(name)
+	}
+
+	return nil, nil
+}
+
+func (c config) ScrapeSceneQueryFragment(scene models.ScrapedSceneInput, txnManager models.TransactionManager, globalConfig GlobalConfig) (*models.ScrapedScene, error) {
+	if c.SceneByQueryFragment != nil {
+		s := getScraper(*c.SceneByQueryFragment, txnManager, c, globalConfig)
+		return s.scrapeSceneByScene(scene)
+	}
+
+	return nil, nil
+}
+
 func (c config) ScrapeScene(scene models.SceneUpdateInput, txnManager models.TransactionManager, globalConfig GlobalConfig) (*models.ScrapedScene, error) {
 	if c.SceneByFragment != nil {
 		s := getScraper(*c.SceneByFragment, txnManager, c, globalConfig)
@@ -414,6 +638,31 @@ func (c config) ScrapeSceneURL(url string, txnManager models.TransactionManager,
 	return nil, nil
 }
 
+func (c config) ScrapeGalleryNames(name string, txnManager models.TransactionManager, globalConfig GlobalConfig) ([]*models.ScrapedGallery, error) {
+	if c.GalleryByName != nil {
+		s := getScraper(*c.GalleryByName, txnManager, c, globalConfig)
+		return s.scrapeGalleriesByName(name)
+	}
+
+	return nil, nil
+}
+
+// ScrapeGalleryQueryFragment scrapes the full gallery from a galleryByName
+// result. The result is scraped from its URL if it matches a galleryByURL
+// configuration, otherwise from the galleryByFragment configuration.
+func (c config) ScrapeGalleryQueryFragment(gallery models.ScrapedGalleryInput, txnManager models.TransactionManager, globalConfig GlobalConfig) (*models.ScrapedGallery, error) {
+	if gallery.URL != nil && c.matchesGalleryURL(*gallery.URL) {
+		return c.ScrapeGalleryURL(*gallery.URL, txnManager, globalConfig)
+	}
+
+	if c.GalleryByFragment != nil {
+		s := getScraper(*c.GalleryByFragment, txnManager, c, globalConfig)
+		return s.scrapeGalleryByGallery(gallery)
+	}
+
+	return nil, nil
+}
+
 func (c config) ScrapeGallery(gallery models.GalleryUpdateInput, txnManager models.TransactionManager, globalConfig GlobalConfig) (*models.ScrapedGallery, error) {
 	if c.GalleryByFragment != nil {
 		s := getScraper(*c.GalleryByFragment, txnManager, c, globalConfig)
@@ -441,6 +690,29 @@ func (c config) ScrapeGalleryURL(url string, txnManager models.TransactionManage
 	return nil, nil
 }
 
+func (c config) ScrapeMovieNames(name string, txnManager models.TransactionManager, globalConfig GlobalConfig) ([]*models.ScrapedMovie, error) {
+	if c.MovieByName != nil {
+		s := getScraper(*c.MovieByName, txnManager, c, globalConfig)
+		return s.scrapeMoviesByName(name)
+	}
+
+	return nil, nil
+}
+
+func (c config) ScrapeMovie(scrapedMovie models.ScrapedMovieInput, txnManager models.TransactionManager, globalConfig GlobalConfig) (*models.ScrapedMovie, error) {
+	if c.MovieByFragment != nil {
+		s := getScraper(*c.MovieByFragment, txnManager, c, globalConfig)
+		return s.scrapeMovieByFragment(scrapedMovie)
+	}
+
+	// try to match against URL if present
+	if scrapedMovie.URL != nil && *scrapedMovie.URL != "" {
+		return c.ScrapeMovieURL(*scrapedMovie.URL, txnManager, globalConfig)
+	}
+
+	return nil, nil
+}
+
 func (c config) ScrapeMovieURL(url string, txnManager models.TransactionManager, globalConfig GlobalConfig) (*models.ScrapedMovie, error) {
 	for _, scraper := range c.MovieByURL {
 		if scraper.matchesURL(url) {
@@ -3,8 +3,6 @@ package scraper
 import (
 	"errors"
 	"io/ioutil"
-	"net/url"
-	"strings"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -115,34 +113,90 @@ func (s *jsonScraper) scrapeMovieByURL(url string) (*models.ScrapedMovie, error)
 }
 
 func (s *jsonScraper) scrapePerformersByName(name string) ([]*models.ScrapedPerformer, error) {
-	scraper := s.getJsonScraper()
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
+	}
 
-	if scraper == nil {
-		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	q := s.getJsonQuery(doc)
+	return scraper.scrapePerformers(q)
+}
+
+func (s *jsonScraper) scrapeGalleriesByName(name string) ([]*models.ScrapedGallery, error) {
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
 	}
 
-	const placeholder = "{}"
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeGalleries(q)
+}
 
-	// replace the placeholder string with the URL-escaped name
-	escapedName := url.QueryEscape(name)
+func (s *jsonScraper) scrapeMoviesByName(name string) ([]*models.ScrapedMovie, error) {
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
+	}
 
-	url := s.scraper.QueryURL
-	url = strings.Replace(url, placeholder, escapedName, -1)
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeMovies(q)
+}
 
-	doc, err := s.loadURL(url)
+func (s *jsonScraper) scrapeMovieByFragment(movie models.ScrapedMovieInput) (*models.ScrapedMovie, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedMovie(movie)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
 
+	doc, scraper, err := s.scrapeURL(url)
 	if err != nil {
 		return nil, err
 	}
 
 	q := s.getJsonQuery(doc)
-	return scraper.scrapePerformers(q)
+	return scraper.scrapeMovie(q)
 }
 
 func (s *jsonScraper) scrapePerformerByFragment(scrapedPerformer models.ScrapedPerformerInput) (*models.ScrapedPerformer, error) {
 	return nil, errors.New("scrapePerformerByFragment not supported for json scraper")
 }
 
+func (s *jsonScraper) scrapeScenesByName(name string) ([]*models.ScrapedScene, error) {
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeScenes(q)
+}
+
+func (s *jsonScraper) scrapeSceneByScene(scene models.ScrapedSceneInput) (*models.ScrapedScene, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedScene(scene)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeScene(q)
+}
+
 func (s *jsonScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*models.ScrapedScene, error) {
 	storedScene, err := sceneFromUpdateFragment(scene, s.txnManager)
 	if err != nil {
@@ -176,6 +230,23 @@ func (s *jsonScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*mod
 	return scraper.scrapeScene(q)
 }
 
+func (s *jsonScraper) scrapeGalleryByGallery(gallery models.ScrapedGalleryInput) (*models.ScrapedGallery, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedGallery(gallery)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	doc, scraper, err := s.scrapeURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeGallery(q)
+}
+
 func (s *jsonScraper) scrapeGalleryByFragment(gallery models.GalleryUpdateInput) (*models.ScrapedGallery, error) {
 	storedGallery, err := galleryFromUpdateFragment(gallery, s.txnManager)
 	if err != nil {
@@ -221,12 +292,12 @@ type jsonQuery struct {
 	scraper *jsonScraper
 }
 
-func (q *jsonQuery) runQuery(selector string) []string {
+func (q *jsonQuery) runQuery(selector string) ([]string, error) {
 	value := gjson.Get(q.doc, selector)
 
 	if !value.Exists() {
 		logger.Warnf("Could not find json path '%s' in json object", selector)
-		return nil
+		return nil, nil
 	}
 
 	var ret []string
@@ -239,16 +310,14 @@ func (q *jsonQuery) runQuery(selector string) []string {
 		ret = append(ret, value.String())
 	}
 
-	return ret
+	return ret, nil
 }
 
-func (q *jsonQuery) subScrape(value string) mappedQuery {
+func (q *jsonQuery) subScrape(value string) (mappedQuery, error) {
 	doc, err := q.scraper.loadURL(value)
-
 	if err != nil {
-		logger.Warnf("Error getting URL '%s' for sub-scraper: %s", value, err.Error())
-		return nil
+		return nil, err
 	}
 
-	return q.scraper.getJsonQuery(doc)
+	return q.scraper.getJsonQuery(doc), nil
 }
@@ -1,8 +1,13 @@
 package scraper
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 )
 
@@ -91,3 +96,95 @@ jsonScrapers:
 	verifyField(t, "None", scrapedPerformer.Tattoos, "Tattoos")
 	verifyField(t, "Navel", scrapedPerformer.Piercings, "Piercings")
 }
+
+func TestJsonMovieQuery(t *testing.T) {
+	const searchJSON = `
+{
+	"results": [
+		{ "id": "1", "title": "First movie" },
+		{ "id": "2", "title": "Second movie" }
+	]
+}
+`
+
+	const movieJSON = `
+{
+	"title": "Second movie",
+	"director": "The director",
+	"studio": { "name": "The studio" }
+}
+`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/movie/2" {
+			fmt.Fprint(w, movieJSON)
+		} else {
+			fmt.Fprint(w, searchJSON)
+		}
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+movieByName:
+  action: scrapeJson
+  queryURL: ` + ts.URL + `/search?q={}
+  scraper: movieSearch
+movieByFragment:
+  action: scrapeJson
+  queryURL: "{url}"
+  scraper: movieScraper
+jsonScrapers:
+  movieSearch:
+    movie:
+      Name: results.#.title
+      URL:
+        selector: results.#.id
+        postProcess:
+          - replace:
+              - regex: ^
+                with: ` + ts.URL + `/movie/
+  movieScraper:
+    movie:
+      Name: title
+      Director: director
+      Studio:
+        Name: studio.name
+`
+
+	c := &config{}
+	err := yaml.Unmarshal([]byte(yamlStr), &c)
+
+	if err != nil {
+		t.Fatalf("Error loading yaml: %s", err.Error())
+	}
+
+	assert.ElementsMatch(t, []models.ScrapeType{models.ScrapeTypeName, models.ScrapeTypeFragment}, c.toScraper().Movie.SupportedScrapes)
+
+	globalConfig := GlobalConfig{}
+
+	movies, err := c.ScrapeMovieNames("movie", nil, globalConfig)
+	if err != nil {
+		t.Fatalf("Error querying movies: %s", err.Error())
+	}
+
+	if !assert.Len(t, movies, 2) {
+		return
+	}
+
+	verifyField(t, "First movie", movies[0].Name, "Name")
+	verifyField(t, ts.URL+"/movie/2", movies[1].URL, "URL")
+
+	movie, err := c.ScrapeMovie(models.ScrapedMovieInput{
+		Name: movies[1].Name,
+		URL:  movies[1].URL,
+	}, nil, globalConfig)
+	if err != nil {
+		t.Fatalf("Error scraping movie: %s", err.Error())
+	}
+
+	verifyField(t, "Second movie", movie.Name, "Name")
+	verifyField(t, "The director", movie.Director, "Director")
+	if assert.NotNil(t, movie.Studio) {
+		assert.Equal(t, "The studio", movie.Studio.Name)
+	}
+}
@@ -0,0 +1,245 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html/charset"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// the default selector for the login form: the first form with a password
+// field
+const defaultLoginFormSelector = "//form[.//input[@type='password']]"
+
+type scraperLoginOptions struct {
+	// URL of the page containing the login form
+	URL string `yaml:"url"`
+	// XPath selector of the login form. Defaults to the first form with a
+	// password field.
+	Form string `yaml:"form"`
+	// Names of the username and password form fields
+	UsernameField string `yaml:"usernameField"`
+	PasswordField string `yaml:"passwordField"`
+	// Name of the secret in the scraper_secrets section of the main
+	// configuration that holds the username and password
+	Secret string `yaml:"secret"`
+	// Requests redirected to a URL containing this string are treated as
+	// redirects to the login page. Defaults to the login form URL.
+	RedirectURL string `yaml:"redirectURL"`
+}
+
+func (o scraperLoginOptions) validate() error {
+	if o.URL == "" {
+		return errors.New("login url is mandatory")
+	}
+
+	if o.Secret == "" {
+		return errors.New("login secret is mandatory")
+	}
+
+	return nil
+}
+
+func (o scraperLoginOptions) usernameField() string {
+	if o.UsernameField == "" {
+		return "username"
+	}
+	return o.UsernameField
+}
+
+func (o scraperLoginOptions) passwordField() string {
+	if o.PasswordField == "" {
+		return "password"
+	}
+	return o.PasswordField
+}
+
+// isLoginURL returns true if u is the login page.
+func (o scraperLoginOptions) isLoginURL(u *url.URL) bool {
+	if o.RedirectURL != "" {
+		return strings.Contains(u.String(), o.RedirectURL)
+	}
+
+	loginURL, err := url.Parse(o.URL)
+	if err != nil {
+		return false
+	}
+
+	return u.Host == loginURL.Host && u.Path == loginURL.Path
+}
+
+// scraperSession holds the cookie jar of a scraper that logs in, so that
+// the login is kept between requests.
+type scraperSession struct {
+	scraperID string
+	options   scraperLoginOptions
+	jar       *cookiejar.Jar
+
+	mutex    sync.Mutex
+	loggedIn bool
+}
+
+func newScraperSession(scraperID string, options scraperLoginOptions) (*scraperSession, error) {
+	jar, err := newCookieJar()
+	if err != nil {
+		return nil, err
+	}
+
+	return &scraperSession{
+		scraperID: scraperID,
+		options:   options,
+		jar:       jar,
+	}, nil
+}
+
+// get loads the url, logging in first if required. If the request is
+// redirected to the login page, then it logs in again and retries the
+// request.
+func (s *scraperSession) get(client *http.Client, u string, globalConfig GlobalConfig) (*http.Response, error) {
+	if err := s.ensureLoggedIn(client, globalConfig); err != nil {
+		return nil, err
+	}
+
+	resp, err := getURL(client, u, globalConfig.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.options.isLoginURL(resp.Request.URL) {
+		return resp, nil
+	}
+
+	resp.Body.Close()
+	logger.Infof("Scraper %s was redirected to the login page, logging in again", s.scraperID)
+
+	s.mutex.Lock()
+	s.loggedIn = false
+	s.mutex.Unlock()
+
+	if err := s.ensureLoggedIn(client, globalConfig); err != nil {
+		return nil, err
+	}
+
+	resp, err = getURL(client, u, globalConfig.UserAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.options.isLoginURL(resp.Request.URL) {
+		resp.Body.Close()
+		return nil, fmt.Errorf("scraper %s login failed: redirected to login page", s.scraperID)
+	}
+
+	return resp, nil
+}
+
+func (s *scraperSession) ensureLoggedIn(client *http.Client, globalConfig GlobalConfig) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.loggedIn {
+		return nil
+	}
+
+	if err := s.login(client, globalConfig); err != nil {
+		return fmt.Errorf("scraper %s login failed: %s", s.scraperID, err.Error())
+	}
+
+	s.loggedIn = true
+	return nil
+}
+
+// login loads the login form and submits it with the credentials from the
+// scraper secret. Other fields of the form, such as hidden tokens, are
+// submitted with their existing values.
+func (s *scraperSession) login(client *http.Client, globalConfig GlobalConfig) error {
+	secret, found := globalConfig.Secrets[s.options.Secret]
+	if !found {
+		return fmt.Errorf("scraper secret %s not found", s.options.Secret)
+	}
+
+	logger.Debugf("Logging in scraper %s", s.scraperID)
+
+	resp, err := getURL(client, s.options.URL, globalConfig.UserAgent)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	r, err := charset.NewReader(resp.Body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return err
+	}
+
+	doc, err := htmlquery.Parse(r)
+	if err != nil {
+		return err
+	}
+
+	formSelector := s.options.Form
+	if formSelector == "" {
+		formSelector = defaultLoginFormSelector
+	}
+
+	form, err := htmlquery.Query(doc, formSelector)
+	if err != nil {
+		return err
+	}
+	if form == nil {
+		return errors.New("login form not found")
+	}
+
+	values := url.Values{}
+	inputs, err := htmlquery.QueryAll(form, ".//input[@name]")
+	if err != nil {
+		return err
+	}
+
+	for _, input := range inputs {
+		inputType := strings.ToLower(htmlquery.SelectAttr(input, "type"))
+		if (inputType == "checkbox" || inputType == "radio") && htmlquery.FindOne(input, "self::*[@checked]") == nil {
+			continue
+		}
+
+		values.Set(htmlquery.SelectAttr(input, "name"), htmlquery.SelectAttr(input, "value"))
+	}
+
+	values.Set(s.options.usernameField(), secret.Username)
+	values.Set(s.options.passwordField(), secret.Password)
+
+	// the form is posted to the page URL if the action is empty
+	action, err := resp.Request.URL.Parse(htmlquery.SelectAttr(form, "action"))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, action.String(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if globalConfig.UserAgent != "" {
+		req.Header.Set("User-Agent", globalConfig.UserAgent)
+	}
+
+	loginResp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	loginResp.Body.Close()
+
+	if loginResp.StatusCode >= 400 {
+		return fmt.Errorf("http error %d", loginResp.StatusCode)
+	}
+
+	return nil
+}
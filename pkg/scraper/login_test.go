@@ -0,0 +1,120 @@
+package scraper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	stashConfig "github.com/stashapp/stash/pkg/manager/config"
+)
+
+const loginForm = `<html><body>
+<form action="/login" method="post">
+<input type="hidden" name="token" value="csrf"/>
+<input type="text" name="user"/>
+<input type="password" name="pass"/>
+<input type="checkbox" name="remember"/>
+</form>
+</body></html>`
+
+func TestLoadURLLogin(t *testing.T) {
+	const (
+		username = "user"
+		password = "pass"
+	)
+
+	logins := 0
+	session := ""
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, loginForm)
+			return
+		}
+
+		assert.Equal(t, "csrf", r.FormValue("token"))
+		assert.Equal(t, "", r.FormValue("remember"))
+
+		if r.FormValue("user") != username || r.FormValue("pass") != password {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+
+		logins++
+		session = fmt.Sprintf("session%d", logins)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: session, Path: "/"})
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		if err != nil || cookie.Value != session {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+
+		fmt.Fprint(w, "content")
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	login := scraperLoginOptions{
+		URL:           ts.URL + "/login",
+		UsernameField: "user",
+		PasswordField: "pass",
+		Secret:        "test",
+	}
+
+	s, err := newScraperSession("test", login)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := config{
+		ID:      "test",
+		Login:   &login,
+		session: s,
+	}
+
+	globalConfig := GlobalConfig{
+		Secrets: map[string]stashConfig.ScraperSecret{
+			"test": {Username: username, Password: password},
+		},
+	}
+
+	load := func() (string, error) {
+		r, err := loadURL(ts.URL+"/page", c, globalConfig)
+		if err != nil {
+			return "", err
+		}
+
+		data, _ := ioutil.ReadAll(r)
+		return string(data), nil
+	}
+
+	// logs in once and keeps the session between requests
+	for i := 0; i < 2; i++ {
+		content, err := load()
+		assert.Nil(t, err)
+		assert.Equal(t, "content", content)
+	}
+	assert.Equal(t, 1, logins)
+
+	// logs in again when the session expires
+	session = "expired"
+	content, err := load()
+	assert.Nil(t, err)
+	assert.Equal(t, "content", content)
+	assert.Equal(t, 2, logins)
+
+	// returns an error if the login fails
+	globalConfig.Secrets["test"] = stashConfig.ScraperSecret{Username: username, Password: "wrong"}
+	session = "expired"
+	_, err = load()
+	assert.NotNil(t, err)
+}
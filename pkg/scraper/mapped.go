@@ -6,6 +6,7 @@ import (
 	"math"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,8 +17,8 @@ import (
 )
 
 type mappedQuery interface {
-	runQuery(selector string) []string
-	subScrape(value string) mappedQuery
+	runQuery(selector string) ([]string, error)
+	subScrape(value string) (mappedQuery, error)
 }
 
 type commonMappedConfig map[string]string
@@ -40,22 +41,44 @@ func (s mappedConfig) applyCommon(c commonMappedConfig, src string) string {
 }
 
 func (s mappedConfig) process(q mappedQuery, common commonMappedConfig) mappedResults {
+	return s.processTrace(q, common, nil, "")
+}
+
+// processTrace processes the attributes in key order. If t is not nil, the
+// intermediate values of each attribute are recorded in t, with the
+// attribute names prefixed by section.
+func (s mappedConfig) processTrace(q mappedQuery, common commonMappedConfig, t *mappedTrace, section string) mappedResults {
 	var ret mappedResults
 
-	for k, attrConfig := range s {
+	keys := make([]string, 0, len(s))
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		attrConfig := s[k]
+		at := t.addAttribute(section, k, attrConfig)
 
 		if attrConfig.Fixed != "" {
 			// TODO - not sure if this needs to set _all_ indexes for the key
 			const i = 0
 			ret = ret.setKey(i, k, attrConfig.Fixed)
+			at.setResults([]string{attrConfig.Fixed})
 		} else {
 			selector := attrConfig.Selector
 			selector = s.applyCommon(common, selector)
 
-			found := q.runQuery(selector)
+			found, err := q.runQuery(selector)
+			if err != nil {
+				logger.Warnf("Error running selector '%s': %s", selector, err.Error())
+				at.addError(err)
+			}
+			at.setMatches(selector, found)
 
 			if len(found) > 0 {
-				result := s.postProcess(q, attrConfig, found)
+				result := s.postProcess(q, attrConfig, found, at)
+				at.setResults(result)
 				for i, text := range result {
 					ret = ret.setKey(i, k, text)
 				}
@@ -66,12 +89,12 @@ func (s mappedConfig) process(q mappedQuery, common commonMappedConfig) mappedRe
 	return ret
 }
 
-func (s mappedConfig) postProcess(q mappedQuery, attrConfig mappedScraperAttrConfig, found []string) []string {
+func (s mappedConfig) postProcess(q mappedQuery, attrConfig mappedScraperAttrConfig, found []string, at *attributeTrace) []string {
 	// check if we're concatenating the results into a single result
 	var ret []string
 	if attrConfig.hasConcat() {
 		result := attrConfig.concatenateResults(found)
-		result = attrConfig.postProcess(result, q)
+		result = attrConfig.postProcess(result, q, at)
 		if attrConfig.hasSplit() {
 			return attrConfig.splitString(result)
 		}
@@ -79,7 +102,7 @@ func (s mappedConfig) postProcess(q mappedQuery, attrConfig mappedScraperAttrCon
 		ret = []string{result}
 	} else {
 		for _, text := range found {
-			text = attrConfig.postProcess(text, q)
+			text = attrConfig.postProcess(text, q, at)
 			if attrConfig.hasSplit() {
 				return attrConfig.splitString(text)
 			}
@@ -320,12 +343,11 @@ type mappedRegexConfig struct {
 
 type mappedRegexConfigs []mappedRegexConfig
 
-func (c mappedRegexConfig) apply(value string) string {
+func (c mappedRegexConfig) apply(value string) (string, error) {
 	if c.Regex != "" {
 		re, err := regexp.Compile(c.Regex)
 		if err != nil {
-			logger.Warnf("Error compiling regex '%s': %s", c.Regex, err.Error())
-			return value
+			return value, fmt.Errorf("error compiling regex '%s': %s", c.Regex, err.Error())
 		}
 
 		ret := re.ReplaceAllString(value, c.With)
@@ -338,28 +360,37 @@ func (c mappedRegexConfig) apply(value string) string {
 		logger.Debugf(`Replace: '%s' with '%s'`, c.Regex, c.With)
 		logger.Debugf("Before: %s", value)
 		logger.Debugf("After: %s", ret)
-		return ret
+		return ret, nil
 	}
 
-	return value
+	return value, nil
 }
 
-func (c mappedRegexConfigs) apply(value string) string {
-	// apply regex in order
+func (c mappedRegexConfigs) apply(value string) (string, error) {
+	// apply regex in order. Regexes that fail to compile are skipped, and the
+	// first error is returned.
+	var firstErr error
 	for _, config := range c {
-		value = config.apply(value)
+		var err error
+		value, err = config.apply(value)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
 
-	return value
+	return value, firstErr
 }
 
+// postProcessAction is a post-processing step applied to scraped values.
+// Apply returns the processed value. If an error occurs, then the error is
+// returned along with the value to use in place of the processed value.
 type postProcessAction interface {
-	Apply(value string, q mappedQuery) string
+	Apply(value string, q mappedQuery) (string, error)
 }
 
 type postProcessParseDate string
 
-func (p *postProcessParseDate) Apply(value string, q mappedQuery) string {
+func (p *postProcessParseDate) Apply(value string, q mappedQuery) (string, error) {
 	parseDate := string(*p)
 
 	const internalDateFormat = "2006-01-02"
@@ -370,77 +401,88 @@ func (p *postProcessParseDate) Apply(value string, q mappedQuery) string {
 		if value == "yesterday" { // subtract 1 day from now
 			dt = dt.AddDate(0, 0, -1)
 		}
-		return dt.Format(internalDateFormat)
+		return dt.Format(internalDateFormat), nil
 	}
 
 	if parseDate == "" {
-		return value
+		return value, nil
 	}
 
 	// try to parse the date using the pattern
 	// if it fails, then just fall back to the original value
 	parsedValue, err := time.Parse(parseDate, value)
 	if err != nil {
-		logger.Warnf("Error parsing date string '%s' using format '%s': %s", value, parseDate, err.Error())
-		return value
+		return value, fmt.Errorf("error parsing date string '%s' using format '%s': %s", value, parseDate, err.Error())
 	}
 
 	// convert it into our date format
-	return parsedValue.Format(internalDateFormat)
+	return parsedValue.Format(internalDateFormat), nil
 }
 
 type postProcessReplace mappedRegexConfigs
 
-func (c *postProcessReplace) Apply(value string, q mappedQuery) string {
+func (c *postProcessReplace) Apply(value string, q mappedQuery) (string, error) {
 	replace := mappedRegexConfigs(*c)
 	return replace.apply(value)
 }
 
 type postProcessSubScraper mappedScraperAttrConfig
 
-func (p *postProcessSubScraper) Apply(value string, q mappedQuery) string {
+func (p *postProcessSubScraper) Apply(value string, q mappedQuery) (string, error) {
+	return p.applyTrace(value, q, nil)
+}
+
+// applyTrace sub-scrapes the value. If at is not nil, the intermediate
+// values of the sub-scraper are recorded in at.
+func (p *postProcessSubScraper) applyTrace(value string, q mappedQuery, at *attributeTrace) (string, error) {
 	subScrapeConfig := mappedScraperAttrConfig(*p)
 
 	logger.Debugf("Sub-scraping for: %s", value)
-	ss := q.subScrape(value)
-
-	if ss != nil {
-		found := ss.runQuery(subScrapeConfig.Selector)
+	ss, err := q.subScrape(value)
+	if err != nil {
+		return "", fmt.Errorf("error getting URL '%s' for sub-scraper: %s", value, err.Error())
+	}
 
-		if len(found) > 0 {
-			// check if we're concatenating the results into a single result
-			var result string
-			if subScrapeConfig.hasConcat() {
-				result = subScrapeConfig.concatenateResults(found)
-			} else {
-				result = found[0]
-			}
+	found, err := ss.runQuery(subScrapeConfig.Selector)
+	if err != nil {
+		return "", fmt.Errorf("error running sub-scraper selector '%s': %s", subScrapeConfig.Selector, err.Error())
+	}
+	at.setMatches(subScrapeConfig.Selector, found)
 
-			result = subScrapeConfig.postProcess(result, ss)
-			return result
+	if len(found) > 0 {
+		// check if we're concatenating the results into a single result
+		var result string
+		if subScrapeConfig.hasConcat() {
+			result = subScrapeConfig.concatenateResults(found)
+		} else {
+			result = found[0]
 		}
+
+		result = subScrapeConfig.postProcess(result, ss, at)
+		at.addResult(result)
+		return result, nil
 	}
 
-	return ""
+	return "", nil
 }
 
 type postProcessMap map[string]string
 
-func (p *postProcessMap) Apply(value string, q mappedQuery) string {
+func (p *postProcessMap) Apply(value string, q mappedQuery) (string, error) {
 	// return the mapped value if present
 	m := *p
 	mapped, ok := m[value]
 
 	if ok {
-		return mapped
+		return mapped, nil
 	}
 
-	return value
+	return value, nil
 }
 
 type postProcessFeetToCm bool
 
-func (p *postProcessFeetToCm) Apply(value string, q mappedQuery) string {
+func (p *postProcessFeetToCm) Apply(value string, q mappedQuery) (string, error) {
 	const foot_in_cm = 30.48
 	const inch_in_cm = 2.54
 
@@ -459,7 +501,7 @@ func (p *postProcessFeetToCm) Apply(value string, q mappedQuery) string {
 	var centimeters = feet*foot_in_cm + inches*inch_in_cm
 
 	// Return rounded integer string
-	return strconv.Itoa(int(math.Round(centimeters)))
+	return strconv.Itoa(int(math.Round(centimeters))), nil
 }
 
 type mappedPostProcessAction struct {
@@ -599,6 +641,29 @@ func (c *mappedScraperAttrConfig) convertPostProcessActions() error {
 	return nil
 }
 
+func (c mappedScraperAttrConfig) validate(selector string, checkSelector func(selector string) error) []error {
+	var ret []error
+	if err := checkSelector(selector); err != nil {
+		ret = append(ret, fmt.Errorf("invalid selector '%s': %s", selector, err.Error()))
+	}
+
+	for _, action := range c.postProcessActions {
+		switch a := action.(type) {
+		case *postProcessReplace:
+			for _, r := range *a {
+				if _, err := regexp.Compile(r.Regex); err != nil {
+					ret = append(ret, fmt.Errorf("invalid regex '%s': %s", r.Regex, err.Error()))
+				}
+			}
+		case *postProcessSubScraper:
+			sub := mappedScraperAttrConfig(*a)
+			ret = append(ret, sub.validate(sub.Selector, checkSelector)...)
+		}
+	}
+
+	return ret
+}
+
 func (c mappedScraperAttrConfig) hasConcat() bool {
 	return c.Concat != ""
 }
@@ -635,9 +700,22 @@ func (c mappedScraperAttrConfig) splitString(value string) []string {
 	return res
 }
 
-func (c mappedScraperAttrConfig) postProcess(value string, q mappedQuery) string {
-	for _, action := range c.postProcessActions {
-		value = action.Apply(value, q)
+// postProcess applies the post-process actions to the value in order. If
+// at is not nil, the value after each action is recorded in at.
+func (c mappedScraperAttrConfig) postProcess(value string, q mappedQuery, at *attributeTrace) string {
+	for i, action := range c.postProcessActions {
+		var err error
+		if subScraper, ok := action.(*postProcessSubScraper); ok {
+			value, err = subScraper.applyTrace(value, q, at.subScraper(mappedScraperAttrConfig(*subScraper)))
+		} else {
+			value, err = action.Apply(value, q)
+		}
+		if err != nil {
+			logger.Warnf("Error post-processing value: %s", err.Error())
+			at.addError(err)
+		}
+
+		at.addStep(i, action, value)
 	}
 
 	return value
@@ -653,6 +731,83 @@ type mappedScraper struct {
 	Movie     *mappedMovieScraperConfig     `yaml:"movie"`
 }
 
+type mappedSection struct {
+	name   string
+	config mappedConfig
+}
+
+// sections returns the attribute configurations for the content type, in
+// the order that they are processed.
+func (s mappedScraper) sections(contentType models.ScrapeContentType) []mappedSection {
+	switch contentType {
+	case models.ScrapeContentTypePerformer:
+		if c := s.Performer; c != nil {
+			return []mappedSection{
+				{"performer", c.mappedConfig},
+				{"performer.Tags", c.Tags},
+			}
+		}
+	case models.ScrapeContentTypeScene:
+		if c := s.Scene; c != nil {
+			return []mappedSection{
+				{"scene", c.mappedConfig},
+				{"scene.Performers", c.Performers.mappedConfig},
+				{"scene.Performers.Tags", c.Performers.Tags},
+				{"scene.Tags", c.Tags},
+				{"scene.Studio", c.Studio},
+				{"scene.Movies", c.Movies},
+			}
+		}
+	case models.ScrapeContentTypeGallery:
+		if c := s.Gallery; c != nil {
+			return []mappedSection{
+				{"gallery", c.mappedConfig},
+				{"gallery.Performers", c.Performers},
+				{"gallery.Tags", c.Tags},
+				{"gallery.Studio", c.Studio},
+			}
+		}
+	case models.ScrapeContentTypeMovie:
+		if c := s.Movie; c != nil {
+			return []mappedSection{
+				{"movie", c.mappedConfig},
+				{"movie.Studio", c.Studio},
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks the selectors and regular expressions of each attribute.
+// checkSelector returns an error if a selector is invalid.
+func (s mappedScraper) validate(checkSelector func(selector string) error) []error {
+	var ret []error
+	for _, contentType := range models.AllScrapeContentType {
+		for _, section := range s.sections(contentType) {
+			keys := make([]string, 0, len(section.config))
+			for k := range section.config {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				attrConfig := section.config[k]
+				if attrConfig.Fixed != "" {
+					continue
+				}
+
+				selector := section.config.applyCommon(s.Common, attrConfig.Selector)
+				for _, err := range attrConfig.validate(selector, checkSelector) {
+					ret = append(ret, fmt.Errorf("%s.%s: %s", section.name, k, err.Error()))
+				}
+			}
+		}
+	}
+
+	return ret
+}
+
 type mappedResult map[string]string
 type mappedResults []mappedResult
 
@@ -825,6 +980,49 @@ func (s mappedScraper) scrapeScene(q mappedQuery) (*models.ScrapedScene, error)
 	return &ret, nil
 }
 
+// scrapeScenes returns the scenes from a scene query result. Only the top
+// level scene fields are populated. The complete scene is scraped with the
+// sceneByQueryFragment configuration.
+func (s mappedScraper) scrapeScenes(q mappedQuery) ([]*models.ScrapedScene, error) {
+	var ret []*models.ScrapedScene
+
+	sceneScraperConfig := s.Scene
+	if sceneScraperConfig == nil || sceneScraperConfig.mappedConfig == nil {
+		return nil, nil
+	}
+
+	logger.Debug(`Processing scenes:`)
+	results := sceneScraperConfig.mappedConfig.process(q, s.Common)
+	for _, r := range results {
+		var scene models.ScrapedScene
+		r.apply(&scene)
+		ret = append(ret, &scene)
+	}
+
+	return ret, nil
+}
+
+// scrapeGalleries returns the galleries from a gallery query result. Only
+// the top level gallery fields are populated.
+func (s mappedScraper) scrapeGalleries(q mappedQuery) ([]*models.ScrapedGallery, error) {
+	var ret []*models.ScrapedGallery
+
+	galleryScraperConfig := s.Gallery
+	if galleryScraperConfig == nil || galleryScraperConfig.mappedConfig == nil {
+		return nil, nil
+	}
+
+	logger.Debug(`Processing galleries:`)
+	results := galleryScraperConfig.mappedConfig.process(q, s.Common)
+	for _, r := range results {
+		var gallery models.ScrapedGallery
+		r.apply(&gallery)
+		ret = append(ret, &gallery)
+	}
+
+	return ret, nil
+}
+
 func (s mappedScraper) scrapeGallery(q mappedQuery) (*models.ScrapedGallery, error) {
 	var ret models.ScrapedGallery
 
@@ -881,6 +1079,27 @@ func (s mappedScraper) scrapeGallery(q mappedQuery) (*models.ScrapedGallery, err
 	return &ret, nil
 }
 
+// scrapeMovies returns the movies from a movie query result. Only the top
+// level movie fields are populated.
+func (s mappedScraper) scrapeMovies(q mappedQuery) ([]*models.ScrapedMovie, error) {
+	var ret []*models.ScrapedMovie
+
+	movieScraperConfig := s.Movie
+	if movieScraperConfig == nil || movieScraperConfig.mappedConfig == nil {
+		return nil, nil
+	}
+
+	logger.Debug(`Processing movies:`)
+	results := movieScraperConfig.mappedConfig.process(q, s.Common)
+	for _, r := range results {
+		var movie models.ScrapedMovie
+		r.apply(&movie)
+		ret = append(ret, &movie)
+	}
+
+	return ret, nil
+}
+
 func (s mappedScraper) scrapeMovie(q mappedQuery) (*models.ScrapedMovie, error) {
 	var ret models.ScrapedMovie
 
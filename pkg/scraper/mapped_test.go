@@ -55,6 +55,8 @@ func TestFeetToCM(t *testing.T) {
 	q := &xpathQuery{}
 
 	for _, test := range feetToCMTests {
-		assert.Equal(t, test.out, pp.Apply(test.in, q))
+		out, err := pp.Apply(test.in, q)
+		assert.Nil(t, err)
+		assert.Equal(t, test.out, out)
 	}
 }
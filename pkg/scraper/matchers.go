@@ -72,6 +72,14 @@ func MatchScrapedSceneTag(qb models.TagReader, s *models.ScrapedSceneTag) error
 		return err
 	}
 
+	if tag == nil {
+		// try matching by alias
+		tag, err = qb.FindByAlias(s.Name, true)
+		if err != nil {
+			return err
+		}
+	}
+
 	if tag == nil {
 		// ignore - cannot match
 		return nil
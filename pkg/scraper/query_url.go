@@ -1,9 +1,11 @@
 package scraper
 
 import (
+	"net/url"
 	"path/filepath"
 	"strings"
 
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -21,6 +23,64 @@ func queryURLParametersFromScene(scene *models.Scene) queryURLParameters {
 	return ret
 }
 
+func queryURLParametersFromScrapedScene(scene models.ScrapedSceneInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("title", scene.Title)
+	setField("url", scene.URL)
+	setField("date", scene.Date)
+	setField("details", scene.Details)
+	setField("remote_site_id", scene.RemoteSiteID)
+	return ret
+}
+
+func queryURLParametersFromScrapedMovie(movie models.ScrapedMovieInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("name", movie.Name)
+	setField("url", movie.URL)
+	setField("date", movie.Date)
+	setField("director", movie.Director)
+	return ret
+}
+
+func queryURLParametersFromScrapedGallery(gallery models.ScrapedGalleryInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("title", gallery.Title)
+	setField("url", gallery.URL)
+	setField("date", gallery.Date)
+	setField("details", gallery.Details)
+	return ret
+}
+
+// nameQueryURL returns the query URL of the scraper configuration, with the
+// {} placeholder replaced with the URL-escaped name.
+func nameQueryURL(name string, scraperConfig scraperTypeConfig) string {
+	const placeholder = "{}"
+
+	escapedName := url.QueryEscape(name)
+	return strings.Replace(scraperConfig.QueryURL, placeholder, escapedName, -1)
+}
+
 func queryURLParameterFromURL(url string) queryURLParameters {
 	ret := make(queryURLParameters)
 	ret["url"] = url
@@ -44,7 +104,11 @@ func (p queryURLParameters) applyReplacements(r queryURLReplacements) {
 	for k, v := range p {
 		rpl, found := r[k]
 		if found {
-			p[k] = rpl.apply(v)
+			value, err := rpl.apply(v)
+			if err != nil {
+				logger.Warnf("Error applying %s replacements: %s", k, err.Error())
+			}
+			p[k] = value
 		}
 	}
 }
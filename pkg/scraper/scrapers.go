@@ -7,8 +7,10 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/pkg/logger"
+	stashConfig "github.com/stashapp/stash/pkg/manager/config"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -21,6 +23,12 @@ type GlobalConfig struct {
 	// Path (file or remote address) to a Chrome CDP instance.
 	CDPPath string
 	Path    string
+
+	// Path to the directory where scraper responses are cached.
+	CachePath string
+
+	// Credentials used by scrapers that log in, by secret name.
+	Secrets map[string]stashConfig.ScraperSecret
 }
 
 func (c GlobalConfig) isCDPPathHTTP() bool {
@@ -36,6 +44,15 @@ type Cache struct {
 	scrapers     []config
 	globalConfig GlobalConfig
 	txnManager   models.TransactionManager
+
+	// problems found when loading the scraper configurations
+	configErrors []*models.ScraperConfigError
+
+	// rate limiters and login sessions by scraper ID. These are kept when
+	// the scrapers are reloaded, so that the rate limits and logins are not
+	// reset.
+	limiters map[string]*rateLimiter
+	sessions map[string]*scraperSession
 }
 
 // NewCache returns a new Cache loading scraper configurations from the
@@ -45,20 +62,76 @@ type Cache struct {
 // Scraper configurations are loaded from yml files in the provided scrapers
 // directory and any subdirectories.
 func NewCache(globalConfig GlobalConfig, txnManager models.TransactionManager) (*Cache, error) {
-	scrapers, err := loadScrapers(globalConfig.Path)
+	scrapers, configErrors, err := loadScrapers(globalConfig.Path)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Cache{
+	ret := &Cache{
 		globalConfig: globalConfig,
 		scrapers:     scrapers,
+		configErrors: configErrors,
 		txnManager:   txnManager,
-	}, nil
+		limiters:     make(map[string]*rateLimiter),
+		sessions:     make(map[string]*scraperSession),
+	}
+	ret.setScraperState()
+	ret.pruneResponseCache()
+
+	return ret, nil
+}
+
+// setScraperState sets the rate limiter of each scraper with a rate limit,
+// and the login session of each scraper that logs in. Existing rate limiters
+// and sessions are reused if their configuration has not changed.
+func (c *Cache) setScraperState() {
+	for i, s := range c.scrapers {
+		c.scrapers[i].limiter = c.getRateLimiter(s)
+		c.scrapers[i].session = c.getSession(s)
+	}
+}
+
+func (c *Cache) getRateLimiter(s config) *rateLimiter {
+	if s.RateLimit == nil {
+		delete(c.limiters, s.ID)
+		return nil
+	}
+
+	l := c.limiters[s.ID]
+	if l == nil || !l.matches(*s.RateLimit) {
+		l = newRateLimiter(*s.RateLimit)
+		c.limiters[s.ID] = l
+	}
+
+	return l
 }
 
-func loadScrapers(path string) ([]config, error) {
+func (c *Cache) getSession(s config) *scraperSession {
+	if s.Login == nil {
+		delete(c.sessions, s.ID)
+		return nil
+	}
+
+	session := c.sessions[s.ID]
+	if session == nil || session.options != *s.Login {
+		var err error
+		session, err = newScraperSession(s.ID, *s.Login)
+		if err != nil {
+			logger.Errorf("Error creating session for scraper %s: %s", s.ID, err.Error())
+			return nil
+		}
+		c.sessions[s.ID] = session
+	}
+
+	return session
+}
+
+// loadScrapers loads the scraper configurations from the path. It returns
+// the loaded scrapers and the problems found in the configuration files.
+// Files with fatal problems are not loaded.
+func loadScrapers(path string) ([]config, []*models.ScraperConfigError, error) {
 	scrapers := make([]config, 0)
+	configErrors := make([]*models.ScraperConfigError, 0)
 
 	logger.Debugf("Reading scraper configs from %s", path)
 	scraperFiles := []string{}
@@ -71,7 +144,7 @@ func loadScrapers(path string) ([]config, error) {
 
 	if err != nil {
 		logger.Errorf("Error reading scraper configs: %s", err.Error())
-		return nil, err
+		return nil, nil, err
 	}
 
 	// add built-in freeones scraper
@@ -81,27 +154,101 @@ func loadScrapers(path string) ([]config, error) {
 		scraper, err := loadScraperFromYAMLFile(file)
 		if err != nil {
 			logger.Errorf("Error loading scraper %s: %s", file, err.Error())
-		} else {
-			scrapers = append(scrapers, *scraper)
+			configErrors = append(configErrors, &models.ScraperConfigError{
+				Path:      file,
+				ScraperID: scraperIDFromPath(file),
+				Message:   err.Error(),
+				Fatal:     true,
+			})
+			continue
 		}
+
+		for _, err := range scraper.check() {
+			logger.Warnf("Scraper %s: %s", scraper.ID, err.Error())
+			configErrors = append(configErrors, &models.ScraperConfigError{
+				Path:      file,
+				ScraperID: scraper.ID,
+				Message:   err.Error(),
+			})
+		}
+
+		scrapers = append(scrapers, *scraper)
 	}
 
-	return scrapers, nil
+	return scrapers, configErrors, nil
 }
 
 // ReloadScrapers clears the scraper cache and reloads from the scraper path.
 // In the event of an error during loading, the cache will be left empty.
 func (c *Cache) ReloadScrapers() error {
 	c.scrapers = nil
-	scrapers, err := loadScrapers(c.globalConfig.Path)
+	c.configErrors = nil
+	scrapers, configErrors, err := loadScrapers(c.globalConfig.Path)
 	if err != nil {
 		return err
 	}
 
 	c.scrapers = scrapers
+	c.configErrors = configErrors
+	c.setScraperState()
+	c.pruneResponseCache()
 	return nil
 }
 
+// pruneResponseCache removes the expired cached responses of the scrapers,
+// and the cached responses of scrapers that no longer cache responses.
+func (c Cache) pruneResponseCache() {
+	if c.globalConfig.CachePath == "" {
+		return
+	}
+
+	ttls := make(map[string]time.Duration)
+	for _, s := range c.scrapers {
+		ttls[s.ID] = time.Duration(s.CacheTTL) * time.Second
+	}
+
+	cache := responseCache{path: c.globalConfig.CachePath}
+	cache.pruneAll(ttls)
+}
+
+// ClearResponseCache removes the cached responses for the scraper with the
+// provided ID. If scraperID is empty, then the cached responses for all
+// scrapers are removed.
+func (c Cache) ClearResponseCache(scraperID string) error {
+	if c.globalConfig.CachePath == "" {
+		return nil
+	}
+
+	if scraperID != "" && c.findScraper(scraperID) == nil {
+		return errors.New("Scraper with ID " + scraperID + " not found")
+	}
+
+	cache := responseCache{path: c.globalConfig.CachePath}
+	return cache.clear(scraperID)
+}
+
+// ListConfigErrors returns the problems found in the scraper configuration
+// files when the scrapers were last loaded.
+func (c Cache) ListConfigErrors() []*models.ScraperConfigError {
+	if c.configErrors == nil {
+		return []*models.ScraperConfigError{}
+	}
+
+	return c.configErrors
+}
+
+// TestScraper runs the xpath or json scraper with the provided ID for the
+// content type, and returns the values of each attribute before and after
+// post-processing.
+func (c Cache) TestScraper(scraperID string, contentType models.ScrapeContentType, input models.ScraperTestInput) (*models.ScraperTestResult, error) {
+	s := c.findScraper(scraperID)
+	if s == nil {
+		return nil, errors.New("Scraper with ID " + scraperID + " not found")
+	}
+
+	return s.test(contentType, input, c.txnManager, c.globalConfig)
+}
+
 // UpdateConfig updates the global config for the cache. If the scraper path
 // has changed, ReloadScrapers will need to be called separately.
 func (c *Cache) UpdateConfig(globalConfig GlobalConfig) {
@@ -278,7 +425,22 @@ func (c Cache) postScrapeScenePerformer(ret *models.ScrapedScenePerformer) error
 }
 
 func (c Cache) postScrapeScene(ret *models.ScrapedScene) error {
-	if err := c.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+	if err := c.matchScrapedScene(ret); err != nil {
+		return err
+	}
+
+	// post-process - set the image if applicable
+	if err := setSceneImage(ret, c.globalConfig); err != nil {
+		logger.Warnf("Could not set image using URL %s: %s", *ret.Image, err.Error())
+	}
+
+	return nil
+}
+
+// matchScrapedScene matches the performers, movies, tags and studio of the
+// scraped scene to existing objects.
+func (c Cache) matchScrapedScene(ret *models.ScrapedScene) error {
+	return c.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
 		pqb := r.Performer()
 		mqb := r.Movie()
 		tqb := r.Tag()
@@ -316,16 +478,7 @@ func (c Cache) postScrapeScene(ret *models.ScrapedScene) error {
 		}
 
 		return nil
-	}); err != nil {
-		return err
-	}
-
-	// post-process - set the image if applicable
-	if err := setSceneImage(ret, c.globalConfig); err != nil {
-		logger.Warnf("Could not set image using URL %s: %s", *ret.Image, err.Error())
-	}
-
-	return nil
+	})
 }
 
 func (c Cache) postScrapeGallery(ret *models.ScrapedGallery) error {
@@ -363,6 +516,56 @@ func (c Cache) postScrapeGallery(ret *models.ScrapedGallery) error {
 	return nil
 }
 
+// ScrapeSceneQuery uses the scraper with the provided ID to query for
+// scenes using the provided query string. It returns a list of scraped
+// scene data.
+func (c Cache) ScrapeSceneQuery(scraperID string, query string) ([]*models.ScrapedScene, error) {
+	// find scraper with the provided id
+	s := c.findScraper(scraperID)
+	if s != nil {
+		ret, err := s.ScrapeSceneQuery(query, c.txnManager, c.globalConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// images are only fetched for the selected scene, so that a search
+		// does not fetch an image for each result
+		for _, scene := range ret {
+			if err := c.matchScrapedScene(scene); err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
+	}
+
+	return nil, errors.New("Scraper with ID " + scraperID + " not found")
+}
+
+// ScrapeSceneQueryFragment uses the scraper with the provided ID to scrape
+// a complete scene using a scene returned by ScrapeSceneQuery.
+func (c Cache) ScrapeSceneQueryFragment(scraperID string, scene models.ScrapedSceneInput) (*models.ScrapedScene, error) {
+	// find scraper with the provided id
+	s := c.findScraper(scraperID)
+	if s != nil {
+		ret, err := s.ScrapeSceneQueryFragment(scene, c.txnManager, c.globalConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if ret != nil {
+			err = c.postScrapeScene(ret)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
+	}
+
+	return nil, errors.New("Scraper with ID " + scraperID + " not found")
+}
+
 // ScrapeScene uses the scraper with the provided ID to scrape a scene.
 func (c Cache) ScrapeScene(scraperID string, scene models.SceneUpdateInput) (*models.ScrapedScene, error) {
 	// find scraper with the provided id
@@ -411,6 +614,54 @@ func (c Cache) ScrapeSceneURL(url string) (*models.ScrapedScene, error) {
 	return nil, nil
 }
 
+// ScrapeGalleryList uses the scraper with the provided ID to query for
+// galleries using the provided query string. It returns a list of scraped
+// gallery data.
+func (c Cache) ScrapeGalleryList(scraperID string, query string) ([]*models.ScrapedGallery, error) {
+	// find scraper with the provided id
+	s := c.findScraper(scraperID)
+	if s != nil {
+		ret, err := s.ScrapeGalleryNames(query, c.txnManager, c.globalConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, gallery := range ret {
+			if err := c.postScrapeGallery(gallery); err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
+	}
+
+	return nil, errors.New("Scraper with ID " + scraperID + " not found")
+}
+
+// ScrapeGalleryQueryFragment uses the scraper with the provided ID to scrape
+// the full gallery of a ScrapeGalleryList result.
+func (c Cache) ScrapeGalleryQueryFragment(scraperID string, gallery models.ScrapedGalleryInput) (*models.ScrapedGallery, error) {
+	s := c.findScraper(scraperID)
+	if s != nil {
+		ret, err := s.ScrapeGalleryQueryFragment(gallery, c.txnManager, c.globalConfig)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ret != nil {
+			err = c.postScrapeGallery(ret)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
+	}
+
+	return nil, errors.New("Scraped with ID " + scraperID + " not found")
+}
+
 // ScrapeGallery uses the scraper with the provided ID to scrape a scene.
 func (c Cache) ScrapeGallery(scraperID string, gallery models.GalleryUpdateInput) (*models.ScrapedGallery, error) {
 	s := c.findScraper(scraperID)
@@ -475,6 +726,83 @@ func matchMovieStudio(qb models.StudioReader, s *models.ScrapedMovieStudio) erro
 	return nil
 }
 
+func (c Cache) postScrapeMovie(ret *models.ScrapedMovie) error {
+	if err := c.matchScrapedMovie(ret); err != nil {
+		return err
+	}
+
+	// post-process - set the image if applicable
+	if err := setMovieFrontImage(ret, c.globalConfig); err != nil {
+		logger.Warnf("Could not set front image using URL %s: %s", *ret.FrontImage, err.Error())
+	}
+	if err := setMovieBackImage(ret, c.globalConfig); err != nil {
+		logger.Warnf("Could not set back image using URL %s: %s", *ret.BackImage, err.Error())
+	}
+
+	return nil
+}
+
+// matchScrapedMovie matches the studio of the scraped movie to an existing
+// studio.
+func (c Cache) matchScrapedMovie(ret *models.ScrapedMovie) error {
+	if ret.Studio == nil {
+		return nil
+	}
+
+	return c.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		return matchMovieStudio(r.Studio(), ret.Studio)
+	})
+}
+
+// ScrapeMovieList uses the scraper with the provided ID to query for
+// movies using the provided query string. It returns a list of scraped
+// movie data.
+func (c Cache) ScrapeMovieList(scraperID string, query string) ([]*models.ScrapedMovie, error) {
+	// find scraper with the provided id
+	s := c.findScraper(scraperID)
+	if s != nil {
+		ret, err := s.ScrapeMovieNames(query, c.txnManager, c.globalConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// images are only fetched for the selected movie, so that a search
+		// does not fetch the images for each result
+		for _, movie := range ret {
+			if err := c.matchScrapedMovie(movie); err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
+	}
+
+	return nil, errors.New("Scraper with ID " + scraperID + " not found")
+}
+
+// ScrapeMovie uses the scraper with the provided ID to scrape a movie
+// using the provided movie fragment.
+func (c Cache) ScrapeMovie(scraperID string, scrapedMovie models.ScrapedMovieInput) (*models.ScrapedMovie, error) {
+	// find scraper with the provided id
+	s := c.findScraper(scraperID)
+	if s != nil {
+		ret, err := s.ScrapeMovie(scrapedMovie, c.txnManager, c.globalConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if ret != nil {
+			if err := c.postScrapeMovie(ret); err != nil {
+				return nil, err
+			}
+		}
+
+		return ret, nil
+	}
+
+	return nil, errors.New("Scraper with ID " + scraperID + " not found")
+}
+
 // ScrapeMovieURL uses the first scraper it finds that matches the URL
 // provided to scrape a movie. If no scrapers are found that matches
 // the URL, then nil is returned.
@@ -486,22 +814,12 @@ func (c Cache) ScrapeMovieURL(url string) (*models.ScrapedMovie, error) {
 				return nil, err
 			}
 
-			if ret.Studio != nil {
-				if err := c.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
-					return matchMovieStudio(r.Studio(), ret.Studio)
-				}); err != nil {
+			if ret != nil {
+				if err := c.postScrapeMovie(ret); err != nil {
 					return nil, err
 				}
 			}
 
-			// post-process - set the image if applicable
-			if err := setMovieFrontImage(ret, c.globalConfig); err != nil {
-				logger.Warnf("Could not set front image using URL %s: %s", *ret.FrontImage, err.Error())
-			}
-			if err := setMovieBackImage(ret, c.globalConfig); err != nil {
-				logger.Warnf("Could not set back image using URL %s: %s", *ret.BackImage, err.Error())
-			}
-
 			return ret, nil
 		}
 	}
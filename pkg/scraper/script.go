@@ -92,8 +92,19 @@ func (s *scriptScraper) runScraperScript(inString string, out interface{}) error
 	return nil
 }
 
+// scriptInput returns the JSON input for a scraper script with the field set
+// to value.
+func scriptInput(field string, value string) string {
+	// marshalling a map of strings cannot fail
+	ret, _ := json.Marshal(map[string]string{
+		field: value,
+	})
+
+	return string(ret)
+}
+
 func (s *scriptScraper) scrapePerformersByName(name string) ([]*models.ScrapedPerformer, error) {
-	inString := `{"name": "` + name + `"}`
+	inString := scriptInput("name", name)
 
 	var performers []models.ScrapedPerformer
 
@@ -125,7 +136,7 @@ func (s *scriptScraper) scrapePerformerByFragment(scrapedPerformer models.Scrape
 }
 
 func (s *scriptScraper) scrapePerformerByURL(url string) (*models.ScrapedPerformer, error) {
-	inString := `{"url": "` + url + `"}`
+	inString := scriptInput("url", url)
 
 	var ret models.ScrapedPerformer
 
@@ -134,6 +145,38 @@ func (s *scriptScraper) scrapePerformerByURL(url string) (*models.ScrapedPerform
 	return &ret, err
 }
 
+func (s *scriptScraper) scrapeScenesByName(name string) ([]*models.ScrapedScene, error) {
+	inString := scriptInput("name", name)
+
+	var scenes []models.ScrapedScene
+
+	err := s.runScraperScript(inString, &scenes)
+
+	// convert to pointers
+	var ret []*models.ScrapedScene
+	if err == nil {
+		for i := 0; i < len(scenes); i++ {
+			ret = append(ret, &scenes[i])
+		}
+	}
+
+	return ret, err
+}
+
+func (s *scriptScraper) scrapeSceneByScene(scene models.ScrapedSceneInput) (*models.ScrapedScene, error) {
+	inString, err := json.Marshal(scene)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret models.ScrapedScene
+
+	err = s.runScraperScript(string(inString), &ret)
+
+	return &ret, err
+}
+
 func (s *scriptScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*models.ScrapedScene, error) {
 	inString, err := json.Marshal(scene)
 
@@ -148,6 +191,20 @@ func (s *scriptScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*m
 	return &ret, err
 }
 
+func (s *scriptScraper) scrapeGalleryByGallery(gallery models.ScrapedGalleryInput) (*models.ScrapedGallery, error) {
+	inString, err := json.Marshal(gallery)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret models.ScrapedGallery
+
+	err = s.runScraperScript(string(inString), &ret)
+
+	return &ret, err
+}
+
 func (s *scriptScraper) scrapeGalleryByFragment(gallery models.GalleryUpdateInput) (*models.ScrapedGallery, error) {
 	inString, err := json.Marshal(gallery)
 
@@ -163,7 +220,7 @@ func (s *scriptScraper) scrapeGalleryByFragment(gallery models.GalleryUpdateInpu
 }
 
 func (s *scriptScraper) scrapeSceneByURL(url string) (*models.ScrapedScene, error) {
-	inString := `{"url": "` + url + `"}`
+	inString := scriptInput("url", url)
 
 	var ret models.ScrapedScene
 
@@ -173,7 +230,7 @@ func (s *scriptScraper) scrapeSceneByURL(url string) (*models.ScrapedScene, erro
 }
 
 func (s *scriptScraper) scrapeGalleryByURL(url string) (*models.ScrapedGallery, error) {
-	inString := `{"url": "` + url + `"}`
+	inString := scriptInput("url", url)
 
 	var ret models.ScrapedGallery
 
@@ -183,7 +240,7 @@ func (s *scriptScraper) scrapeGalleryByURL(url string) (*models.ScrapedGallery,
 }
 
 func (s *scriptScraper) scrapeMovieByURL(url string) (*models.ScrapedMovie, error) {
-	inString := `{"url": "` + url + `"}`
+	inString := scriptInput("url", url)
 
 	var ret models.ScrapedMovie
 
@@ -192,6 +249,56 @@ func (s *scriptScraper) scrapeMovieByURL(url string) (*models.ScrapedMovie, erro
 	return &ret, err
 }
 
+func (s *scriptScraper) scrapeGalleriesByName(name string) ([]*models.ScrapedGallery, error) {
+	inString := scriptInput("name", name)
+
+	var galleries []models.ScrapedGallery
+
+	err := s.runScraperScript(inString, &galleries)
+
+	// convert to pointers
+	var ret []*models.ScrapedGallery
+	if err == nil {
+		for i := 0; i < len(galleries); i++ {
+			ret = append(ret, &galleries[i])
+		}
+	}
+
+	return ret, err
+}
+
+func (s *scriptScraper) scrapeMoviesByName(name string) ([]*models.ScrapedMovie, error) {
+	inString := scriptInput("name", name)
+
+	var movies []models.ScrapedMovie
+
+	err := s.runScraperScript(inString, &movies)
+
+	// convert to pointers
+	var ret []*models.ScrapedMovie
+	if err == nil {
+		for i := 0; i < len(movies); i++ {
+			ret = append(ret, &movies[i])
+		}
+	}
+
+	return ret, err
+}
+
+func (s *scriptScraper) scrapeMovieByFragment(movie models.ScrapedMovieInput) (*models.ScrapedMovie, error) {
+	inString, err := json.Marshal(movie)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret models.ScrapedMovie
+
+	err = s.runScraperScript(string(inString), &ret)
+
+	return &ret, err
+}
+
 func findPythonExecutable() (string, error) {
 	_, err := exec.LookPath("python3")
 
@@ -0,0 +1,17 @@
+package scraper
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScriptInput(t *testing.T) {
+	const name = `name with "quotes" and \ backslash`
+
+	var decoded map[string]string
+	err := json.Unmarshal([]byte(scriptInput("name", name)), &decoded)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"name": name}, decoded)
+}
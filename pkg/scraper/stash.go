@@ -123,6 +123,119 @@ func (s *stashScraper) scrapePerformerByFragment(scrapedPerformer models.Scraped
 	return &ret, nil
 }
 
+type stashFindSceneNamesResultType struct {
+	Count  int                         `graphql:"count"`
+	Scenes []*models.ScrapedSceneStash `graphql:"scenes"`
+}
+
+func (s *stashScraper) scrapedStashSceneToScrapedScene(scene *models.ScrapedSceneStash) (*models.ScrapedScene, error) {
+	if scene == nil {
+		return nil, nil
+	}
+
+	// the ids of the studio, performers and tags must be nilled
+	if scene.Studio != nil {
+		scene.Studio.ID = nil
+	}
+
+	for _, p := range scene.Performers {
+		p.ID = nil
+	}
+
+	for _, t := range scene.Tags {
+		t.ID = nil
+	}
+
+	// need to copy back to a scraped scene
+	ret := models.ScrapedScene{}
+	if err := copier.Copy(&ret, scene); err != nil {
+		return nil, err
+	}
+
+	// put id into the remote site id field
+	id := scene.ID
+	ret.RemoteSiteID = &id
+
+	return &ret, nil
+}
+
+// scrapeStashScene converts the scene and gets its image. The image is only
+// fetched for individual scenes, so that searches do not fetch an image for
+// each result.
+func (s *stashScraper) scrapeStashScene(scene *models.ScrapedSceneStash) (*models.ScrapedScene, error) {
+	ret, err := s.scrapedStashSceneToScrapedScene(scene)
+	if err != nil || ret == nil {
+		return ret, err
+	}
+
+	// get the scene image directly
+	ret.Image, err = getStashSceneImage(s.config.StashServer.URL, scene.ID, s.globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *stashScraper) scrapeScenesByName(name string) ([]*models.ScrapedScene, error) {
+	client := s.getStashClient()
+
+	var q struct {
+		FindScenes stashFindSceneNamesResultType `graphql:"findScenes(filter: $f)"`
+	}
+
+	page := 1
+	perPage := 10
+
+	vars := map[string]interface{}{
+		"f": models.FindFilterType{
+			Q:       &name,
+			Page:    &page,
+			PerPage: &perPage,
+		},
+	}
+
+	err := client.Query(context.Background(), &q, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*models.ScrapedScene
+	for _, scene := range q.FindScenes.Scenes {
+		converted, err := s.scrapedStashSceneToScrapedScene(scene)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, converted)
+	}
+
+	return ret, nil
+}
+
+func (s *stashScraper) scrapeSceneByScene(scene models.ScrapedSceneInput) (*models.ScrapedScene, error) {
+	if scene.RemoteSiteID == nil {
+		return nil, errors.New("remote site id is required for stash scraper")
+	}
+
+	client := s.getStashClient()
+
+	var q struct {
+		FindScene *models.ScrapedSceneStash `graphql:"findScene(id: $i)"`
+	}
+
+	// get the id from the remote site id field
+	vars := map[string]interface{}{
+		"i": graphql.ID(*scene.RemoteSiteID),
+	}
+
+	err := client.Query(context.Background(), &q, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scrapeStashScene(q.FindScene)
+}
+
 func (s *stashScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*models.ScrapedScene, error) {
 	// query by MD5
 	// assumes that the scene exists in the database
@@ -164,35 +277,7 @@ func (s *stashScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*mo
 		return nil, err
 	}
 
-	if q.FindScene != nil {
-		// the ids of the studio, performers and tags must be nilled
-		if q.FindScene.Studio != nil {
-			q.FindScene.Studio.ID = nil
-		}
-
-		for _, p := range q.FindScene.Performers {
-			p.ID = nil
-		}
-
-		for _, t := range q.FindScene.Tags {
-			t.ID = nil
-		}
-	}
-
-	// need to copy back to a scraped scene
-	ret := models.ScrapedScene{}
-	err = copier.Copy(&ret, q.FindScene)
-	if err != nil {
-		return nil, err
-	}
-
-	// get the performer image directly
-	ret.Image, err = getStashSceneImage(s.config.StashServer.URL, q.FindScene.ID, s.globalConfig)
-	if err != nil {
-		return nil, err
-	}
-
-	return &ret, nil
+	return s.scrapeStashScene(q.FindScene)
 }
 
 func (s *stashScraper) scrapeGalleryByFragment(scene models.GalleryUpdateInput) (*models.ScrapedGallery, error) {
@@ -273,6 +358,22 @@ func (s *stashScraper) scrapeGalleryByURL(url string) (*models.ScrapedGallery, e
 	return nil, errors.New("scrapeGalleryByURL not supported for stash scraper")
 }
 
+func (s *stashScraper) scrapeGalleriesByName(name string) ([]*models.ScrapedGallery, error) {
+	return nil, errors.New("scrapeGalleriesByName not supported for stash scraper")
+}
+
+func (s *stashScraper) scrapeGalleryByGallery(gallery models.ScrapedGalleryInput) (*models.ScrapedGallery, error) {
+	return nil, errors.New("scrapeGalleryByGallery not supported for stash scraper")
+}
+
+func (s *stashScraper) scrapeMoviesByName(name string) ([]*models.ScrapedMovie, error) {
+	return nil, errors.New("scrapeMoviesByName not supported for stash scraper")
+}
+
+func (s *stashScraper) scrapeMovieByFragment(movie models.ScrapedMovieInput) (*models.ScrapedMovie, error) {
+	return nil, errors.New("scrapeMovieByFragment not supported for stash scraper")
+}
+
 func (s *stashScraper) scrapeMovieByURL(url string) (*models.ScrapedMovie, error) {
 	return nil, errors.New("scrapeMovieByURL not supported for stash scraper")
 }
@@ -0,0 +1,305 @@
+package stashbox
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Yamashou/gqlgenc/client"
+	"github.com/Yamashou/gqlgenc/graphqljson"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
+)
+
+// SubmitSceneDraft submits a draft of the scene with the provided ID to
+// stash-box. The scene's performers, studio, tags, cover image and
+// fingerprints are included. Returns the ID of the draft.
+func (c Client) SubmitSceneDraft(sceneID int, endpoint string) (*string, error) {
+	draft := graphql.SceneDraftInput{}
+	var image []byte
+
+	if err := c.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		qb := r.Scene()
+
+		scene, err := qb.Find(sceneID)
+		if err != nil {
+			return err
+		}
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", sceneID)
+		}
+
+		if scene.Title.Valid {
+			draft.Title = &scene.Title.String
+		}
+		if scene.Details.Valid {
+			draft.Details = &scene.Details.String
+		}
+		if scene.URL.Valid {
+			draft.URL = &scene.URL.String
+		}
+		if scene.Date.Valid {
+			draft.Date = &scene.Date.String
+		}
+
+		stashIDs, err := qb.GetStashIDs(sceneID)
+		if err != nil {
+			return err
+		}
+		draft.ID = findStashID(stashIDs, endpoint)
+
+		if scene.StudioID.Valid {
+			studio, err := r.Studio().Find(int(scene.StudioID.Int64))
+			if err != nil {
+				return err
+			}
+			if studio == nil {
+				return fmt.Errorf("studio with id %d not found", scene.StudioID.Int64)
+			}
+
+			studioStashIDs, err := r.Studio().GetStashIDs(studio.ID)
+			if err != nil {
+				return err
+			}
+
+			draft.Studio = &graphql.DraftEntityInput{
+				Name: studio.Name.String,
+				ID:   findStashID(studioStashIDs, endpoint),
+			}
+		}
+
+		performers, err := r.Performer().FindBySceneID(sceneID)
+		if err != nil {
+			return err
+		}
+
+		draft.Performers = []*graphql.DraftEntityInput{}
+		for _, p := range performers {
+			performerStashIDs, err := r.Performer().GetStashIDs(p.ID)
+			if err != nil {
+				return err
+			}
+
+			draft.Performers = append(draft.Performers, &graphql.DraftEntityInput{
+				Name: p.Name.String,
+				ID:   findStashID(performerStashIDs, endpoint),
+			})
+		}
+
+		tags, err := r.Tag().FindBySceneID(sceneID)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range tags {
+			draft.Tags = append(draft.Tags, &graphql.DraftEntityInput{
+				Name: t.Name,
+			})
+		}
+
+		draft.Fingerprints = []*graphql.FingerprintInput{}
+		if scene.Duration.Valid {
+			duration := int(scene.Duration.Float64)
+			if scene.Checksum.Valid {
+				draft.Fingerprints = append(draft.Fingerprints, &graphql.FingerprintInput{
+					Hash:      scene.Checksum.String,
+					Algorithm: graphql.FingerprintAlgorithmMd5,
+					Duration:  duration,
+				})
+			}
+			if scene.OSHash.Valid {
+				draft.Fingerprints = append(draft.Fingerprints, &graphql.FingerprintInput{
+					Hash:      scene.OSHash.String,
+					Algorithm: graphql.FingerprintAlgorithmOshash,
+					Duration:  duration,
+				})
+			}
+		}
+
+		image, err = qb.GetCover(sceneID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var res graphql.SubmitSceneDraftPayload
+	if err := c.submitDraft(context.TODO(), graphql.SubmitSceneDraftQuery, draft, image, &res); err != nil {
+		return nil, err
+	}
+
+	return res.SubmitSceneDraft.ID, nil
+}
+
+// SubmitPerformerDraft submits a draft of the performer with the provided ID
+// to stash-box. Returns the ID of the draft.
+func (c Client) SubmitPerformerDraft(performerID int, endpoint string) (*string, error) {
+	draft := graphql.PerformerDraftInput{}
+	var image []byte
+
+	if err := c.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		qb := r.Performer()
+
+		performer, err := qb.Find(performerID)
+		if err != nil {
+			return err
+		}
+		if performer == nil {
+			return fmt.Errorf("performer with id %d not found", performerID)
+		}
+
+		draft.Name = performer.Name.String
+		draft.Aliases = nullStringPtr(performer.Aliases)
+		draft.Gender = nullStringPtr(performer.Gender)
+		draft.Ethnicity = nullStringPtr(performer.Ethnicity)
+		draft.Country = nullStringPtr(performer.Country)
+		draft.EyeColor = nullStringPtr(performer.EyeColor)
+		draft.Height = nullStringPtr(performer.Height)
+		draft.Measurements = nullStringPtr(performer.Measurements)
+		draft.BreastType = nullStringPtr(performer.FakeTits)
+		draft.Tattoos = nullStringPtr(performer.Tattoos)
+		draft.Piercings = nullStringPtr(performer.Piercings)
+
+		if performer.Birthdate.Valid {
+			draft.Birthdate = &performer.Birthdate.String
+		}
+
+		if performer.CareerLength.Valid {
+			draft.CareerStartYear, draft.CareerEndYear = parseCareerLength(performer.CareerLength.String)
+		}
+
+		for _, u := range []sql.NullString{performer.URL, performer.Twitter, performer.Instagram} {
+			if u.Valid && u.String != "" {
+				draft.Urls = append(draft.Urls, u.String)
+			}
+		}
+
+		stashIDs, err := qb.GetStashIDs(performerID)
+		if err != nil {
+			return err
+		}
+		draft.ID = findStashID(stashIDs, endpoint)
+
+		image, err = qb.GetImage(performerID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var res graphql.SubmitPerformerDraftPayload
+	if err := c.submitDraft(context.TODO(), graphql.SubmitPerformerDraftQuery, draft, image, &res); err != nil {
+		return nil, err
+	}
+
+	return res.SubmitPerformerDraft.ID, nil
+}
+
+// submitDraft posts the draft mutation as a multipart request, so that the
+// image can be uploaded with it. The generated client only supports JSON
+// requests, so the request is sent using its HTTP client and options.
+func (c Client) submitDraft(ctx context.Context, query string, input interface{}, image []byte, ret interface{}) error {
+	operations, err := json.Marshal(client.Request{
+		Query: query,
+		Variables: map[string]interface{}{
+			"input": input,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writer.WriteField("operations", string(operations)); err != nil {
+		return err
+	}
+
+	if len(image) > 0 {
+		if err := writer.WriteField("map", `{"0": ["variables.input.image"]}`); err != nil {
+			return err
+		}
+
+		part, err := writer.CreateFormFile("0", "draft")
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(image); err != nil {
+			return err
+		}
+	} else if err := writer.WriteField("map", "{}"); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	gqlClient := c.client.Client
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, gqlClient.BaseURL, body)
+	if err != nil {
+		return err
+	}
+
+	for _, option := range gqlClient.HTTPRequestOptions {
+		option(req)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := gqlClient.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("http status code: %d", resp.StatusCode)
+	}
+
+	return graphqljson.Unmarshal(resp.Body, ret)
+}
+
+func findStashID(stashIDs []*models.StashID, endpoint string) *string {
+	for _, stashID := range stashIDs {
+		if stashID.Endpoint == endpoint {
+			id := stashID.StashID
+			return &id
+		}
+	}
+
+	return nil
+}
+
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid || s.String == "" {
+		return nil
+	}
+
+	return &s.String
+}
+
+// parseCareerLength parses career lengths in the form "2010 - 2015" or
+// "2010 -" into start and end years.
+func parseCareerLength(careerLength string) (start *int, end *int) {
+	years := strings.SplitN(careerLength, "-", 2)
+
+	parseYear := func(s string) *int {
+		year, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return nil
+		}
+		return &year
+	}
+
+	start = parseYear(years[0])
+	if len(years) > 1 {
+		end = parseYear(years[1])
+	}
+
+	return start, end
+}
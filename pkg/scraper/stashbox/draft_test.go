@@ -0,0 +1,101 @@
+package stashbox
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
+)
+
+func TestParseCareerLength(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		careerLength string
+		start        *int
+		end          *int
+	}{
+		{"2010 - 2015", intPtr(2010), intPtr(2015)},
+		{"2010-", intPtr(2010), nil},
+		{"2010", intPtr(2010), nil},
+		{"unknown", nil, nil},
+	}
+
+	for _, test := range tests {
+		start, end := parseCareerLength(test.careerLength)
+		assert.Equal(t, test.start, start, test.careerLength)
+		assert.Equal(t, test.end, end, test.careerLength)
+	}
+}
+
+func TestSubmitDraft(t *testing.T) {
+	const (
+		apiKey  = "apikey"
+		draftID = "draft"
+		image   = "image data"
+	)
+
+	title := "title"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, apiKey, r.Header.Get("ApiKey"))
+
+		var operations struct {
+			Query     string
+			Variables struct {
+				Input graphql.SceneDraftInput
+			}
+		}
+		if err := json.Unmarshal([]byte(r.FormValue("operations")), &operations); err != nil {
+			t.Error(err)
+		}
+
+		assert.Equal(t, graphql.SubmitSceneDraftQuery, operations.Query)
+		assert.Equal(t, title, *operations.Variables.Input.Title)
+		assert.JSONEq(t, `{"0": ["variables.input.image"]}`, r.FormValue("map"))
+
+		f, _, err := r.FormFile("0")
+		if err != nil {
+			t.Error(err)
+		} else {
+			data, _ := ioutil.ReadAll(f)
+			assert.Equal(t, image, string(data))
+		}
+
+		_, _ = w.Write([]byte(`{"data": {"submitSceneDraft": {"id": "` + draftID + `"}}}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(models.StashBox{Endpoint: ts.URL, APIKey: apiKey}, nil)
+
+	var res graphql.SubmitSceneDraftPayload
+	err := c.submitDraft(context.Background(), graphql.SubmitSceneDraftQuery, graphql.SceneDraftInput{
+		Title: &title,
+	}, []byte(image), &res)
+
+	assert.Nil(t, err)
+	assert.Equal(t, draftID, *res.SubmitSceneDraft.ID)
+}
+
+func TestSubmitDraftStatus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	c := NewClient(models.StashBox{Endpoint: ts.URL}, nil)
+
+	var res graphql.SubmitSceneDraftPayload
+	err := c.submitDraft(context.Background(), graphql.SubmitSceneDraftQuery, graphql.SceneDraftInput{}, nil, &res)
+
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "500")
+	}
+}
@@ -39,35 +39,37 @@ type Query struct {
 }
 
 type Mutation struct {
-	SceneCreate       *Scene     "json:\"sceneCreate\" graphql:\"sceneCreate\""
-	SceneUpdate       *Scene     "json:\"sceneUpdate\" graphql:\"sceneUpdate\""
-	SceneDestroy      bool       "json:\"sceneDestroy\" graphql:\"sceneDestroy\""
-	PerformerCreate   *Performer "json:\"performerCreate\" graphql:\"performerCreate\""
-	PerformerUpdate   *Performer "json:\"performerUpdate\" graphql:\"performerUpdate\""
-	PerformerDestroy  bool       "json:\"performerDestroy\" graphql:\"performerDestroy\""
-	StudioCreate      *Studio    "json:\"studioCreate\" graphql:\"studioCreate\""
-	StudioUpdate      *Studio    "json:\"studioUpdate\" graphql:\"studioUpdate\""
-	StudioDestroy     bool       "json:\"studioDestroy\" graphql:\"studioDestroy\""
-	TagCreate         *Tag       "json:\"tagCreate\" graphql:\"tagCreate\""
-	TagUpdate         *Tag       "json:\"tagUpdate\" graphql:\"tagUpdate\""
-	TagDestroy        bool       "json:\"tagDestroy\" graphql:\"tagDestroy\""
-	UserCreate        *User      "json:\"userCreate\" graphql:\"userCreate\""
-	UserUpdate        *User      "json:\"userUpdate\" graphql:\"userUpdate\""
-	UserDestroy       bool       "json:\"userDestroy\" graphql:\"userDestroy\""
-	ImageCreate       *Image     "json:\"imageCreate\" graphql:\"imageCreate\""
-	ImageUpdate       *Image     "json:\"imageUpdate\" graphql:\"imageUpdate\""
-	ImageDestroy      bool       "json:\"imageDestroy\" graphql:\"imageDestroy\""
-	RegenerateAPIKey  string     "json:\"regenerateAPIKey\" graphql:\"regenerateAPIKey\""
-	ChangePassword    bool       "json:\"changePassword\" graphql:\"changePassword\""
-	SceneEdit         Edit       "json:\"sceneEdit\" graphql:\"sceneEdit\""
-	PerformerEdit     Edit       "json:\"performerEdit\" graphql:\"performerEdit\""
-	StudioEdit        Edit       "json:\"studioEdit\" graphql:\"studioEdit\""
-	TagEdit           Edit       "json:\"tagEdit\" graphql:\"tagEdit\""
-	EditVote          Edit       "json:\"editVote\" graphql:\"editVote\""
-	EditComment       Edit       "json:\"editComment\" graphql:\"editComment\""
-	ApplyEdit         Edit       "json:\"applyEdit\" graphql:\"applyEdit\""
-	CancelEdit        Edit       "json:\"cancelEdit\" graphql:\"cancelEdit\""
-	SubmitFingerprint bool       "json:\"submitFingerprint\" graphql:\"submitFingerprint\""
+	SceneCreate          *Scene                "json:\"sceneCreate\" graphql:\"sceneCreate\""
+	SceneUpdate          *Scene                "json:\"sceneUpdate\" graphql:\"sceneUpdate\""
+	SceneDestroy         bool                  "json:\"sceneDestroy\" graphql:\"sceneDestroy\""
+	PerformerCreate      *Performer            "json:\"performerCreate\" graphql:\"performerCreate\""
+	PerformerUpdate      *Performer            "json:\"performerUpdate\" graphql:\"performerUpdate\""
+	PerformerDestroy     bool                  "json:\"performerDestroy\" graphql:\"performerDestroy\""
+	StudioCreate         *Studio               "json:\"studioCreate\" graphql:\"studioCreate\""
+	StudioUpdate         *Studio               "json:\"studioUpdate\" graphql:\"studioUpdate\""
+	StudioDestroy        bool                  "json:\"studioDestroy\" graphql:\"studioDestroy\""
+	TagCreate            *Tag                  "json:\"tagCreate\" graphql:\"tagCreate\""
+	TagUpdate            *Tag                  "json:\"tagUpdate\" graphql:\"tagUpdate\""
+	TagDestroy           bool                  "json:\"tagDestroy\" graphql:\"tagDestroy\""
+	UserCreate           *User                 "json:\"userCreate\" graphql:\"userCreate\""
+	UserUpdate           *User                 "json:\"userUpdate\" graphql:\"userUpdate\""
+	UserDestroy          bool                  "json:\"userDestroy\" graphql:\"userDestroy\""
+	ImageCreate          *Image                "json:\"imageCreate\" graphql:\"imageCreate\""
+	ImageUpdate          *Image                "json:\"imageUpdate\" graphql:\"imageUpdate\""
+	ImageDestroy         bool                  "json:\"imageDestroy\" graphql:\"imageDestroy\""
+	RegenerateAPIKey     string                "json:\"regenerateAPIKey\" graphql:\"regenerateAPIKey\""
+	ChangePassword       bool                  "json:\"changePassword\" graphql:\"changePassword\""
+	SceneEdit            Edit                  "json:\"sceneEdit\" graphql:\"sceneEdit\""
+	PerformerEdit        Edit                  "json:\"performerEdit\" graphql:\"performerEdit\""
+	StudioEdit           Edit                  "json:\"studioEdit\" graphql:\"studioEdit\""
+	TagEdit              Edit                  "json:\"tagEdit\" graphql:\"tagEdit\""
+	EditVote             Edit                  "json:\"editVote\" graphql:\"editVote\""
+	EditComment          Edit                  "json:\"editComment\" graphql:\"editComment\""
+	ApplyEdit            Edit                  "json:\"applyEdit\" graphql:\"applyEdit\""
+	CancelEdit           Edit                  "json:\"cancelEdit\" graphql:\"cancelEdit\""
+	SubmitFingerprint    bool                  "json:\"submitFingerprint\" graphql:\"submitFingerprint\""
+	SubmitSceneDraft     DraftSubmissionStatus "json:\"submitSceneDraft\" graphql:\"submitSceneDraft\""
+	SubmitPerformerDraft DraftSubmissionStatus "json:\"submitPerformerDraft\" graphql:\"submitPerformerDraft\""
 }
 type URLFragment struct {
 	URL  string "json:\"url\" graphql:\"url\""
@@ -155,9 +157,34 @@ type FindScenesByFingerprints struct {
 type SearchScene struct {
 	SearchScene []*SceneFragment "json:\"searchScene\" graphql:\"searchScene\""
 }
+type SearchPerformer struct {
+	SearchPerformer []*PerformerFragment "json:\"searchPerformer\" graphql:\"searchPerformer\""
+}
+type FindPerformerByID struct {
+	FindPerformer *PerformerFragment "json:\"findPerformer\" graphql:\"findPerformer\""
+}
+type FindStudioByID struct {
+	FindStudio *StudioFragment "json:\"findStudio\" graphql:\"findStudio\""
+}
+type QueryStudios struct {
+	QueryStudios struct {
+		Count   int               "json:\"count\" graphql:\"count\""
+		Studios []*StudioFragment "json:\"studios\" graphql:\"studios\""
+	} "json:\"queryStudios\" graphql:\"queryStudios\""
+}
 type SubmitFingerprintPayload struct {
 	SubmitFingerprint bool "json:\"submitFingerprint\" graphql:\"submitFingerprint\""
 }
+type SubmitSceneDraftPayload struct {
+	SubmitSceneDraft struct {
+		ID *string "json:\"id\" graphql:\"id\""
+	} "json:\"submitSceneDraft\" graphql:\"submitSceneDraft\""
+}
+type SubmitPerformerDraftPayload struct {
+	SubmitPerformerDraft struct {
+		ID *string "json:\"id\" graphql:\"id\""
+	} "json:\"submitPerformerDraft\" graphql:\"submitPerformerDraft\""
+}
 
 const FindSceneByFingerprintQuery = `query FindSceneByFingerprint ($fingerprint: FingerprintQueryInput!) {
 	findSceneByFingerprint(fingerprint: $fingerprint) {
@@ -540,6 +567,243 @@ func (c *Client) SearchScene(ctx context.Context, term string, httpRequestOption
 	return &res, nil
 }
 
+const SearchPerformerQuery = `query SearchPerformer ($term: String!) {
+	searchPerformer(term: $term) {
+		... PerformerFragment
+	}
+}
+fragment PerformerFragment on Performer {
+	id
+	name
+	disambiguation
+	aliases
+	gender
+	urls {
+		... URLFragment
+	}
+	images {
+		... ImageFragment
+	}
+	birthdate {
+		... FuzzyDateFragment
+	}
+	ethnicity
+	country
+	eye_color
+	hair_color
+	height
+	measurements {
+		... MeasurementsFragment
+	}
+	breast_type
+	career_start_year
+	career_end_year
+	tattoos {
+		... BodyModificationFragment
+	}
+	piercings {
+		... BodyModificationFragment
+	}
+}
+fragment URLFragment on URL {
+	url
+	type
+}
+fragment ImageFragment on Image {
+	id
+	url
+	width
+	height
+}
+fragment FuzzyDateFragment on FuzzyDate {
+	date
+	accuracy
+}
+fragment MeasurementsFragment on Measurements {
+	band_size
+	cup_size
+	waist
+	hip
+}
+fragment BodyModificationFragment on BodyModification {
+	location
+	description
+}
+`
+
+func (c *Client) SearchPerformer(ctx context.Context, term string, httpRequestOptions ...client.HTTPRequestOption) (*SearchPerformer, error) {
+	vars := map[string]interface{}{
+		"term": term,
+	}
+
+	var res SearchPerformer
+	if err := c.Client.Post(ctx, SearchPerformerQuery, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const FindPerformerByIDQuery = `query FindPerformerByID ($id: ID!) {
+	findPerformer(id: $id) {
+		... PerformerFragment
+	}
+}
+fragment PerformerFragment on Performer {
+	id
+	name
+	disambiguation
+	aliases
+	gender
+	urls {
+		... URLFragment
+	}
+	images {
+		... ImageFragment
+	}
+	birthdate {
+		... FuzzyDateFragment
+	}
+	ethnicity
+	country
+	eye_color
+	hair_color
+	height
+	measurements {
+		... MeasurementsFragment
+	}
+	breast_type
+	career_start_year
+	career_end_year
+	tattoos {
+		... BodyModificationFragment
+	}
+	piercings {
+		... BodyModificationFragment
+	}
+}
+fragment URLFragment on URL {
+	url
+	type
+}
+fragment ImageFragment on Image {
+	id
+	url
+	width
+	height
+}
+fragment FuzzyDateFragment on FuzzyDate {
+	date
+	accuracy
+}
+fragment MeasurementsFragment on Measurements {
+	band_size
+	cup_size
+	waist
+	hip
+}
+fragment BodyModificationFragment on BodyModification {
+	location
+	description
+}
+`
+
+func (c *Client) FindPerformerByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindPerformerByID, error) {
+	vars := map[string]interface{}{
+		"id": id,
+	}
+
+	var res FindPerformerByID
+	if err := c.Client.Post(ctx, FindPerformerByIDQuery, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const FindStudioByIDQuery = `query FindStudioByID ($id: ID!) {
+	findStudio(id: $id) {
+		... StudioFragment
+	}
+}
+fragment StudioFragment on Studio {
+	name
+	id
+	urls {
+		... URLFragment
+	}
+	images {
+		... ImageFragment
+	}
+}
+fragment URLFragment on URL {
+	url
+	type
+}
+fragment ImageFragment on Image {
+	id
+	url
+	width
+	height
+}
+`
+
+func (c *Client) FindStudioByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindStudioByID, error) {
+	vars := map[string]interface{}{
+		"id": id,
+	}
+
+	var res FindStudioByID
+	if err := c.Client.Post(ctx, FindStudioByIDQuery, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const QueryStudiosQuery = `query QueryStudios ($filter: StudioFilterType!) {
+	queryStudios(studio_filter: $filter) {
+		count
+		studios {
+			... StudioFragment
+		}
+	}
+}
+fragment StudioFragment on Studio {
+	name
+	id
+	urls {
+		... URLFragment
+	}
+	images {
+		... ImageFragment
+	}
+}
+fragment URLFragment on URL {
+	url
+	type
+}
+fragment ImageFragment on Image {
+	id
+	url
+	width
+	height
+}
+`
+
+func (c *Client) QueryStudios(ctx context.Context, filter StudioFilterType, httpRequestOptions ...client.HTTPRequestOption) (*QueryStudios, error) {
+	vars := map[string]interface{}{
+		"filter": filter,
+	}
+
+	var res QueryStudios
+	if err := c.Client.Post(ctx, QueryStudiosQuery, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 const SubmitFingerprintQuery = `mutation SubmitFingerprint ($input: FingerprintSubmission!) {
 	submitFingerprint(input: $input)
 }
@@ -557,3 +821,43 @@ func (c *Client) SubmitFingerprint(ctx context.Context, input FingerprintSubmiss
 
 	return &res, nil
 }
+
+const SubmitSceneDraftQuery = `mutation SubmitSceneDraft ($input: SceneDraftInput!) {
+	submitSceneDraft(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) SubmitSceneDraft(ctx context.Context, input SceneDraftInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitSceneDraftPayload, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res SubmitSceneDraftPayload
+	if err := c.Client.Post(ctx, SubmitSceneDraftQuery, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SubmitPerformerDraftQuery = `mutation SubmitPerformerDraft ($input: PerformerDraftInput!) {
+	submitPerformerDraft(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) SubmitPerformerDraft(ctx context.Context, input PerformerDraftInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitPerformerDraftPayload, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res SubmitPerformerDraftPayload
+	if err := c.Client.Post(ctx, SubmitPerformerDraftQuery, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -7,6 +7,8 @@ import (
 	"io"
 	"strconv"
 	"time"
+
+	graphql1 "github.com/99designs/gqlgen/graphql"
 )
 
 type EditDetails interface {
@@ -51,6 +53,15 @@ type DateCriterionInput struct {
 	Modifier CriterionModifier `json:"modifier"`
 }
 
+type DraftEntityInput struct {
+	Name string  `json:"name"`
+	ID   *string `json:"id"`
+}
+
+type DraftSubmissionStatus struct {
+	ID *string `json:"id"`
+}
+
 type Edit struct {
 	ID   string `json:"id"`
 	User *User  `json:"user"`
@@ -275,6 +286,27 @@ type PerformerDestroyInput struct {
 	ID string `json:"id"`
 }
 
+type PerformerDraftInput struct {
+	ID              *string          `json:"id"`
+	Name            string           `json:"name"`
+	Aliases         *string          `json:"aliases"`
+	Gender          *string          `json:"gender"`
+	Birthdate       *string          `json:"birthdate"`
+	Urls            []string         `json:"urls"`
+	Ethnicity       *string          `json:"ethnicity"`
+	Country         *string          `json:"country"`
+	EyeColor        *string          `json:"eye_color"`
+	HairColor       *string          `json:"hair_color"`
+	Height          *string          `json:"height"`
+	Measurements    *string          `json:"measurements"`
+	BreastType      *string          `json:"breast_type"`
+	Tattoos         *string          `json:"tattoos"`
+	Piercings       *string          `json:"piercings"`
+	CareerStartYear *int             `json:"career_start_year"`
+	CareerEndYear   *int             `json:"career_end_year"`
+	Image           *graphql1.Upload `json:"image"`
+}
+
 type PerformerEdit struct {
 	Name           *string        `json:"name"`
 	Disambiguation *string        `json:"disambiguation"`
@@ -461,6 +493,19 @@ type SceneDestroyInput struct {
 	ID string `json:"id"`
 }
 
+type SceneDraftInput struct {
+	ID           *string             `json:"id"`
+	Title        *string             `json:"title"`
+	Details      *string             `json:"details"`
+	URL          *string             `json:"url"`
+	Date         *string             `json:"date"`
+	Studio       *DraftEntityInput   `json:"studio"`
+	Performers   []*DraftEntityInput `json:"performers"`
+	Tags         []*DraftEntityInput `json:"tags"`
+	Image        *graphql1.Upload    `json:"image"`
+	Fingerprints []*FingerprintInput `json:"fingerprints"`
+}
+
 type SceneEdit struct {
 	Title       *string `json:"title"`
 	Details     *string `json:"details"`
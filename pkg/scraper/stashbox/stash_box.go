@@ -132,6 +132,98 @@ func (c Client) findStashBoxScenesByFingerprints(fingerprints []string) ([]*mode
 	return ret, nil
 }
 
+// QueryStashBoxPerformer queries stash-box for performers using a query
+// string. The performer images are not fetched; FindStashBoxPerformerByID
+// should be used to get the image of the selected performer.
+func (c Client) QueryStashBoxPerformer(queryStr string) ([]*models.ScrapedPerformer, error) {
+	performers, err := c.client.SearchPerformer(context.TODO(), queryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*models.ScrapedPerformer
+	for _, p := range performers.SearchPerformer {
+		ret = append(ret, performerFragmentToScrapedPerformer(*p))
+	}
+
+	return ret, nil
+}
+
+// FindStashBoxPerformerByID queries stash-box for the performer with the
+// provided stash ID, including its image. Returns nil if the performer is
+// not found.
+func (c Client) FindStashBoxPerformerByID(id string) (*models.ScrapedPerformer, error) {
+	performer, err := c.client.FindPerformerByID(context.TODO(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if performer.FindPerformer == nil {
+		return nil, nil
+	}
+
+	ret := performerFragmentToScrapedPerformer(*performer.FindPerformer)
+	if len(performer.FindPerformer.Images) > 0 {
+		ret.Image = getFirstImage(performer.FindPerformer.Images)
+	}
+
+	return ret, nil
+}
+
+// QueryStashBoxStudio queries stash-box for studios using a query string.
+// Studios that exist locally have their ID set.
+func (c Client) QueryStashBoxStudio(queryStr string) ([]*models.ScrapedSceneStudio, error) {
+	studios, err := c.client.QueryStudios(context.TODO(), graphql.StudioFilterType{
+		Name: &queryStr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []*models.ScrapedSceneStudio
+	for _, s := range studios.QueryStudios.Studios {
+		ret = append(ret, studioFragmentToScrapedSceneStudio(s))
+	}
+
+	if err := c.matchStudios(ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// FindStashBoxStudioByID queries stash-box for the studio with the provided
+// stash ID. Returns nil if the studio is not found.
+func (c Client) FindStashBoxStudioByID(id string) (*models.ScrapedSceneStudio, error) {
+	studio, err := c.client.FindStudioByID(context.TODO(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if studio.FindStudio == nil {
+		return nil, nil
+	}
+
+	ret := studioFragmentToScrapedSceneStudio(studio.FindStudio)
+	if err := c.matchStudios([]*models.ScrapedSceneStudio{ret}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (c Client) matchStudios(studios []*models.ScrapedSceneStudio) error {
+	return c.txnManager.WithReadTxn(context.TODO(), func(r models.ReaderRepository) error {
+		for _, s := range studios {
+			if err := scraper.MatchScrapedSceneStudio(r.Studio(), s); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
 func (c Client) SubmitStashBoxFingerprints(sceneIDs []string, endpoint string) (bool, error) {
 	ids, err := utils.StringSliceToIntSlice(sceneIDs)
 	if err != nil {
@@ -356,6 +448,43 @@ func performerFragmentToScrapedScenePerformer(p graphql.PerformerFragment) *mode
 	return sp
 }
 
+func performerFragmentToScrapedPerformer(p graphql.PerformerFragment) *models.ScrapedPerformer {
+	sp := performerFragmentToScrapedScenePerformer(p)
+
+	ret := &models.ScrapedPerformer{
+		Name:         &sp.Name,
+		Gender:       sp.Gender,
+		Twitter:      sp.Twitter,
+		Birthdate:    sp.Birthdate,
+		Ethnicity:    sp.Ethnicity,
+		Country:      sp.Country,
+		EyeColor:     sp.EyeColor,
+		Height:       sp.Height,
+		Measurements: sp.Measurements,
+		FakeTits:     sp.FakeTits,
+		CareerLength: sp.CareerLength,
+		Tattoos:      sp.Tattoos,
+		Piercings:    sp.Piercings,
+		RemoteSiteID: sp.RemoteSiteID,
+	}
+
+	if len(p.Aliases) > 0 {
+		aliases := strings.Join(p.Aliases, ", ")
+		ret.Aliases = &aliases
+	}
+
+	return ret
+}
+
+func studioFragmentToScrapedSceneStudio(s *graphql.StudioFragment) *models.ScrapedSceneStudio {
+	studioID := s.ID
+	return &models.ScrapedSceneStudio{
+		Name:         s.Name,
+		URL:          findURL(s.Urls, "HOME"),
+		RemoteSiteID: &studioID,
+	}
+}
+
 func getFirstImage(images []*graphql.ImageFragment) *string {
 	ret, err := fetchImage(images[0].URL)
 	if err != nil {
@@ -403,12 +532,7 @@ func sceneFragmentToScrapedScene(txnManager models.TransactionManager, s *graphq
 		tqb := r.Tag()
 
 		if s.Studio != nil {
-			studioID := s.Studio.ID
-			ss.Studio = &models.ScrapedSceneStudio{
-				Name:         s.Studio.Name,
-				URL:          findURL(s.Studio.Urls, "HOME"),
-				RemoteSiteID: &studioID,
-			}
+			ss.Studio = studioFragmentToScrapedSceneStudio(s.Studio)
 
 			err := scraper.MatchScrapedSceneStudio(r.Studio(), ss.Studio)
 			if err != nil {
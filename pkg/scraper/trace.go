@@ -0,0 +1,232 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// mappedTrace records the intermediate values of the attributes of a mapped
+// scraper. It is used to test scrapers.
+type mappedTrace struct {
+	attributes []*models.ScraperAttributeTestResult
+}
+
+// attributeTrace records the intermediate values of a single attribute. All
+// methods are no-ops on a nil attributeTrace, so that attributes are only
+// recorded when testing.
+type attributeTrace struct {
+	result *models.ScraperAttributeTestResult
+	trace  *mappedTrace
+	sub    *attributeTrace
+}
+
+func (t *mappedTrace) addAttribute(section string, key string, c mappedScraperAttrConfig) *attributeTrace {
+	if t == nil {
+		return nil
+	}
+
+	ret := &models.ScraperAttributeTestResult{
+		Attribute:   section + "." + key,
+		Matches:     []string{},
+		PostProcess: []*models.ScraperPostProcessResult{},
+		Results:     []string{},
+		Errors:      []string{},
+	}
+
+	if c.Fixed != "" {
+		fixed := c.Fixed
+		ret.Fixed = &fixed
+	}
+
+	t.attributes = append(t.attributes, ret)
+	return &attributeTrace{result: ret, trace: t}
+}
+
+// subScraper returns the trace of the sub-scraper post-process action of
+// the attribute. It is recorded as a separate attribute, with the attribute
+// name suffixed by ".subScraper".
+func (t *attributeTrace) subScraper(c mappedScraperAttrConfig) *attributeTrace {
+	if t == nil {
+		return nil
+	}
+
+	if t.sub == nil {
+		t.sub = t.trace.addAttribute(t.result.Attribute, "subScraper", c)
+	}
+
+	return t.sub
+}
+
+func (t *attributeTrace) setMatches(selector string, matches []string) {
+	if t == nil {
+		return
+	}
+
+	t.result.Selector = &selector
+	t.result.Matches = append(t.result.Matches, matches...)
+}
+
+func (t *attributeTrace) addStep(index int, action postProcessAction, value string) {
+	if t == nil {
+		return
+	}
+
+	// the same steps are applied to each value, so each step is recorded
+	// once, with the value from each application
+	for len(t.result.PostProcess) <= index {
+		t.result.PostProcess = append(t.result.PostProcess, &models.ScraperPostProcessResult{
+			Action: postProcessActionName(action),
+			Values: []string{},
+		})
+	}
+
+	step := t.result.PostProcess[index]
+	step.Values = append(step.Values, value)
+}
+
+func (t *attributeTrace) addError(err error) {
+	if t == nil {
+		return
+	}
+
+	t.result.Errors = append(t.result.Errors, err.Error())
+}
+
+func (t *attributeTrace) addResult(result string) {
+	if t == nil {
+		return
+	}
+
+	t.result.Results = append(t.result.Results, result)
+}
+
+func (t *attributeTrace) setResults(results []string) {
+	if t == nil {
+		return
+	}
+
+	t.result.Results = append([]string{}, results...)
+}
+
+func postProcessActionName(action postProcessAction) string {
+	switch action.(type) {
+	case *postProcessParseDate:
+		return "parseDate"
+	case *postProcessReplace:
+		return "replace"
+	case *postProcessSubScraper:
+		return "subScraper"
+	case *postProcessMap:
+		return "map"
+	case *postProcessFeetToCm:
+		return "feetToCm"
+	}
+
+	return fmt.Sprintf("%T", action)
+}
+
+// trace processes the configuration for the content type, and returns the
+// intermediate values of each attribute.
+func (s mappedScraper) trace(q mappedQuery, contentType models.ScrapeContentType) []*models.ScraperAttributeTestResult {
+	t := &mappedTrace{}
+
+	for _, section := range s.sections(contentType) {
+		section.config.processTrace(q, s.Common, t, section.name)
+	}
+
+	if t.attributes == nil {
+		return []*models.ScraperAttributeTestResult{}
+	}
+
+	return t.attributes
+}
+
+// getTestScraperTypeConfig returns the scraper configuration used to test
+// the content type with the input, and the URL to load.
+func (c config) getTestScraperTypeConfig(contentType models.ScrapeContentType, input models.ScraperTestInput) (*scraperTypeConfig, string, error) {
+	if input.URL != nil && *input.URL != "" {
+		var byURL []*scrapeByURLConfig
+		switch contentType {
+		case models.ScrapeContentTypePerformer:
+			byURL = c.PerformerByURL
+		case models.ScrapeContentTypeScene:
+			byURL = c.SceneByURL
+		case models.ScrapeContentTypeGallery:
+			byURL = c.GalleryByURL
+		case models.ScrapeContentTypeMovie:
+			byURL = c.MovieByURL
+		}
+
+		for _, s := range byURL {
+			if s.matchesURL(*input.URL) {
+				return &s.scraperTypeConfig, replaceURL(*input.URL, s.scraperTypeConfig), nil
+			}
+		}
+
+		return nil, "", fmt.Errorf("no %s by URL configuration matches %s", contentType, *input.URL)
+	}
+
+	if input.Query != nil && *input.Query != "" {
+		var byName *scraperTypeConfig
+		switch contentType {
+		case models.ScrapeContentTypePerformer:
+			byName = c.PerformerByName
+		case models.ScrapeContentTypeScene:
+			byName = c.SceneByName
+		case models.ScrapeContentTypeGallery:
+			byName = c.GalleryByName
+		case models.ScrapeContentTypeMovie:
+			byName = c.MovieByName
+		}
+
+		if byName == nil {
+			return nil, "", fmt.Errorf("no %s by name configuration", contentType)
+		}
+
+		return byName, nameQueryURL(*input.Query, *byName), nil
+	}
+
+	return nil, "", errors.New("one of url or query must be set")
+}
+
+// test runs the xpath or json scraper for the content type with the input,
+// and returns the intermediate values of each attribute.
+func (c config) test(contentType models.ScrapeContentType, input models.ScraperTestInput, txnManager models.TransactionManager, globalConfig GlobalConfig) (*models.ScraperTestResult, error) {
+	typeConfig, u, err := c.getTestScraperTypeConfig(contentType, input)
+	if err != nil {
+		return nil, err
+	}
+
+	var scraper *mappedScraper
+	var q mappedQuery
+
+	switch typeConfig.Action {
+	case scraperActionXPath:
+		s := newXpathScraper(*typeConfig, txnManager, c, globalConfig)
+		doc, mapped, err := s.scrapeURL(u)
+		if err != nil {
+			return nil, err
+		}
+
+		scraper = mapped
+		q = s.getXPathQuery(doc)
+	case scraperActionJson:
+		s := newJsonScraper(*typeConfig, txnManager, c, globalConfig)
+		doc, mapped, err := s.scrapeURL(u)
+		if err != nil {
+			return nil, err
+		}
+
+		scraper = mapped
+		q = s.getJsonQuery(doc)
+	default:
+		return nil, fmt.Errorf("%s scrapers cannot be tested", typeConfig.Action)
+	}
+
+	return &models.ScraperTestResult{
+		URL:        u,
+		Attributes: scraper.trace(q, contentType),
+	}, nil
+}
@@ -0,0 +1,238 @@
+package scraper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestTestScraperXPath(t *testing.T) {
+	const performerHTML = `
+	<h1>  The name  </h1>
+	<span class="height">5'10"</span>
+	<span class="date">invalid date</span>
+	`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, performerHTML)
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+performerByURL:
+  - action: scrapeXPath
+    url:
+      - ` + ts.URL + `
+    scraper: performerScraper
+xPathScrapers:
+  performerScraper:
+    performer:
+      Name:
+        selector: //h1
+        postProcess:
+          - replace:
+              - regex: name
+                with: performer
+      Height:
+        selector: //span[@class="height"]
+        postProcess:
+          - feetToCm: true
+      Birthdate:
+        selector: //span[@class="date"]
+        postProcess:
+          - parseDate: January 2, 2006
+      Gender:
+        fixed: Female
+`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Errorf("Error loading yaml: %s", err.Error())
+		return
+	}
+
+	u := ts.URL + "/performer"
+	result, err := c.test(models.ScrapeContentTypePerformer, models.ScraperTestInput{
+		URL: &u,
+	}, nil, GlobalConfig{})
+
+	if err != nil {
+		t.Errorf("Error testing scraper: %s", err.Error())
+		return
+	}
+
+	assert.Equal(t, u, result.URL)
+
+	attributes := make(map[string]*models.ScraperAttributeTestResult)
+	for _, a := range result.Attributes {
+		attributes[a.Attribute] = a
+	}
+
+	name := attributes["performer.Name"]
+	if assert.NotNil(t, name) {
+		assert.Equal(t, "//h1", *name.Selector)
+		assert.Equal(t, []string{"The name"}, name.Matches)
+		if assert.Len(t, name.PostProcess, 1) {
+			assert.Equal(t, "replace", name.PostProcess[0].Action)
+			assert.Equal(t, []string{"The performer"}, name.PostProcess[0].Values)
+		}
+		assert.Equal(t, []string{"The performer"}, name.Results)
+		assert.Len(t, name.Errors, 0)
+	}
+
+	height := attributes["performer.Height"]
+	if assert.NotNil(t, height) && assert.Len(t, height.PostProcess, 1) {
+		assert.Equal(t, "feetToCm", height.PostProcess[0].Action)
+		assert.Equal(t, []string{"178"}, height.Results)
+	}
+
+	birthdate := attributes["performer.Birthdate"]
+	if assert.NotNil(t, birthdate) {
+		assert.Equal(t, []string{"invalid date"}, birthdate.Matches)
+		assert.Len(t, birthdate.Errors, 1)
+	}
+
+	gender := attributes["performer.Gender"]
+	if assert.NotNil(t, gender) {
+		assert.Equal(t, "Female", *gender.Fixed)
+		assert.Equal(t, []string{"Female"}, gender.Results)
+	}
+
+	// urls that do not match a configuration return an error
+	u = "http://unknown.com"
+	_, err = c.test(models.ScrapeContentTypePerformer, models.ScraperTestInput{
+		URL: &u,
+	}, nil, GlobalConfig{})
+	assert.NotNil(t, err)
+}
+
+func TestTestScraperSubScraper(t *testing.T) {
+	const (
+		performerHTML = `<a href="/name">A link</a>`
+		nameHTML      = `<span>The name</span>`
+	)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/name" {
+			fmt.Fprint(w, nameHTML)
+		} else {
+			fmt.Fprint(w, performerHTML)
+		}
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+performerByURL:
+  - action: scrapeXPath
+    url:
+      - ` + ts.URL + `
+    scraper: performerScraper
+xPathScrapers:
+  performerScraper:
+    performer:
+      Name:
+        selector: //a/@href
+        postProcess:
+          - replace:
+              - regex: ^
+                with: ` + ts.URL + `
+          - subScraper:
+              selector: //span
+              postProcess:
+                - replace:
+                    - regex: name
+                      with: performer
+`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Errorf("Error loading yaml: %s", err.Error())
+		return
+	}
+
+	u := ts.URL + "/performer"
+	result, err := c.test(models.ScrapeContentTypePerformer, models.ScraperTestInput{
+		URL: &u,
+	}, nil, GlobalConfig{})
+
+	if err != nil {
+		t.Errorf("Error testing scraper: %s", err.Error())
+		return
+	}
+
+	attributes := make(map[string]*models.ScraperAttributeTestResult)
+	for _, a := range result.Attributes {
+		attributes[a.Attribute] = a
+	}
+
+	name := attributes["performer.Name"]
+	if assert.NotNil(t, name) && assert.Len(t, name.PostProcess, 2) {
+		assert.Equal(t, "subScraper", name.PostProcess[1].Action)
+		assert.Equal(t, []string{"The performer"}, name.Results)
+	}
+
+	// the sub-scraper is recorded using the parent trace
+	sub := attributes["performer.Name.subScraper"]
+	if assert.NotNil(t, sub) {
+		assert.Equal(t, "//span", *sub.Selector)
+		assert.Equal(t, []string{"The name"}, sub.Matches)
+		if assert.Len(t, sub.PostProcess, 1) {
+			assert.Equal(t, []string{"The performer"}, sub.PostProcess[0].Values)
+		}
+		assert.Equal(t, []string{"The performer"}, sub.Results)
+	}
+}
+
+func TestConfigCheck(t *testing.T) {
+	const yamlStr = `name: Test
+performerByName:
+  action: scrapeXPath
+  scraper: performerScraper
+sceneByURL:
+  - action: scrapeXPath
+    url:
+      - test.com
+    scraper: missingScraper
+sceneByFragment:
+  action: stash
+xPathScrapers:
+  performerScraper:
+    performer:
+      Name: //h1[
+      Aliases:
+        selector: //h2
+        postProcess:
+          - replace:
+              - regex: (
+                with: ""
+      Gender:
+        fixed: Female
+`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Errorf("Error loading yaml: %s", err.Error())
+		return
+	}
+
+	var messages []string
+	for _, err := range c.check() {
+		messages = append(messages, err.Error())
+	}
+
+	if !assert.Len(t, messages, 5) {
+		return
+	}
+
+	assert.Equal(t, "performerByName: queryURL is mandatory for scrapeXPath scraper action", messages[0])
+	assert.Equal(t, "sceneByFragment: stashServer url is mandatory for stash scraper action", messages[1])
+	assert.Equal(t, "sceneByURL[0]: scraper missingScraper not found", messages[2])
+	assert.Contains(t, messages[3], "xPathScrapers.performerScraper.performer.Aliases: invalid regex '('")
+	assert.Contains(t, messages[4], "xPathScrapers.performerScraper.performer.Name: invalid selector '//h1['")
+}
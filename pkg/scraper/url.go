@@ -31,20 +31,57 @@ import (
 const scrapeGetTimeout = time.Second * 60
 const scrapeDefaultSleep = time.Second * 2
 
+// loadURL loads the url, applying the rate limit and response cache of the
+// scraper.
 func loadURL(url string, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
+	cache := responseCache{path: globalConfig.CachePath}
+	ttl := time.Duration(scraperConfig.CacheTTL) * time.Second
+	useCache := ttl > 0 && globalConfig.CachePath != ""
+
+	if useCache {
+		if data := cache.get(scraperConfig.ID, url, ttl); data != nil {
+			logger.Debugf("Using cached response for %s", url)
+			return bytes.NewReader(data), nil
+		}
+	}
+
+	if scraperConfig.limiter != nil {
+		scraperConfig.limiter.wait()
+	}
+
+	r, err := fetchURL(url, scraperConfig, globalConfig)
+	if err != nil || !useCache {
+		return r, err
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.set(scraperConfig.ID, url, data, ttl)
+
+	return bytes.NewReader(data), nil
+}
+
+func fetchURL(url string, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
 	driverOptions := scraperConfig.DriverOptions
 	if driverOptions != nil && driverOptions.UseCDP {
 		// get the page using chrome dp
 		return urlFromCDP(url, *driverOptions, globalConfig)
 	}
 
-	// get the page using http.Client
-	options := cookiejar.Options{
-		PublicSuffixList: publicsuffix.List,
-	}
-	jar, er := cookiejar.New(&options)
-	if er != nil {
-		return nil, er
+	// get the page using http.Client. Scrapers that log in keep their cookie
+	// jar between requests.
+	var jar *cookiejar.Jar
+	if scraperConfig.session != nil {
+		jar = scraperConfig.session.jar
+	} else {
+		var err error
+		jar, err = newCookieJar()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	setCookies(jar, scraperConfig)
@@ -65,12 +102,45 @@ func loadURL(url string, scraperConfig config, globalConfig GlobalConfig) (io.Re
 		Jar: jar,
 	}
 
+	var resp *http.Response
+	var err error
+	if scraperConfig.session != nil {
+		resp, err = scraperConfig.session.get(client, url, globalConfig)
+	} else {
+		resp, err = getURL(client, url, globalConfig.UserAgent)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	bodyReader := bytes.NewReader(body)
+	printCookies(jar, scraperConfig, "Jar cookies found for scraper urls")
+
+	return charset.NewReader(bodyReader, resp.Header.Get("Content-Type"))
+}
+
+func newCookieJar() (*cookiejar.Jar, error) {
+	options := cookiejar.Options{
+		PublicSuffixList: publicsuffix.List,
+	}
+	return cookiejar.New(&options)
+}
+
+// getURL sends a GET request for the url. Returns an error if the response
+// has an error status.
+func getURL(client *http.Client, url string, userAgent string) (*http.Response, error) {
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	userAgent := globalConfig.UserAgent
 	if userAgent != "" {
 		req.Header.Set("User-Agent", userAgent)
 	}
@@ -80,20 +150,11 @@ func loadURL(url string, scraperConfig config, globalConfig GlobalConfig) (io.Re
 		return nil, err
 	}
 	if resp.StatusCode >= 400 {
+		resp.Body.Close()
 		return nil, fmt.Errorf("http error %d", resp.StatusCode)
 	}
 
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	bodyReader := bytes.NewReader(body)
-	printCookies(jar, scraperConfig, "Jar cookies found for scraper urls")
-
-	return charset.NewReader(bodyReader, resp.Header.Get("Content-Type"))
+	return resp, nil
 }
 
 // func urlFromCDP uses chrome cdp and DOM to load and process the url
@@ -0,0 +1,232 @@
+package scraper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// the name of the directory in the cache path where scraper responses are
+// stored
+const responseCacheDir = "scrapers"
+
+type scraperRateLimit struct {
+	// The maximum number of requests made in each interval
+	Requests int `yaml:"requests"`
+	// The length of the interval in seconds
+	Interval int `yaml:"interval"`
+}
+
+// rateLimiter limits the number of requests made by a scraper within a
+// sliding interval.
+type rateLimiter struct {
+	requests int
+	interval time.Duration
+
+	mutex sync.Mutex
+	// times of the most recent requests, oldest first
+	times []time.Time
+}
+
+func newRateLimiter(l scraperRateLimit) *rateLimiter {
+	return &rateLimiter{
+		requests: l.Requests,
+		interval: time.Duration(l.Interval) * time.Second,
+	}
+}
+
+func (l *rateLimiter) matches(c scraperRateLimit) bool {
+	return l.requests == c.Requests && l.interval == time.Duration(c.Interval)*time.Second
+}
+
+// reserve reserves the next available request time and returns the amount
+// of time to wait from now until the request may be made.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	at := now
+	if len(l.times) >= l.requests {
+		next := l.times[len(l.times)-l.requests].Add(l.interval)
+		if next.After(at) {
+			at = next
+		}
+	}
+
+	l.times = append(l.times, at)
+	if len(l.times) > l.requests {
+		l.times = l.times[len(l.times)-l.requests:]
+	}
+
+	return at.Sub(now)
+}
+
+// wait blocks until a request may be made without exceeding the rate limit.
+func (l *rateLimiter) wait() {
+	if d := l.reserve(time.Now()); d > 0 {
+		logger.Debugf("Rate limit reached, waiting %s", d)
+		time.Sleep(d)
+	}
+}
+
+// responseCachePruneInterval is the minimum time between removing the
+// expired responses of a scraper when responses are cached.
+const responseCachePruneInterval = time.Hour
+
+// lastResponseCachePrune holds the time that the expired responses of each
+// scraper directory were last removed.
+var lastResponseCachePrune = struct {
+	sync.Mutex
+	times map[string]time.Time
+}{
+	times: make(map[string]time.Time),
+}
+
+// responseCache stores scraper responses on disk. Responses are stored in a
+// separate directory for each scraper. Responses expire after the cache TTL
+// of the scraper, and expired responses are removed when responses are
+// cached and when the scrapers are loaded.
+type responseCache struct {
+	path string
+}
+
+func (c responseCache) scraperDir(scraperID string) string {
+	return filepath.Join(c.path, responseCacheDir, scraperID)
+}
+
+func (c responseCache) filename(scraperID string, url string) string {
+	return filepath.Join(c.scraperDir(scraperID), utils.MD5FromString(url))
+}
+
+// get returns the cached response for the url, or nil if there is no cached
+// response that is newer than the ttl.
+func (c responseCache) get(scraperID string, url string, ttl time.Duration) []byte {
+	fn := c.filename(scraperID, url)
+	info, err := os.Stat(fn)
+	if err != nil {
+		return nil
+	}
+
+	if time.Since(info.ModTime()) > ttl {
+		c.remove(fn)
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(fn)
+	if err != nil {
+		logger.Warnf("error reading cached response for %s: %s", url, err.Error())
+		return nil
+	}
+
+	return data
+}
+
+// set caches the response for the url. The expired responses of the scraper
+// are removed if they have not been removed within the prune interval.
+func (c responseCache) set(scraperID string, url string, data []byte, ttl time.Duration) {
+	if err := utils.EnsureDirAll(c.scraperDir(scraperID)); err != nil {
+		logger.Warnf("error creating scraper cache directory: %s", err.Error())
+		return
+	}
+
+	if err := ioutil.WriteFile(c.filename(scraperID, url), data, 0644); err != nil {
+		logger.Warnf("error caching response for %s: %s", url, err.Error())
+	}
+
+	if c.pruneDue(scraperID, time.Now()) {
+		c.prune(scraperID, ttl)
+	}
+}
+
+// pruneDue returns true if the expired responses of the scraper have not been
+// removed within the prune interval, and records now as the prune time if so.
+func (c responseCache) pruneDue(scraperID string, now time.Time) bool {
+	lastResponseCachePrune.Lock()
+	defer lastResponseCachePrune.Unlock()
+
+	dir := c.scraperDir(scraperID)
+	if last, found := lastResponseCachePrune.times[dir]; found && now.Sub(last) < responseCachePruneInterval {
+		return false
+	}
+
+	lastResponseCachePrune.times[dir] = now
+	return true
+}
+
+// prune removes the cached responses of the scraper that are older than the
+// ttl.
+func (c responseCache) prune(scraperID string, ttl time.Duration) {
+	dir := c.scraperDir(scraperID)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warnf("error reading scraper cache directory: %s", err.Error())
+		}
+		return
+	}
+
+	for _, f := range files {
+		if !f.IsDir() && time.Since(f.ModTime()) > ttl {
+			c.remove(filepath.Join(dir, f.Name()))
+		}
+	}
+}
+
+// pruneAll removes the expired responses of the scrapers with the provided
+// cache TTLs, by scraper ID. The responses of scrapers that are not in ttls,
+// or that no longer cache responses, are removed entirely.
+func (c responseCache) pruneAll(ttls map[string]time.Duration) {
+	cacheDir := filepath.Join(c.path, responseCacheDir)
+	dirs, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warnf("error reading scraper cache directory: %s", err.Error())
+		}
+		return
+	}
+
+	for _, d := range dirs {
+		ttl := ttls[d.Name()]
+		if !d.IsDir() || ttl <= 0 {
+			c.remove(filepath.Join(cacheDir, d.Name()))
+			continue
+		}
+
+		c.prune(d.Name(), ttl)
+	}
+}
+
+func (c responseCache) remove(fn string) {
+	if err := os.RemoveAll(fn); err != nil {
+		logger.Warnf("error removing cached response %s: %s", fn, err.Error())
+	}
+}
+
+// clear removes the cached responses for the scraper. If scraperID is
+// empty, then the cached responses for all scrapers are removed.
+func (c responseCache) clear(scraperID string) error {
+	cacheDir := filepath.Join(c.path, responseCacheDir)
+	if scraperID == "" {
+		return os.RemoveAll(cacheDir)
+	}
+
+	// scraper IDs are the names of the configuration files, so they cannot
+	// refer to anything other than a directory in the cache directory
+	if strings.ContainsAny(scraperID, `/\`) || strings.Contains(scraperID, "..") {
+		return fmt.Errorf("invalid scraper ID %s", scraperID)
+	}
+
+	dir := c.scraperDir(scraperID)
+	if filepath.Dir(dir) != cacheDir {
+		return fmt.Errorf("invalid scraper ID %s", scraperID)
+	}
+
+	return os.RemoveAll(dir)
+}
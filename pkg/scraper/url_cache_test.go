@@ -0,0 +1,186 @@
+package scraper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	l := newRateLimiter(scraperRateLimit{
+		Requests: 2,
+		Interval: 10,
+	})
+
+	now := time.Now()
+
+	assert.Equal(t, time.Duration(0), l.reserve(now))
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(time.Second)))
+
+	// third request must wait until the first is outside the interval
+	assert.Equal(t, 9*time.Second, l.reserve(now.Add(time.Second)))
+
+	// fourth request must wait until the second is outside the interval
+	assert.Equal(t, 10*time.Second, l.reserve(now.Add(time.Second)))
+
+	assert.Equal(t, time.Duration(0), l.reserve(now.Add(time.Minute)))
+}
+
+func TestLoadURLCache(t *testing.T) {
+	cachePath, err := ioutil.TempDir("", "stash-scraper-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cachePath)
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, "response %d", requests)
+	}))
+	defer ts.Close()
+
+	c := config{
+		ID:       "test",
+		CacheTTL: 60,
+	}
+	globalConfig := GlobalConfig{
+		CachePath: cachePath,
+	}
+
+	load := func() string {
+		r, err := loadURL(ts.URL, c, globalConfig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data, _ := ioutil.ReadAll(r)
+		return string(data)
+	}
+
+	assert.Equal(t, "response 1", load())
+	assert.Equal(t, "response 1", load())
+
+	cache := Cache{
+		scrapers:     []config{c},
+		globalConfig: globalConfig,
+	}
+	if err := cache.ClearResponseCache(c.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "response 2", load())
+
+	// responses are not cached if the ttl is not set
+	c.CacheTTL = 0
+	assert.Equal(t, "response 3", load())
+	assert.Equal(t, "response 4", load())
+}
+
+func TestClearResponseCache(t *testing.T) {
+	cachePath, err := ioutil.TempDir("", "stash-scraper-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cachePath)
+
+	// a directory outside of the scraper cache directory
+	outside := filepath.Join(cachePath, "outside")
+	if err := os.Mkdir(outside, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := Cache{
+		scrapers: []config{
+			{ID: "test"},
+			{ID: "../outside"},
+		},
+		globalConfig: GlobalConfig{
+			CachePath: cachePath,
+		},
+	}
+
+	assert.Nil(t, cache.ClearResponseCache("test"))
+
+	// unknown scrapers are rejected
+	assert.NotNil(t, cache.ClearResponseCache("missing"))
+	assert.NotNil(t, cache.ClearResponseCache("../../.."))
+
+	// paths outside of the cache directory are rejected, even if they match
+	// a scraper ID
+	assert.NotNil(t, cache.ClearResponseCache("../outside"))
+
+	rc := responseCache{path: cachePath}
+	for _, id := range []string{"..", "../outside", "a/b", `a\b`, "."} {
+		assert.NotNil(t, rc.clear(id), id)
+	}
+
+	_, err = os.Stat(outside)
+	assert.Nil(t, err)
+}
+
+func TestResponseCachePrune(t *testing.T) {
+	cachePath, err := ioutil.TempDir("", "stash-scraper-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cachePath)
+
+	rc := responseCache{path: cachePath}
+	ttl := time.Hour
+	old := time.Now().Add(-2 * ttl)
+
+	write := func(scraperID string, url string, modTime time.Time) string {
+		if err := os.MkdirAll(rc.scraperDir(scraperID), 0755); err != nil {
+			t.Fatal(err)
+		}
+
+		fn := rc.filename(scraperID, url)
+		if err := ioutil.WriteFile(fn, []byte(url), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(fn, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+
+		return fn
+	}
+
+	exists := func(fn string) bool {
+		_, err := os.Stat(fn)
+		return err == nil
+	}
+
+	// expired responses are removed when read
+	expired := write("test", "expired", old)
+	assert.Nil(t, rc.get("test", "expired", ttl))
+	assert.False(t, exists(expired))
+
+	// expired responses are removed for each scraper, and the responses of
+	// scrapers that are not caching are removed entirely
+	expired = write("test", "expired", old)
+	current := write("test", "current", time.Now())
+	removed := write("removed", "current", time.Now())
+
+	rc.pruneAll(map[string]time.Duration{
+		"test": ttl,
+	})
+
+	assert.False(t, exists(expired))
+	assert.True(t, exists(current))
+	assert.False(t, exists(rc.scraperDir("removed")))
+	assert.False(t, exists(removed))
+
+	// responses are pruned at most once in the prune interval
+	now := time.Now()
+	assert.True(t, rc.pruneDue("test", now))
+	assert.False(t, rc.pruneDue("test", now.Add(time.Minute)))
+	assert.True(t, rc.pruneDue("test", now.Add(responseCachePruneInterval)))
+}
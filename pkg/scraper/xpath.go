@@ -3,7 +3,6 @@ package scraper
 import (
 	"bytes"
 	"errors"
-	"net/url"
 	"regexp"
 	"strings"
 
@@ -96,34 +95,90 @@ func (s *xpathScraper) scrapeMovieByURL(url string) (*models.ScrapedMovie, error
 }
 
 func (s *xpathScraper) scrapePerformersByName(name string) ([]*models.ScrapedPerformer, error) {
-	scraper := s.getXpathScraper()
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
+	}
 
-	if scraper == nil {
-		return nil, errors.New("xpath scraper with name " + s.scraper.Scraper + " not found in config")
+	q := s.getXPathQuery(doc)
+	return scraper.scrapePerformers(q)
+}
+
+func (s *xpathScraper) scrapeGalleriesByName(name string) ([]*models.ScrapedGallery, error) {
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
 	}
 
-	const placeholder = "{}"
+	q := s.getXPathQuery(doc)
+	return scraper.scrapeGalleries(q)
+}
 
-	// replace the placeholder string with the URL-escaped name
-	escapedName := url.QueryEscape(name)
+func (s *xpathScraper) scrapeMoviesByName(name string) ([]*models.ScrapedMovie, error) {
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
+	}
 
-	url := s.scraper.QueryURL
-	url = strings.Replace(url, placeholder, escapedName, -1)
+	q := s.getXPathQuery(doc)
+	return scraper.scrapeMovies(q)
+}
 
-	doc, err := s.loadURL(url)
+func (s *xpathScraper) scrapeMovieByFragment(movie models.ScrapedMovieInput) (*models.ScrapedMovie, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedMovie(movie)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
 
+	doc, scraper, err := s.scrapeURL(url)
 	if err != nil {
 		return nil, err
 	}
 
 	q := s.getXPathQuery(doc)
-	return scraper.scrapePerformers(q)
+	return scraper.scrapeMovie(q)
 }
 
 func (s *xpathScraper) scrapePerformerByFragment(scrapedPerformer models.ScrapedPerformerInput) (*models.ScrapedPerformer, error) {
 	return nil, errors.New("scrapePerformerByFragment not supported for xpath scraper")
 }
 
+func (s *xpathScraper) scrapeScenesByName(name string) ([]*models.ScrapedScene, error) {
+	doc, scraper, err := s.scrapeURL(nameQueryURL(name, s.scraper))
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getXPathQuery(doc)
+	return scraper.scrapeScenes(q)
+}
+
+func (s *xpathScraper) scrapeSceneByScene(scene models.ScrapedSceneInput) (*models.ScrapedScene, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedScene(scene)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getXpathScraper()
+
+	if scraper == nil {
+		return nil, errors.New("xpath scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getXPathQuery(doc)
+	return scraper.scrapeScene(q)
+}
+
 func (s *xpathScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*models.ScrapedScene, error) {
 	storedScene, err := sceneFromUpdateFragment(scene, s.txnManager)
 	if err != nil {
@@ -157,6 +212,23 @@ func (s *xpathScraper) scrapeSceneByFragment(scene models.SceneUpdateInput) (*mo
 	return scraper.scrapeScene(q)
 }
 
+func (s *xpathScraper) scrapeGalleryByGallery(gallery models.ScrapedGalleryInput) (*models.ScrapedGallery, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedGallery(gallery)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	doc, scraper, err := s.scrapeURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getXPathQuery(doc)
+	return scraper.scrapeGallery(q)
+}
+
 func (s *xpathScraper) scrapeGalleryByFragment(gallery models.GalleryUpdateInput) (*models.ScrapedGallery, error) {
 	storedGallery, err := galleryFromUpdateFragment(gallery, s.txnManager)
 	if err != nil {
@@ -219,11 +291,10 @@ type xpathQuery struct {
 	scraper *xpathScraper
 }
 
-func (q *xpathQuery) runQuery(selector string) []string {
+func (q *xpathQuery) runQuery(selector string) ([]string, error) {
 	found, err := htmlquery.QueryAll(q.doc, selector)
 	if err != nil {
-		logger.Warnf("Error parsing xpath expression '%s': %s", selector, err.Error())
-		return nil
+		return nil, err
 	}
 
 	var ret []string
@@ -235,7 +306,7 @@ func (q *xpathQuery) runQuery(selector string) []string {
 		}
 	}
 
-	return ret
+	return ret, nil
 }
 
 func (q *xpathQuery) nodeText(n *html.Node) string {
@@ -260,13 +331,11 @@ func (q *xpathQuery) nodeText(n *html.Node) string {
 	return ret
 }
 
-func (q *xpathQuery) subScrape(value string) mappedQuery {
+func (q *xpathQuery) subScrape(value string) (mappedQuery, error) {
 	doc, err := q.scraper.loadURL(value)
-
 	if err != nil {
-		logger.Warnf("Error getting URL '%s' for sub-scraper: %s", value, err.Error())
-		return nil
+		return nil, err
 	}
 
-	return q.scraper.getXPathQuery(doc)
+	return q.scraper.getXPathQuery(doc), nil
 }
@@ -816,3 +816,95 @@ xPathScrapers:
 
 	verifyField(t, "The name", performer.Name, "Name")
 }
+
+func TestSceneQueryXPath(t *testing.T) {
+	searchHTML := `
+	<div class="result">
+		<a href="/scene/1">First scene</a>
+	</div>
+	<div class="result">
+		<a href="/scene/2">Second scene</a>
+	</div>
+	`
+
+	sceneHTML := `
+	<h1>Second scene</h1>
+	<span class="performer">The performer</span>
+	`
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scene/2" {
+			fmt.Fprint(w, sceneHTML)
+		} else {
+			fmt.Fprint(w, searchHTML)
+		}
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+sceneByName:
+  action: scrapeXPath
+  queryURL: ` + ts.URL + `/search?q={}
+  scraper: sceneSearch
+sceneByQueryFragment:
+  action: scrapeXPath
+  queryURL: "{url}"
+  scraper: sceneScraper
+xPathScrapers:
+  sceneSearch:
+    scene:
+      Title: //div[@class="result"]/a
+      URL:
+        selector: //div[@class="result"]/a/@href
+        postProcess:
+          - replace:
+              - regex: ^
+                with: ` + ts.URL + `
+  sceneScraper:
+    scene:
+      Title: //h1
+      Performers:
+        Name: //span[@class="performer"]
+`
+
+	c := &config{}
+	err := yaml.Unmarshal([]byte(yamlStr), &c)
+
+	if err != nil {
+		t.Errorf("Error loading yaml: %s", err.Error())
+		return
+	}
+
+	assert.Contains(t, c.toScraper().Scene.SupportedScrapes, models.ScrapeTypeName)
+
+	globalConfig := GlobalConfig{}
+
+	scenes, err := c.ScrapeSceneQuery("scene", nil, globalConfig)
+
+	if err != nil {
+		t.Errorf("Error querying scenes: %s", err.Error())
+		return
+	}
+
+	if !assert.Len(t, scenes, 2) {
+		return
+	}
+
+	verifyField(t, "First scene", scenes[0].Title, "Title")
+	verifyField(t, ts.URL+"/scene/2", scenes[1].URL, "URL")
+
+	scene, err := c.ScrapeSceneQueryFragment(models.ScrapedSceneInput{
+		Title: scenes[1].Title,
+		URL:   scenes[1].URL,
+	}, nil, globalConfig)
+
+	if err != nil {
+		t.Errorf("Error scraping scene: %s", err.Error())
+		return
+	}
+
+	verifyField(t, "Second scene", scene.Title, "Title")
+	if assert.Len(t, scene.Performers, 1) {
+		assert.Equal(t, "The performer", scene.Performers[0].Name)
+	}
+}
@@ -0,0 +1,116 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const fileTable = "files"
+
+type fileQueryBuilder struct {
+	repository
+}
+
+func NewFileReaderWriter(tx dbi) *fileQueryBuilder {
+	return &fileQueryBuilder{
+		repository{
+			tx:        tx,
+			tableName: fileTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+func (qb *fileQueryBuilder) Create(newObject models.File) (*models.File, error) {
+	var ret models.File
+	if err := qb.insertObject(newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *fileQueryBuilder) Update(updatedObject models.File) (*models.File, error) {
+	const partial = false
+	if err := qb.update(updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	return qb.find(updatedObject.ID)
+}
+
+func (qb *fileQueryBuilder) Destroy(id int) error {
+	return qb.destroyExisting([]int{id})
+}
+
+func (qb *fileQueryBuilder) Find(id int) (*models.File, error) {
+	return qb.find(id)
+}
+
+func (qb *fileQueryBuilder) find(id int) (*models.File, error) {
+	var ret models.File
+	if err := qb.get(id, &ret); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// FindBySceneID returns the files of the scene, with the primary file first.
+func (qb *fileQueryBuilder) FindBySceneID(sceneID int) ([]*models.File, error) {
+	query := selectAll(fileTable) + "WHERE scene_id = ? ORDER BY is_primary DESC, id ASC"
+	args := []interface{}{sceneID}
+	return qb.queryFiles(query, args)
+}
+
+func (qb *fileQueryBuilder) FindByPath(path string) (*models.File, error) {
+	query := selectAll(fileTable) + "WHERE path = ? LIMIT 1"
+	args := []interface{}{path}
+	return qb.queryFile(query, args)
+}
+
+// FindByChecksum returns the files with the provided MD5 checksum, with
+// primary files first.
+func (qb *fileQueryBuilder) FindByChecksum(checksum string) ([]*models.File, error) {
+	query := selectAll(fileTable) + "WHERE checksum = ? ORDER BY is_primary DESC, id ASC"
+	args := []interface{}{checksum}
+	return qb.queryFiles(query, args)
+}
+
+// FindByOSHash returns the files with the provided oshash, with primary
+// files first.
+func (qb *fileQueryBuilder) FindByOSHash(oshash string) ([]*models.File, error) {
+	query := selectAll(fileTable) + "WHERE oshash = ? ORDER BY is_primary DESC, id ASC"
+	args := []interface{}{oshash}
+	return qb.queryFiles(query, args)
+}
+
+func (qb *fileQueryBuilder) All() ([]*models.File, error) {
+	return qb.queryFiles(selectAll(fileTable)+"ORDER BY id ASC", nil)
+}
+
+func (qb *fileQueryBuilder) findPrimary(sceneID int) (*models.File, error) {
+	query := selectAll(fileTable) + "WHERE scene_id = ? AND is_primary = 1 LIMIT 1"
+	args := []interface{}{sceneID}
+	return qb.queryFile(query, args)
+}
+
+func (qb *fileQueryBuilder) queryFile(query string, args []interface{}) (*models.File, error) {
+	results, err := qb.queryFiles(query, args)
+	if err != nil || len(results) < 1 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (qb *fileQueryBuilder) queryFiles(query string, args []interface{}) ([]*models.File, error) {
+	var ret models.Files
+	if err := qb.query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.File(ret), nil
+}
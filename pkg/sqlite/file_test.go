@@ -0,0 +1,218 @@
+// +build integration
+
+package sqlite_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+func TestFileFindBySceneID(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		fqb := r.File()
+
+		sceneID := sceneIDs[sceneIdxWithMovie]
+		files, err := fqb.FindBySceneID(sceneID)
+		if err != nil {
+			t.Errorf("Error finding files: %s", err.Error())
+		}
+
+		// scenes are created with a primary file
+		assert.Len(t, files, 1)
+		file := files[0]
+		assert.True(t, file.Primary)
+		assert.Equal(t, getSceneStringValue(sceneIdxWithMovie, pathField), file.Path)
+		assert.Equal(t, getSceneStringValue(sceneIdxWithMovie, checksumField), file.Checksum.String)
+
+		files, err = fqb.FindBySceneID(0)
+		if err != nil {
+			t.Errorf("Error finding files: %s", err.Error())
+		}
+
+		assert.Len(t, files, 0)
+
+		return nil
+	})
+}
+
+func TestFileFindByPath(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		fqb := r.File()
+
+		path := getSceneStringValue(sceneIdxWithGallery, pathField)
+		file, err := fqb.FindByPath(path)
+		if err != nil {
+			t.Errorf("Error finding file: %s", err.Error())
+		}
+
+		assert.Equal(t, sceneIDs[sceneIdxWithGallery], file.SceneID)
+
+		file, err = fqb.FindByPath("not exist")
+		if err != nil {
+			t.Errorf("Error finding file: %s", err.Error())
+		}
+
+		assert.Nil(t, file)
+
+		return nil
+	})
+}
+
+func TestFileSetPrimary(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Scene()
+		fqb := r.File()
+
+		const name = "TestFileSetPrimary"
+		created, err := qb.Create(models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+
+		const otherName = name + "_other"
+		other, err := fqb.Create(models.File{
+			SceneID:  created.ID,
+			Path:     otherName,
+			Checksum: sql.NullString{String: utils.MD5FromString(otherName), Valid: true},
+			Height:   sql.NullInt64{Int64: 2160, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating file: %s", err.Error())
+		}
+
+		updated, err := scene.SetPrimaryFile(qb, fqb, created.ID, other.ID)
+		if err != nil {
+			return fmt.Errorf("Error setting primary file: %s", err.Error())
+		}
+
+		// scene file details are those of the primary file
+		assert.Equal(t, otherName, updated.Path)
+		assert.Equal(t, other.Checksum, updated.Checksum)
+		assert.Equal(t, other.Height, updated.Height)
+
+		files, err := fqb.FindBySceneID(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding files: %s", err.Error())
+		}
+
+		assert.Len(t, files, 2)
+		assert.Equal(t, other.ID, files[0].ID)
+		assert.True(t, files[0].Primary)
+		assert.Equal(t, name, files[1].Path)
+		assert.False(t, files[1].Primary)
+
+		// updating the scene file details updates the primary file
+		const movedName = otherName + "_moved"
+		if _, err := scene.UpdateMovedFile(qb, created.ID, movedName, models.NullSQLiteTimestamp{}); err != nil {
+			return fmt.Errorf("Error updating scene: %s", err.Error())
+		}
+
+		primary, err := fqb.Find(other.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding file: %s", err.Error())
+		}
+		assert.Equal(t, movedName, primary.Path)
+
+		// setting a file of another scene should fail
+		if _, err := scene.SetPrimaryFile(qb, fqb, sceneIDs[sceneIdxWithMovie], other.ID); err == nil {
+			return fmt.Errorf("Expected error setting file of another scene")
+		}
+
+		// files are deleted with the scene
+		if err := qb.Destroy(created.ID); err != nil {
+			return fmt.Errorf("Error destroying scene: %s", err.Error())
+		}
+
+		files, err = fqb.FindBySceneID(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding files: %s", err.Error())
+		}
+		assert.Len(t, files, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestFileFindByHash(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Scene()
+		fqb := r.File()
+
+		const name = "TestFileFindByHash"
+		checksum := utils.MD5FromString(name)
+		const oshash = "TestFileFindByHashOSHash"
+		created, err := qb.Create(models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: checksum, Valid: true},
+			OSHash:   sql.NullString{String: oshash, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+
+		const otherName = name + "_other"
+		other, err := fqb.Create(models.File{
+			SceneID:  created.ID,
+			Path:     otherName,
+			Checksum: sql.NullString{String: checksum, Valid: true},
+			OSHash:   sql.NullString{String: oshash, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating file: %s", err.Error())
+		}
+
+		// secondary files are found as well as primary files
+		files, err := fqb.FindByOSHash(oshash)
+		if err != nil {
+			return fmt.Errorf("Error finding files: %s", err.Error())
+		}
+		if assert.Len(t, files, 2) {
+			assert.Equal(t, name, files[0].Path)
+			assert.True(t, files[0].Primary)
+			assert.Equal(t, other.ID, files[1].ID)
+		}
+
+		files, err = fqb.FindByChecksum(checksum)
+		if err != nil {
+			return fmt.Errorf("Error finding files: %s", err.Error())
+		}
+		assert.Len(t, files, 2)
+
+		// moving the secondary file does not change the scene path
+		const movedName = otherName + "_moved"
+		moved, err := scene.UpdateMovedSecondaryFile(fqb, *other, movedName, models.NullSQLiteTimestamp{})
+		if err != nil {
+			return fmt.Errorf("Error updating file: %s", err.Error())
+		}
+		assert.Equal(t, movedName, moved.Path)
+		assert.False(t, moved.Primary)
+
+		s, err := qb.Find(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding scene: %s", err.Error())
+		}
+		assert.Equal(t, name, s.Path)
+
+		files, err = fqb.FindByOSHash("not exist")
+		if err != nil {
+			return fmt.Errorf("Error finding files: %s", err.Error())
+		}
+		assert.Len(t, files, 0)
+
+		return qb.Destroy(created.ID)
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 type sqlClause struct {
@@ -349,6 +351,29 @@ func intCriterionHandler(c *models.IntCriterionInput, column string) criterionHa
 	}
 }
 
+// timestampCriterionHandler compares the timestamps in the column with the
+// date or date and time of the criterion.
+func timestampCriterionHandler(c *models.TimestampCriterionInput, column string) criterionHandlerFunc {
+	return func(f *filterBuilder) {
+		if c != nil {
+			clause, count := getSimpleCriterionClause(c.Modifier, "?")
+
+			if count == 1 {
+				t, err := utils.ParseDateStringAsTime(c.Value)
+				if err != nil {
+					f.setError(fmt.Errorf("invalid timestamp %s: %s", c.Value, err.Error()))
+					return
+				}
+
+				// datetime converts the stored timestamps to UTC
+				f.addWhere("datetime("+column+") "+clause, t.UTC().Format("2006-01-02 15:04:05"))
+			} else {
+				f.addWhere(column + " " + clause)
+			}
+		}
+	}
+}
+
 func boolCriterionHandler(c *bool, column string) criterionHandlerFunc {
 	return func(f *filterBuilder) {
 		if c != nil {
@@ -401,3 +426,156 @@ func (m *multiCriterionHandlerBuilder) handler(criterion *models.MultiCriterionI
 		}
 	}
 }
+
+// hierarchicalMultiCriterionHandlerBuilder handles criteria on a
+// many-to-many relationship with a hierarchical foreign table, such as tags.
+// The criterion values are expanded to include their descendants, up to the
+// depth of the criterion.
+type hierarchicalMultiCriterionHandlerBuilder struct {
+	tx dbi
+
+	primaryTable string
+	joinTable    string
+	primaryFK    string
+	foreignFK    string
+
+	// table containing the parent/child relationships of the foreign table.
+	// The depth walks from parentFK to childFK, so swapping them walks the
+	// ancestors of the values instead of their descendants.
+	relationsTable string
+	parentFK       string
+	childFK        string
+
+	// optional column in the primary table that also references the foreign
+	// table
+	primaryColumn string
+}
+
+func (m *hierarchicalMultiCriterionHandlerBuilder) handler(criterion *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
+	return func(f *filterBuilder) {
+		clause, err := m.whereClause(criterion)
+		if err != nil {
+			f.setError(err)
+			return
+		}
+
+		f.addWhere(clause)
+	}
+}
+
+// whereClause returns the where clause for the criterion, or an empty string
+// if the criterion is not set. The IS_NULL and NOT_NULL modifiers match rows
+// that reference none or some of the foreign table, and ignore the values.
+func (m *hierarchicalMultiCriterionHandlerBuilder) whereClause(criterion *models.HierarchicalMultiCriterionInput) (string, error) {
+	if criterion == nil {
+		return "", nil
+	}
+
+	switch criterion.Modifier {
+	case models.CriterionModifierIsNull:
+		return "NOT " + m.anyClause(), nil
+	case models.CriterionModifierNotNull:
+		return m.anyClause(), nil
+	}
+
+	if len(criterion.Value) == 0 {
+		return "", nil
+	}
+
+	ids, err := utils.StringSliceToIntSlice(criterion.Value)
+	if err != nil {
+		return "", err
+	}
+
+	depth := 0
+	if criterion.Depth != nil {
+		depth = *criterion.Depth
+	}
+
+	switch criterion.Modifier {
+	case models.CriterionModifierIncludes:
+		all, err := m.getDescendantIDs(ids, depth)
+		if err != nil {
+			return "", err
+		}
+		return m.containsClause(all), nil
+	case models.CriterionModifierIncludesAll:
+		// must contain each value or one of its descendants
+		var clauses []string
+		for _, id := range ids {
+			all, err := m.getDescendantIDs([]int{id}, depth)
+			if err != nil {
+				return "", err
+			}
+			clauses = append(clauses, m.containsClause(all))
+		}
+		return "(" + strings.Join(clauses, " AND ") + ")", nil
+	case models.CriterionModifierExcludes:
+		all, err := m.getDescendantIDs(ids, depth)
+		if err != nil {
+			return "", err
+		}
+		return "NOT " + m.containsClause(all), nil
+	}
+
+	return "", fmt.Errorf("invalid modifier %s for hierarchical criterion", criterion.Modifier)
+}
+
+// containsClause returns a clause that matches rows of the primary table that
+// reference any of the provided foreign ids. The ids are integers, so they are
+// written directly into the clause.
+func (m *hierarchicalMultiCriterionHandlerBuilder) containsClause(ids []int) string {
+	var idStrs []string
+	for _, id := range ids {
+		idStrs = append(idStrs, strconv.Itoa(id))
+	}
+	in := "(" + strings.Join(idStrs, ", ") + ")"
+
+	clause := fmt.Sprintf("%s.id IN (SELECT %s FROM %s WHERE %s IN %s)", m.primaryTable, m.primaryFK, m.joinTable, m.foreignFK, in)
+	if m.primaryColumn != "" {
+		clause = fmt.Sprintf("%s.%s IN %s OR %s", m.primaryTable, m.primaryColumn, in, clause)
+	}
+
+	return "(" + clause + ")"
+}
+
+// anyClause returns a clause that matches rows of the primary table that
+// reference any row of the foreign table.
+func (m *hierarchicalMultiCriterionHandlerBuilder) anyClause() string {
+	clause := fmt.Sprintf("%s.id IN (SELECT %s FROM %s)", m.primaryTable, m.primaryFK, m.joinTable)
+	if m.primaryColumn != "" {
+		clause = fmt.Sprintf("%s.%s IS NOT NULL OR %s", m.primaryTable, m.primaryColumn, clause)
+	}
+
+	return "(" + clause + ")"
+}
+
+// getDescendantIDs returns the provided ids and the ids of their descendants,
+// up to depth levels below the provided ids. A negative depth includes all
+// descendants.
+func (m *hierarchicalMultiCriterionHandlerBuilder) getDescendantIDs(ids []int, depth int) ([]int, error) {
+	r := repository{tx: m.tx}
+
+	ret := utils.IntAppendUniques(nil, ids)
+	current := ret
+	for level := 0; len(current) > 0 && (depth < 0 || level < depth); level++ {
+		query := fmt.Sprintf("SELECT %s as id FROM %s WHERE %s IN %s", m.childFK, m.relationsTable, m.parentFK, getInBinding(len(current)))
+
+		var args []interface{}
+		for _, id := range current {
+			args = append(args, id)
+		}
+
+		children, err := r.runIdsQuery(query, args)
+		if err != nil {
+			return nil, err
+		}
+
+		// only walk children that have not been visited, which also
+		// guards against loops in the hierarchy
+		current = utils.IntExclude(utils.IntAppendUniques(nil, children), ret)
+		ret = append(ret, current...)
+	}
+
+	return ret, nil
+}
@@ -227,15 +227,12 @@ func (qb *galleryQueryBuilder) Query(galleryFilter *models.GalleryFilterType, fi
 		}
 	}
 
-	if tagsFilter := galleryFilter.Tags; tagsFilter != nil && len(tagsFilter.Value) > 0 {
-		for _, tagID := range tagsFilter.Value {
-			query.addArg(tagID)
+	if tagsFilter := galleryFilter.Tags; tagsFilter != nil {
+		whereClause, err := tagsCriterionHandlerBuilder(qb.tx, galleryTable, galleriesTagsTable, galleryIDColumn).whereClause(tagsFilter)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		query.body += " LEFT JOIN tags on tags_join.tag_id = tags.id"
-		whereClause, havingClause := getMultiCriterionClause("galleries", "tags", "galleries_tags", "gallery_id", "tag_id", tagsFilter)
 		query.addWhere(whereClause)
-		query.addHaving(havingClause)
 	}
 
 	if performersFilter := galleryFilter.Performers; performersFilter != nil && len(performersFilter.Value) > 0 {
@@ -427,7 +427,7 @@ func TestGalleryQueryPerformers(t *testing.T) {
 func TestGalleryQueryTags(t *testing.T) {
 	withTxn(func(r models.Repository) error {
 		sqb := r.Gallery()
-		tagCriterion := models.MultiCriterionInput{
+		tagCriterion := models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdxWithGallery]),
 				strconv.Itoa(tagIDs[tagIdx1WithGallery]),
@@ -447,7 +447,7 @@ func TestGalleryQueryTags(t *testing.T) {
 			assert.True(t, gallery.ID == galleryIDs[galleryIdxWithTag] || gallery.ID == galleryIDs[galleryIdxWithTwoTags])
 		}
 
-		tagCriterion = models.MultiCriterionInput{
+		tagCriterion = models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdx1WithGallery]),
 				strconv.Itoa(tagIDs[tagIdx2WithGallery]),
@@ -460,7 +460,7 @@ func TestGalleryQueryTags(t *testing.T) {
 		assert.Len(t, galleries, 1)
 		assert.Equal(t, galleryIDs[galleryIdxWithTwoTags], galleries[0].ID)
 
-		tagCriterion = models.MultiCriterionInput{
+		tagCriterion = models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdx1WithGallery]),
 			},
@@ -317,15 +317,12 @@ func (qb *imageQueryBuilder) Query(imageFilter *models.ImageFilterType, findFilt
 		}
 	}
 
-	if tagsFilter := imageFilter.Tags; tagsFilter != nil && len(tagsFilter.Value) > 0 {
-		for _, tagID := range tagsFilter.Value {
-			query.addArg(tagID)
+	if tagsFilter := imageFilter.Tags; tagsFilter != nil {
+		whereClause, err := tagsCriterionHandlerBuilder(qb.tx, imageTable, imagesTagsTable, imageIDColumn).whereClause(tagsFilter)
+		if err != nil {
+			return nil, 0, err
 		}
-
-		query.body += " LEFT JOIN tags on tags_join.tag_id = tags.id"
-		whereClause, havingClause := getMultiCriterionClause("images", "tags", "images_tags", "image_id", "tag_id", tagsFilter)
 		query.addWhere(whereClause)
-		query.addHaving(havingClause)
 	}
 
 	if galleriesFilter := imageFilter.Galleries; galleriesFilter != nil && len(galleriesFilter.Value) > 0 {
@@ -511,7 +511,7 @@ func TestImageQueryPerformers(t *testing.T) {
 func TestImageQueryTags(t *testing.T) {
 	withTxn(func(r models.Repository) error {
 		sqb := r.Image()
-		tagCriterion := models.MultiCriterionInput{
+		tagCriterion := models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdxWithImage]),
 				strconv.Itoa(tagIDs[tagIdx1WithImage]),
@@ -535,7 +535,7 @@ func TestImageQueryTags(t *testing.T) {
 			assert.True(t, image.ID == imageIDs[imageIdxWithTag] || image.ID == imageIDs[imageIdxWithTwoTags])
 		}
 
-		tagCriterion = models.MultiCriterionInput{
+		tagCriterion = models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdx1WithImage]),
 				strconv.Itoa(tagIDs[tagIdx2WithImage]),
@@ -551,7 +551,7 @@ func TestImageQueryTags(t *testing.T) {
 		assert.Len(t, images, 1)
 		assert.Equal(t, imageIDs[imageIdxWithTwoTags], images[0].ID)
 
-		tagCriterion = models.MultiCriterionInput{
+		tagCriterion = models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdx1WithImage]),
 			},
@@ -373,6 +373,41 @@ func (r *stashIDRepository) replace(id int, newIDs []models.StashID) error {
 	return nil
 }
 
+type stringRepository struct {
+	repository
+	stringColumn string
+}
+
+func (r *stringRepository) get(id int) ([]string, error) {
+	query := fmt.Sprintf("SELECT %s from %s WHERE %s = ?", r.stringColumn, r.tableName, r.idColumn)
+	var ret []string
+	err := r.queryFunc(query, []interface{}{id}, func(rows *sqlx.Rows) error {
+		var out string
+		if err := rows.Scan(&out); err != nil {
+			return err
+		}
+
+		ret = append(ret, out)
+		return nil
+	})
+	return ret, err
+}
+
+func (r *stringRepository) replace(id int, newStrings []string) error {
+	if err := r.destroy([]int{id}); err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s, %s) VALUES (?, ?)", r.tableName, r.idColumn, r.stringColumn)
+	for _, s := range newStrings {
+		_, err := r.tx.Exec(query, id, s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func listKeys(i interface{}, addPrefix bool) string {
 	var query []string
 	v := reflect.ValueOf(i)
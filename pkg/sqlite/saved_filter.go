@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const savedFilterTable = "saved_filters"
+
+type savedFilterQueryBuilder struct {
+	repository
+}
+
+func NewSavedFilterReaderWriter(tx dbi) *savedFilterQueryBuilder {
+	return &savedFilterQueryBuilder{
+		repository{
+			tx:        tx,
+			tableName: savedFilterTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+func (qb *savedFilterQueryBuilder) Create(newObject models.SavedFilter) (*models.SavedFilter, error) {
+	var ret models.SavedFilter
+	if err := qb.insertObject(newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *savedFilterQueryBuilder) Update(updatedObject models.SavedFilter) (*models.SavedFilter, error) {
+	const partial = false
+	if err := qb.update(updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	return qb.find(updatedObject.ID)
+}
+
+// SetDefault sets the filter of the default filter of the mode, creating
+// the default filter if it does not exist.
+func (qb *savedFilterQueryBuilder) SetDefault(obj models.SavedFilter) (*models.SavedFilter, error) {
+	existing, err := qb.FindDefault(obj.Mode)
+	if err != nil {
+		return nil, err
+	}
+
+	obj.Name = ""
+	obj.Default = true
+	if existing == nil {
+		return qb.Create(obj)
+	}
+
+	obj.ID = existing.ID
+	return qb.Update(obj)
+}
+
+func (qb *savedFilterQueryBuilder) Destroy(id int) error {
+	return qb.destroyExisting([]int{id})
+}
+
+// Find returns the saved filter with the id. Default filters are not
+// returned, and are found using FindDefault instead.
+func (qb *savedFilterQueryBuilder) Find(id int) (*models.SavedFilter, error) {
+	query := selectAll(savedFilterTable) + "WHERE id = ? AND is_default = 0 LIMIT 1"
+	args := []interface{}{id}
+	return qb.querySavedFilter(query, args)
+}
+
+func (qb *savedFilterQueryBuilder) find(id int) (*models.SavedFilter, error) {
+	var ret models.SavedFilter
+	if err := qb.get(id, &ret); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// FindByMode returns the saved filters of the mode, ordered by name. The
+// default filter is not included.
+func (qb *savedFilterQueryBuilder) FindByMode(mode models.FilterMode) ([]*models.SavedFilter, error) {
+	query := selectAll(savedFilterTable) + "WHERE mode = ? AND is_default = 0 ORDER BY name ASC"
+	args := []interface{}{mode.String()}
+	return qb.querySavedFilters(query, args)
+}
+
+func (qb *savedFilterQueryBuilder) FindDefault(mode models.FilterMode) (*models.SavedFilter, error) {
+	query := selectAll(savedFilterTable) + "WHERE mode = ? AND is_default = 1 LIMIT 1"
+	args := []interface{}{mode.String()}
+	return qb.querySavedFilter(query, args)
+}
+
+// All returns all saved filters, excluding the default filters.
+func (qb *savedFilterQueryBuilder) All() ([]*models.SavedFilter, error) {
+	query := selectAll(savedFilterTable) + "WHERE is_default = 0 ORDER BY mode ASC, name ASC"
+	return qb.querySavedFilters(query, nil)
+}
+
+func (qb *savedFilterQueryBuilder) querySavedFilter(query string, args []interface{}) (*models.SavedFilter, error) {
+	results, err := qb.querySavedFilters(query, args)
+	if err != nil || len(results) < 1 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (qb *savedFilterQueryBuilder) querySavedFilters(query string, args []interface{}) ([]*models.SavedFilter, error) {
+	var ret models.SavedFilters
+	if err := qb.query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.SavedFilter(ret), nil
+}
@@ -0,0 +1,148 @@
+// +build integration
+
+package sqlite_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestSavedFilterCreateUpdateDestroy(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.SavedFilter()
+
+		created, err := qb.Create(models.SavedFilter{
+			Mode:   models.FilterModeMovies,
+			Name:   "savedFilterTest",
+			Filter: `{}`,
+		})
+		if err != nil {
+			return err
+		}
+
+		created.Filter = `{"find_filter": {"q": "test"}}`
+		updated, err := qb.Update(*created)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, created.Filter, updated.Filter)
+
+		// names are unique within a mode
+		_, err = qb.Create(models.SavedFilter{
+			Mode:   models.FilterModeMovies,
+			Name:   "savedFilterTest",
+			Filter: `{}`,
+		})
+		assert.NotNil(t, err)
+
+		other, err := qb.Create(models.SavedFilter{
+			Mode:   models.FilterModeStudios,
+			Name:   "savedFilterTest",
+			Filter: `{}`,
+		})
+		if err != nil {
+			return err
+		}
+
+		found, err := qb.FindByMode(models.FilterModeMovies)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, []*models.SavedFilter{updated}, found)
+
+		if err := qb.Destroy(created.ID); err != nil {
+			return err
+		}
+
+		if err := qb.Destroy(other.ID); err != nil {
+			return err
+		}
+
+		all, err := qb.All()
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, all, 0)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSavedFilterSetDefault(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.SavedFilter()
+
+		found, err := qb.FindDefault(models.FilterModeImages)
+		if err != nil {
+			return err
+		}
+
+		assert.Nil(t, found)
+
+		created, err := qb.SetDefault(models.SavedFilter{
+			Mode:   models.FilterModeImages,
+			Filter: `{}`,
+		})
+		if err != nil {
+			return err
+		}
+
+		updated, err := qb.SetDefault(models.SavedFilter{
+			Mode:   models.FilterModeImages,
+			Filter: `{"find_filter": {"sort": "title"}}`,
+		})
+		if err != nil {
+			return err
+		}
+
+		// the existing default filter is replaced
+		assert.Equal(t, created.ID, updated.ID)
+
+		found, err = qb.FindDefault(models.FilterModeImages)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, updated, found)
+
+		// default filters are not returned with the saved filters
+		filters, err := qb.FindByMode(models.FilterModeImages)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, filters, 0)
+
+		byID, err := qb.Find(found.ID)
+		if err != nil {
+			return err
+		}
+
+		assert.Nil(t, byID)
+
+		// saved filters do not conflict with the unnamed default filter
+		named, err := qb.Create(models.SavedFilter{
+			Mode:   models.FilterModeImages,
+			Filter: `{}`,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := qb.Destroy(named.ID); err != nil {
+			return err
+		}
+
+		return qb.Destroy(found.ID)
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
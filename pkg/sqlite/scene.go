@@ -4,9 +4,12 @@ import (
 	"database/sql"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 const sceneTable = "scenes"
@@ -15,6 +18,7 @@ const performersScenesTable = "performers_scenes"
 const scenesTagsTable = "scenes_tags"
 const scenesGalleriesTable = "scenes_galleries"
 const moviesScenesTable = "movies_scenes"
+const scenesPlayHistoryTable = "scenes_play_history"
 
 var scenesForPerformerQuery = selectAll(sceneTable) + `
 LEFT JOIN performers_scenes as performers_join on performers_join.scene_id = scenes.id
@@ -61,13 +65,32 @@ SELECT id FROM scenes
 WHERE scenes.oshash is null
 `
 
+var findExactDuplicateQuery = `
+SELECT GROUP_CONCAT(id) as ids
+FROM scenes
+WHERE phash IS NOT NULL
+GROUP BY phash
+HAVING COUNT(*) > 1
+`
+
+var findAllPhashesQuery = `
+SELECT id, phash
+FROM scenes
+WHERE phash IS NOT NULL
+ORDER BY cast(size as integer) DESC
+`
+
 type sceneQueryBuilder struct {
 	repository
+
+	// userID is the user whose play history is read and written. Nil if
+	// authentication is not required.
+	userID *int
 }
 
 func NewSceneReaderWriter(tx dbi) *sceneQueryBuilder {
 	return &sceneQueryBuilder{
-		repository{
+		repository: repository{
 			tx:        tx,
 			tableName: sceneTable,
 			idColumn:  idColumn,
@@ -81,6 +104,10 @@ func (qb *sceneQueryBuilder) Create(newObject models.Scene) (*models.Scene, erro
 		return nil, err
 	}
 
+	if err := qb.updatePrimaryFile(&ret); err != nil {
+		return nil, err
+	}
+
 	return &ret, nil
 }
 
@@ -90,7 +117,12 @@ func (qb *sceneQueryBuilder) Update(updatedObject models.ScenePartial) (*models.
 		return nil, err
 	}
 
-	return qb.find(updatedObject.ID)
+	// only the file details are stored in the primary file
+	if !updatedObject.SetsFileDetails() {
+		return qb.find(updatedObject.ID)
+	}
+
+	return qb.findAndUpdatePrimaryFile(updatedObject.ID)
 }
 
 func (qb *sceneQueryBuilder) UpdateFull(updatedObject models.Scene) (*models.Scene, error) {
@@ -99,13 +131,60 @@ func (qb *sceneQueryBuilder) UpdateFull(updatedObject models.Scene) (*models.Sce
 		return nil, err
 	}
 
-	return qb.find(updatedObject.ID)
+	return qb.findAndUpdatePrimaryFile(updatedObject.ID)
 }
 
 func (qb *sceneQueryBuilder) UpdateFileModTime(id int, modTime models.NullSQLiteTimestamp) error {
-	return qb.updateMap(id, map[string]interface{}{
+	if err := qb.updateMap(id, map[string]interface{}{
 		"file_mod_time": modTime,
-	})
+	}); err != nil {
+		return err
+	}
+
+	_, err := qb.findAndUpdatePrimaryFile(id)
+	return err
+}
+
+func (qb *sceneQueryBuilder) findAndUpdatePrimaryFile(id int) (*models.Scene, error) {
+	ret, err := qb.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := qb.updatePrimaryFile(ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// updatePrimaryFile sets the details of the primary file of the scene to the
+// file details of the scene, creating the primary file if it does not exist.
+func (qb *sceneQueryBuilder) updatePrimaryFile(s *models.Scene) error {
+	fqb := NewFileReaderWriter(qb.tx)
+	f, err := fqb.findPrimary(s.ID)
+	if err != nil {
+		return err
+	}
+
+	if f == nil {
+		f = &models.File{
+			SceneID:   s.ID,
+			Primary:   true,
+			CreatedAt: s.CreatedAt,
+		}
+		f.SetFileDetails(*s)
+		f.UpdatedAt = s.UpdatedAt
+
+		_, err = fqb.Create(*f)
+		return err
+	}
+
+	f.SetFileDetails(*s)
+	f.UpdatedAt = s.UpdatedAt
+
+	_, err = fqb.Update(*f)
+	return err
 }
 
 func (qb *sceneQueryBuilder) IncrementOCounter(id int) (int, error) {
@@ -159,6 +238,137 @@ func (qb *sceneQueryBuilder) ResetOCounter(id int) (int, error) {
 	return scene.OCounter, nil
 }
 
+// playHistoryUserClause returns the where clause that selects the play
+// history rows of the current user.
+func (qb *sceneQueryBuilder) playHistoryUserClause(as string) string {
+	if qb.userID == nil {
+		return as + ".user_id IS NULL"
+	}
+
+	return as + ".user_id = " + strconv.Itoa(*qb.userID)
+}
+
+// playHistoryColumn returns an expression that selects the provided column
+// of the current user's play history for each scene. nullValue, if not
+// empty, is returned for scenes that have not been played by the user.
+func (qb *sceneQueryBuilder) playHistoryColumn(column string, nullValue string) string {
+	ret := "(SELECT " + column + " FROM " + scenesPlayHistoryTable + " AS play_history WHERE play_history.scene_id = scenes.id AND " + qb.playHistoryUserClause("play_history") + ")"
+	if nullValue != "" {
+		ret = "COALESCE(" + ret + ", " + nullValue + ")"
+	}
+
+	return ret
+}
+
+func (qb *sceneQueryBuilder) GetPlayHistory(sceneID int) (*models.ScenePlayHistory, error) {
+	query := "SELECT * FROM " + scenesPlayHistoryTable + " AS play_history WHERE play_history.scene_id = ? AND " + qb.playHistoryUserClause("play_history") + " LIMIT 1"
+
+	var ret models.ScenePlayHistory
+	if err := qb.tx.Get(&ret, query, sceneID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *sceneQueryBuilder) GetAllPlayHistory(sceneID int) ([]*models.ScenePlayHistory, error) {
+	var ret []*models.ScenePlayHistory
+	if err := qb.tx.Select(&ret, "SELECT * FROM "+scenesPlayHistoryTable+" WHERE scene_id = ? ORDER BY id", sceneID); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *sceneQueryBuilder) UpdatePlayHistory(history models.ScenePlayHistory) error {
+	_, err := qb.tx.NamedExec(`INSERT INTO `+scenesPlayHistoryTable+` (scene_id, user_id, play_count, last_played_at, resume_time, play_duration)
+		VALUES (:scene_id, :user_id, :play_count, :last_played_at, :resume_time, :play_duration)
+		ON CONFLICT (scene_id, coalesce(user_id, 0)) DO UPDATE SET
+			play_count = excluded.play_count,
+			last_played_at = excluded.last_played_at,
+			resume_time = excluded.resume_time,
+			play_duration = excluded.play_duration`, history)
+	return err
+}
+
+func (qb *sceneQueryBuilder) AssignPlayHistory(userID int) error {
+	_, err := qb.tx.Exec("UPDATE "+scenesPlayHistoryTable+" SET user_id = ? WHERE user_id IS NULL", userID)
+	return err
+}
+
+// getOrCreatePlayHistory returns the play history of the scene for the
+// current user. Returns an error if the scene does not exist.
+func (qb *sceneQueryBuilder) getOrCreatePlayHistory(sceneID int) (*models.ScenePlayHistory, error) {
+	exists, err := qb.exists(sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("scene with id %d not found", sceneID)
+	}
+
+	history, err := qb.GetPlayHistory(sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if history == nil {
+		history = &models.ScenePlayHistory{
+			SceneID: sceneID,
+		}
+		if qb.userID != nil {
+			history.UserID = sql.NullInt64{Int64: int64(*qb.userID), Valid: true}
+		}
+	}
+
+	return history, nil
+}
+
+// IncrementPlayCount increments the play count of the scene for the current
+// user and sets the last played time to the current time. Returns the new
+// play count.
+func (qb *sceneQueryBuilder) IncrementPlayCount(id int) (int, error) {
+	history, err := qb.getOrCreatePlayHistory(id)
+	if err != nil {
+		return 0, err
+	}
+
+	history.PlayCount++
+	history.LastPlayedAt = models.NullSQLiteTimestamp{Timestamp: time.Now(), Valid: true}
+
+	if err := qb.UpdatePlayHistory(*history); err != nil {
+		return 0, err
+	}
+
+	return history.PlayCount, nil
+}
+
+// SaveActivity sets the resume time of the scene for the current user, if
+// not nil, and adds playDuration to the user's play duration of the scene.
+// The last played time is set to the current time.
+func (qb *sceneQueryBuilder) SaveActivity(id int, resumeTime *float64, playDuration *float64) error {
+	history, err := qb.getOrCreatePlayHistory(id)
+	if err != nil {
+		return err
+	}
+
+	history.LastPlayedAt = models.NullSQLiteTimestamp{Timestamp: time.Now(), Valid: true}
+
+	if resumeTime != nil {
+		history.ResumeTime = *resumeTime
+	}
+
+	if playDuration != nil {
+		history.PlayDuration += *playDuration
+	}
+
+	return qb.UpdatePlayHistory(*history)
+}
+
 func (qb *sceneQueryBuilder) Destroy(id int) error {
 	// delete all related table rows
 	// TODO - this should be handled by a delete cascade
@@ -340,6 +550,10 @@ func (qb *sceneQueryBuilder) makeFilter(sceneFilter *models.SceneFilterType) *fi
 	query.handleCriterionFunc(stringCriterionHandler(sceneFilter.Path, "scenes.path"))
 	query.handleCriterionFunc(intCriterionHandler(sceneFilter.Rating, "scenes.rating"))
 	query.handleCriterionFunc(intCriterionHandler(sceneFilter.OCounter, "scenes.o_counter"))
+	query.handleCriterionFunc(intCriterionHandler(sceneFilter.PlayCount, qb.playHistoryColumn("play_count", "0")))
+	query.handleCriterionFunc(timestampCriterionHandler(sceneFilter.LastPlayedAt, qb.playHistoryColumn("last_played_at", "")))
+	query.handleCriterionFunc(durationCriterionHandler(sceneFilter.ResumeTime, qb.playHistoryColumn("resume_time", "0")))
+	query.handleCriterionFunc(durationCriterionHandler(sceneFilter.PlayDuration, qb.playHistoryColumn("play_duration", "0")))
 	query.handleCriterionFunc(boolCriterionHandler(sceneFilter.Organized, "scenes.organized"))
 	query.handleCriterionFunc(durationCriterionHandler(sceneFilter.Duration, "scenes.duration"))
 	query.handleCriterionFunc(resolutionCriterionHandler(sceneFilter.Resolution, "scenes.height", "scenes.width"))
@@ -519,12 +733,8 @@ func (qb *sceneQueryBuilder) getMultiCriterionHandlerBuilder(foreignTable, joinT
 		addJoinsFunc: addJoinsFunc,
 	}
 }
-func sceneTagsCriterionHandler(qb *sceneQueryBuilder, tags *models.MultiCriterionInput) criterionHandlerFunc {
-	addJoinsFunc := func(f *filterBuilder) {
-		qb.tagsRepository().join(f, "tags_join", "scenes.id")
-		f.addJoin("tags", "", "tags_join.tag_id = tags.id")
-	}
-	h := qb.getMultiCriterionHandlerBuilder(tagTable, scenesTagsTable, tagIDColumn, addJoinsFunc)
+func sceneTagsCriterionHandler(qb *sceneQueryBuilder, tags *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
+	h := tagsCriterionHandlerBuilder(qb.tx, sceneTable, scenesTagsTable, sceneIDColumn)
 
 	return h.handler(tags)
 }
@@ -626,9 +836,65 @@ func (qb *sceneQueryBuilder) getSceneSort(findFilter *models.FindFilterType) str
 	}
 	sort := findFilter.GetSort("title")
 	direction := findFilter.GetDirection()
+
+	// play activity is stored per user in the play history table
+	switch sort {
+	case "play_count":
+		return " ORDER BY " + qb.playHistoryColumn("play_count", "0") + " " + direction + ", " + qb.playHistoryColumn("last_played_at", "") + " " + direction
+	case "last_played_at":
+		return " ORDER BY " + qb.playHistoryColumn("last_played_at", "") + " " + direction
+	case "resume_time", "play_duration":
+		return " ORDER BY " + qb.playHistoryColumn(sort, "0") + " " + direction
+	}
+
 	return getSort(sort, direction, "scenes")
 }
 
+// FindDuplicates returns groups of scenes whose perceptual hashes are within
+// the provided Hamming distance of each other.
+func (qb *sceneQueryBuilder) FindDuplicates(distance int) ([][]*models.Scene, error) {
+	var dupeIDs [][]int
+	if distance == 0 {
+		var ids []string
+		if err := qb.tx.Select(&ids, findExactDuplicateQuery); err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			sceneIDs, err := utils.StringSliceToIntSlice(strings.Split(id, ","))
+			if err != nil {
+				return nil, err
+			}
+			dupeIDs = append(dupeIDs, sceneIDs)
+		}
+	} else {
+		var hashes []*utils.Phash
+		if err := qb.queryFunc(findAllPhashesQuery, nil, func(rows *sqlx.Rows) error {
+			var phash utils.Phash
+			if err := rows.StructScan(&phash); err != nil {
+				return err
+			}
+			hashes = append(hashes, &phash)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+
+		dupeIDs = utils.FindDuplicates(hashes, distance)
+	}
+
+	var duplicates [][]*models.Scene
+	for _, sceneIDs := range dupeIDs {
+		scenes, err := qb.FindMany(sceneIDs)
+		if err != nil {
+			return nil, err
+		}
+		duplicates = append(duplicates, scenes)
+	}
+
+	return duplicates, nil
+}
+
 func (qb *sceneQueryBuilder) queryScene(query string, args []interface{}) (*models.Scene, error) {
 	results, err := qb.queryScenes(query, args)
 	if err != nil || len(results) < 1 {
@@ -146,43 +146,17 @@ func (qb *sceneMarkerQueryBuilder) Query(sceneMarkerFilter *models.SceneMarkerFi
 		left join tags on tags_join.tag_id = tags.id
 	`
 
-	if tagsFilter := sceneMarkerFilter.Tags; tagsFilter != nil && len(tagsFilter.Value) > 0 {
-		//select `scene_markers`.* from `scene_markers`
-		//left join `tags` as `primary_tags_join`
-		//  on `primary_tags_join`.`id` = `scene_markers`.`primary_tag_id`
-		//  and `primary_tags_join`.`id` in ('3', '37', '9', '89')
-		//left join `scene_markers_tags` as `tags_join`
-		//  on `tags_join`.`scene_marker_id` = `scene_markers`.`id`
-		//  and `tags_join`.`tag_id` in ('3', '37', '9', '89')
-		//group by `scene_markers`.`id`
-		//having ((count(distinct `primary_tags_join`.`id`) + count(distinct `tags_join`.`tag_id`)) = 4)
-
-		length := len(tagsFilter.Value)
-
-		if tagsFilter.Modifier == models.CriterionModifierIncludes || tagsFilter.Modifier == models.CriterionModifierIncludesAll {
-			body += " LEFT JOIN tags AS ptj ON ptj.id = scene_markers.primary_tag_id AND ptj.id IN " + getInBinding(length)
-			body += " LEFT JOIN scene_markers_tags AS tj ON tj.scene_marker_id = scene_markers.id AND tj.tag_id IN " + getInBinding(length)
+	if tagsFilter := sceneMarkerFilter.Tags; tagsFilter != nil {
+		// markers match on the primary tag as well as the other tags
+		h := tagsCriterionHandlerBuilder(qb.tx, sceneMarkerTable, "scene_markers_tags", "scene_marker_id")
+		h.primaryColumn = "primary_tag_id"
 
-			// only one required for include any
-			requiredCount := 1
-
-			// all required for include all
-			if tagsFilter.Modifier == models.CriterionModifierIncludesAll {
-				requiredCount = length
-			}
-
-			havingClauses = append(havingClauses, "((COUNT(DISTINCT ptj.id) + COUNT(DISTINCT tj.tag_id)) >= "+strconv.Itoa(requiredCount)+")")
-		} else if tagsFilter.Modifier == models.CriterionModifierExcludes {
-			// excludes all of the provided ids
-			whereClauses = append(whereClauses, "scene_markers.primary_tag_id not in "+getInBinding(length))
-			whereClauses = append(whereClauses, "not exists (select smt.scene_marker_id from scene_markers_tags as smt where smt.scene_marker_id = scene_markers.id and smt.tag_id in "+getInBinding(length)+")")
-		}
-
-		for _, tagID := range tagsFilter.Value {
-			args = append(args, tagID)
+		whereClause, err := h.whereClause(tagsFilter)
+		if err != nil {
+			return nil, 0, err
 		}
-		for _, tagID := range tagsFilter.Value {
-			args = append(args, tagID)
+		if whereClause != "" {
+			whereClauses = append(whereClauses, whereClause)
 		}
 	}
 
@@ -3,15 +3,18 @@
 package sqlite_test
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
@@ -899,7 +902,7 @@ func TestSceneQueryPerformers(t *testing.T) {
 func TestSceneQueryTags(t *testing.T) {
 	withTxn(func(r models.Repository) error {
 		sqb := r.Scene()
-		tagCriterion := models.MultiCriterionInput{
+		tagCriterion := models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdxWithScene]),
 				strconv.Itoa(tagIDs[tagIdx1WithScene]),
@@ -919,7 +922,7 @@ func TestSceneQueryTags(t *testing.T) {
 			assert.True(t, scene.ID == sceneIDs[sceneIdxWithTag] || scene.ID == sceneIDs[sceneIdxWithTwoTags])
 		}
 
-		tagCriterion = models.MultiCriterionInput{
+		tagCriterion = models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdx1WithScene]),
 				strconv.Itoa(tagIDs[tagIdx2WithScene]),
@@ -932,7 +935,7 @@ func TestSceneQueryTags(t *testing.T) {
 		assert.Len(t, scenes, 1)
 		assert.Equal(t, sceneIDs[sceneIdxWithTwoTags], scenes[0].ID)
 
-		tagCriterion = models.MultiCriterionInput{
+		tagCriterion = models.HierarchicalMultiCriterionInput{
 			Value: []string{
 				strconv.Itoa(tagIDs[tagIdx1WithScene]),
 			},
@@ -951,6 +954,95 @@ func TestSceneQueryTags(t *testing.T) {
 	})
 }
 
+func TestSceneQueryTagsDepth(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		sqb := r.Scene()
+		tqb := r.Tag()
+
+		// create a grandparent and parent of a tag with a scene
+		grandparent, err := tqb.Create(models.Tag{Name: "TestSceneQueryTagsDepthGrandparent"})
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+		parent, err := tqb.Create(models.Tag{Name: "TestSceneQueryTagsDepthParent"})
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+
+		if err := tqb.UpdateChildTags(grandparent.ID, []int{parent.ID}); err != nil {
+			return fmt.Errorf("Error updating child tags: %s", err.Error())
+		}
+		if err := tqb.UpdateChildTags(parent.ID, []int{tagIDs[tagIdxWithScene]}); err != nil {
+			return fmt.Errorf("Error updating child tags: %s", err.Error())
+		}
+
+		tagCriterion := models.HierarchicalMultiCriterionInput{
+			Value: []string{
+				strconv.Itoa(grandparent.ID),
+			},
+			Modifier: models.CriterionModifierIncludes,
+		}
+
+		sceneFilter := models.SceneFilterType{
+			Tags: &tagCriterion,
+		}
+
+		scenes := queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 0)
+
+		depth := 1
+		tagCriterion.Depth = &depth
+		scenes = queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 0)
+
+		depth = -1
+		scenes = queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 1)
+		assert.Equal(t, sceneIDs[sceneIdxWithTag], scenes[0].ID)
+
+		// each value must be matched for includes all
+		tagCriterion.Value = append(tagCriterion.Value, strconv.Itoa(tagIDs[tagIdx1WithScene]))
+		tagCriterion.Modifier = models.CriterionModifierIncludesAll
+		scenes = queryScene(t, sqb, &sceneFilter, nil)
+		assert.Len(t, scenes, 0)
+
+		tagCriterion.Value = []string{strconv.Itoa(parent.ID)}
+		tagCriterion.Modifier = models.CriterionModifierExcludes
+		q := getSceneStringValue(sceneIdxWithTag, titleField)
+		findFilter := &models.FindFilterType{
+			Q: &q,
+		}
+		scenes = queryScene(t, sqb, &sceneFilter, findFilter)
+		assert.Len(t, scenes, 0)
+
+		// the values and depth are ignored for is null and not null
+		tagCriterion.Modifier = models.CriterionModifierIsNull
+		scenes = queryScene(t, sqb, &sceneFilter, findFilter)
+		assert.Len(t, scenes, 0)
+
+		tagCriterion.Modifier = models.CriterionModifierNotNull
+		scenes = queryScene(t, sqb, &sceneFilter, findFilter)
+		assert.Len(t, scenes, 1)
+
+		tagCriterion.Value = nil
+		tagCriterion.Modifier = models.CriterionModifierIsNull
+		q = getSceneStringValue(sceneIdxWithGallery, titleField)
+		scenes = queryScene(t, sqb, &sceneFilter, findFilter)
+		assert.Len(t, scenes, 1)
+
+		// remove the tags so that other tests are not affected
+		for _, id := range []int{grandparent.ID, parent.ID} {
+			if err := tqb.Destroy(id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestSceneQueryPerformerTags(t *testing.T) {
 	withTxn(func(r models.Repository) error {
 		sqb := r.Scene()
@@ -1354,6 +1446,277 @@ func TestSceneDestroySceneCover(t *testing.T) {
 	}
 }
 
+func TestScenePlayActivity(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Scene()
+
+		const name = "TestScenePlayActivity"
+		scene := models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+		}
+		created, err := qb.Create(scene)
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+
+		playCount, err := qb.IncrementPlayCount(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error incrementing play count: %s", err.Error())
+		}
+		assert.Equal(t, 1, playCount)
+
+		resumeTime := 12.5
+		playDuration := 10.0
+		if err := qb.SaveActivity(created.ID, &resumeTime, &playDuration); err != nil {
+			return fmt.Errorf("Error saving activity: %s", err.Error())
+		}
+
+		// play duration is added to the existing value
+		if err := qb.SaveActivity(created.ID, nil, &playDuration); err != nil {
+			return fmt.Errorf("Error saving activity: %s", err.Error())
+		}
+
+		history, err := qb.GetPlayHistory(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting play history: %s", err.Error())
+		}
+
+		assert.False(t, history.UserID.Valid)
+		assert.Equal(t, 1, history.PlayCount)
+		assert.Equal(t, resumeTime, history.ResumeTime)
+		assert.Equal(t, 2*playDuration, history.PlayDuration)
+		assert.True(t, history.LastPlayedAt.Valid)
+
+		// missing scenes return an error
+		const invalidID = -1
+		_, err = qb.IncrementPlayCount(invalidID)
+		assert.NotNil(t, err)
+		assert.NotNil(t, qb.SaveActivity(invalidID, &resumeTime, &playDuration))
+
+		// remove the scene so that other tests are not affected
+		return qb.Destroy(created.ID)
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestScenePlayActivityPerUser(t *testing.T) {
+	const name = "TestScenePlayActivityPerUser"
+	var sceneID int
+	var user *models.User
+	if err := withTxn(func(r models.Repository) error {
+		created, err := r.Scene().Create(models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+		sceneID = created.ID
+
+		user, err = r.User().Create(*models.NewUser(name, "", models.UserRoleReadOnly))
+		if err != nil {
+			return fmt.Errorf("Error creating user: %s", err.Error())
+		}
+
+		// activity without a current user is not shared with the user
+		_, err = r.Scene().IncrementPlayCount(sceneID)
+		return err
+	}); err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	userCtx := models.WithCurrentUser(context.TODO(), user)
+	if err := sqlite.NewTransactionManager().WithTxn(userCtx, func(r models.Repository) error {
+		qb := r.Scene()
+
+		history, err := qb.GetPlayHistory(sceneID)
+		if err != nil {
+			return fmt.Errorf("Error getting play history: %s", err.Error())
+		}
+		assert.Nil(t, history)
+
+		playCountCriterion := models.IntCriterionInput{
+			Value:    0,
+			Modifier: models.CriterionModifierGreaterThan,
+		}
+		nameCriterion := models.StringCriterionInput{
+			Value:    name,
+			Modifier: models.CriterionModifierEquals,
+		}
+		sceneFilter := &models.SceneFilterType{
+			Path:      &nameCriterion,
+			PlayCount: &playCountCriterion,
+		}
+		assert.Len(t, queryScene(t, qb, sceneFilter, nil), 0)
+
+		for i := 0; i < 2; i++ {
+			if _, err := qb.IncrementPlayCount(sceneID); err != nil {
+				return fmt.Errorf("Error incrementing play count: %s", err.Error())
+			}
+		}
+
+		history, err = qb.GetPlayHistory(sceneID)
+		if err != nil {
+			return fmt.Errorf("Error getting play history: %s", err.Error())
+		}
+		assert.Equal(t, int64(user.ID), history.UserID.Int64)
+		assert.Equal(t, 2, history.PlayCount)
+		assert.Len(t, queryScene(t, qb, sceneFilter, nil), 1)
+
+		all, err := qb.GetAllPlayHistory(sceneID)
+		if err != nil {
+			return fmt.Errorf("Error getting all play history: %s", err.Error())
+		}
+		assert.Len(t, all, 2)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+
+	// the activity of the user does not change the activity without a user
+	if err := withTxn(func(r models.Repository) error {
+		history, err := r.Scene().GetPlayHistory(sceneID)
+		if err != nil {
+			return fmt.Errorf("Error getting play history: %s", err.Error())
+		}
+		assert.Equal(t, 1, history.PlayCount)
+
+		// remove the scene and user so that other tests are not affected
+		if err := r.Scene().Destroy(sceneID); err != nil {
+			return err
+		}
+
+		return r.User().Destroy(user.ID)
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSceneQueryPlayActivity(t *testing.T) {
+	withTxn(func(r models.Repository) error {
+		sqb := r.Scene()
+
+		const name = "TestSceneQueryPlayActivity"
+		scene := models.Scene{
+			Path:     name,
+			Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+		}
+		created, err := sqb.Create(scene)
+		if err != nil {
+			t.Errorf("Error creating scene: %s", err.Error())
+			return nil
+		}
+
+		for i := 0; i < 2; i++ {
+			if _, err := sqb.IncrementPlayCount(created.ID); err != nil {
+				t.Errorf("Error incrementing play count: %s", err.Error())
+				return nil
+			}
+		}
+
+		resumeTime := 30.0
+		if err := sqb.SaveActivity(created.ID, &resumeTime, &resumeTime); err != nil {
+			t.Errorf("Error saving activity: %s", err.Error())
+			return nil
+		}
+
+		sceneIDs := func(scenes []*models.Scene) []int {
+			var ret []int
+			for _, s := range scenes {
+				ret = append(ret, s.ID)
+			}
+			return ret
+		}
+
+		playCountCriterion := models.IntCriterionInput{
+			Value:    1,
+			Modifier: models.CriterionModifierGreaterThan,
+		}
+		scenes := queryScene(t, sqb, &models.SceneFilterType{
+			PlayCount: &playCountCriterion,
+		}, nil)
+		assert.Equal(t, []int{created.ID}, sceneIDs(scenes))
+
+		resumeTimeCriterion := models.IntCriterionInput{
+			Value:    30,
+			Modifier: models.CriterionModifierEquals,
+		}
+		scenes = queryScene(t, sqb, &models.SceneFilterType{
+			ResumeTime:   &resumeTimeCriterion,
+			PlayDuration: &resumeTimeCriterion,
+		}, nil)
+		assert.Equal(t, []int{created.ID}, sceneIDs(scenes))
+
+		yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+		lastPlayedCriterion := models.TimestampCriterionInput{
+			Value:    yesterday,
+			Modifier: models.CriterionModifierGreaterThan,
+		}
+		scenes = queryScene(t, sqb, &models.SceneFilterType{
+			LastPlayedAt: &lastPlayedCriterion,
+		}, nil)
+		assert.Contains(t, sceneIDs(scenes), created.ID)
+		for _, s := range scenes {
+			history, err := sqb.GetPlayHistory(s.ID)
+			if assert.Nil(t, err) && assert.NotNil(t, history) {
+				assert.True(t, history.LastPlayedAt.Valid)
+			}
+		}
+
+		lastPlayedCriterion.Modifier = models.CriterionModifierLessThan
+		scenes = queryScene(t, sqb, &models.SceneFilterType{
+			LastPlayedAt: &lastPlayedCriterion,
+		}, nil)
+		assert.Len(t, scenes, 0)
+
+		lastPlayedCriterion.Value = "invalid"
+		_, _, err = sqb.Query(&models.SceneFilterType{
+			LastPlayedAt: &lastPlayedCriterion,
+		}, nil)
+		assert.NotNil(t, err)
+
+		// unplayed scenes are sorted last
+		sort := "last_played_at"
+		direction := models.SortDirectionEnumDesc
+		scenes = queryScene(t, sqb, nil, &models.FindFilterType{
+			Sort:      &sort,
+			Direction: &direction,
+		})
+		if assert.NotEmpty(t, scenes) {
+			first, err := sqb.GetPlayHistory(scenes[0].ID)
+			if assert.Nil(t, err) && assert.NotNil(t, first) {
+				assert.True(t, first.LastPlayedAt.Valid)
+			}
+			last, err := sqb.GetPlayHistory(scenes[len(scenes)-1].ID)
+			assert.Nil(t, err)
+			assert.Nil(t, last)
+		}
+
+		for _, sort := range []string{"play_count", "play_duration", "resume_time"} {
+			sort := sort
+			direction := models.SortDirectionEnumDesc
+			scenes = queryScene(t, sqb, nil, &models.FindFilterType{
+				Sort:      &sort,
+				Direction: &direction,
+			})
+			if assert.NotEmpty(t, scenes) {
+				assert.Equal(t, created.ID, scenes[0].ID, sort)
+			}
+		}
+
+		// remove the scene so that other tests are not affected
+		if err := sqb.Destroy(created.ID); err != nil {
+			t.Errorf("Error destroying scene: %s", err.Error())
+		}
+
+		return nil
+	})
+}
+
 func TestSceneStashIDs(t *testing.T) {
 	if err := withTxn(func(r models.Repository) error {
 		qb := r.Scene()
@@ -1376,6 +1739,65 @@ func TestSceneStashIDs(t *testing.T) {
 	}
 }
 
+func TestSceneFindDuplicates(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Scene()
+
+		// create scenes with perceptual hashes to test against
+		hashes := []int64{0x0f0f, 0x0f0e, 0x0f0f, 0x7ff0f0f0}
+		var ids []int
+		for i, hash := range hashes {
+			name := fmt.Sprintf("TestSceneFindDuplicates_%d", i)
+			scene := models.Scene{
+				Path:     name,
+				Checksum: sql.NullString{String: utils.MD5FromString(name), Valid: true},
+				Phash:    sql.NullInt64{Int64: hash, Valid: true},
+			}
+			created, err := qb.Create(scene)
+			if err != nil {
+				return fmt.Errorf("Error creating scene: %s", err.Error())
+			}
+			ids = append(ids, created.ID)
+		}
+
+		duplicates, err := qb.FindDuplicates(0)
+		if err != nil {
+			t.Errorf("Error finding duplicates: %s", err.Error())
+		}
+
+		assert.Len(t, duplicates, 1)
+		assert.ElementsMatch(t, []int{ids[0], ids[2]}, getSceneIDs(duplicates[0]))
+
+		duplicates, err = qb.FindDuplicates(1)
+		if err != nil {
+			t.Errorf("Error finding duplicates: %s", err.Error())
+		}
+
+		assert.Len(t, duplicates, 1)
+		assert.ElementsMatch(t, []int{ids[0], ids[1], ids[2]}, getSceneIDs(duplicates[0]))
+
+		// remove the scenes so that other tests are not affected
+		for _, id := range ids {
+			if err := qb.Destroy(id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func getSceneIDs(scenes []*models.Scene) []int {
+	var ret []int
+	for _, s := range scenes {
+		ret = append(ret, s.ID)
+	}
+
+	return ret
+}
+
 // TODO Update
 // TODO IncrementOCounter
 // TODO DecrementOCounter
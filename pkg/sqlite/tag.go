@@ -10,6 +10,11 @@ import (
 
 const tagTable = "tags"
 const tagIDColumn = "tag_id"
+const tagAliasesTable = "tag_aliases"
+const tagAliasColumn = "alias"
+const tagsRelationsTable = "tags_relations"
+const tagParentIDColumn = "parent_id"
+const tagChildIDColumn = "child_id"
 
 type tagQueryBuilder struct {
 	repository
@@ -72,6 +77,97 @@ func (qb *tagQueryBuilder) Destroy(id int) error {
 	return qb.destroyExisting([]int{id})
 }
 
+// tagReferences are the tables that reference tags, which are moved to the
+// destination tag when tags are merged. Join tables specify the column that
+// references the other side of the join, so that duplicate rows are not
+// created.
+var tagReferences = []struct {
+	table     string
+	column    string
+	joinedCol string
+}{
+	{scenesTagsTable, tagIDColumn, sceneIDColumn},
+	{imagesTagsTable, tagIDColumn, imageIDColumn},
+	{galleriesTagsTable, tagIDColumn, galleryIDColumn},
+	{performersTagsTable, tagIDColumn, performerIDColumn},
+	{"scene_markers_tags", tagIDColumn, "scene_marker_id"},
+	{sceneMarkerTable, "primary_tag_id", ""},
+}
+
+func (qb *tagQueryBuilder) Merge(source []int, destination int) ([]*models.TagsMergeCount, error) {
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	inBinding := getInBinding(len(source))
+	args := []interface{}{destination}
+	for _, id := range source {
+		if id == destination {
+			return nil, errors.New("cannot merge a tag into itself")
+		}
+		args = append(args, id)
+	}
+
+	var ret []*models.TagsMergeCount
+	for _, ref := range tagReferences {
+		count := 0
+
+		// sources are moved one at a time so that rows that would duplicate
+		// an existing row are not moved. These rows are deleted when the
+		// source tags are destroyed.
+		for _, id := range source {
+			stmt := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s = ?", ref.table, ref.column, ref.column)
+			stmtArgs := []interface{}{destination, id}
+			if ref.joinedCol != "" {
+				stmt += fmt.Sprintf(" AND %[1]s NOT IN (SELECT %[1]s FROM %[2]s WHERE %[3]s = ?)", ref.joinedCol, ref.table, ref.column)
+				stmtArgs = append(stmtArgs, destination)
+			}
+
+			result, err := qb.tx.Exec(stmt, stmtArgs...)
+			if err != nil {
+				return nil, err
+			}
+
+			moved, err := result.RowsAffected()
+			if err != nil {
+				return nil, err
+			}
+			count += int(moved)
+		}
+
+		ret = append(ret, &models.TagsMergeCount{
+			Table: ref.table,
+			Count: count,
+		})
+	}
+
+	for _, column := range []string{tagParentIDColumn, tagChildIDColumn} {
+		stmt := fmt.Sprintf("UPDATE OR IGNORE %s SET %s = ? WHERE %s IN %s", tagsRelationsTable, column, column, inBinding)
+		if _, err := qb.tx.Exec(stmt, args...); err != nil {
+			return nil, err
+		}
+	}
+
+	// relationships between the source and destination tags become
+	// self-references
+	if _, err := qb.tx.Exec("DELETE FROM tags_relations WHERE parent_id = child_id"); err != nil {
+		return nil, err
+	}
+
+	stmt := fmt.Sprintf("UPDATE OR IGNORE %s SET %s = ? WHERE %s IN %s", tagAliasesTable, tagIDColumn, tagIDColumn, inBinding)
+	if _, err := qb.tx.Exec(stmt, args...); err != nil {
+		return nil, err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
 func (qb *tagQueryBuilder) Find(id int) (*models.Tag, error) {
 	var ret models.Tag
 	if err := qb.get(id, &ret); err != nil {
@@ -161,6 +257,28 @@ func (qb *tagQueryBuilder) FindBySceneMarkerID(sceneMarkerID int) ([]*models.Tag
 	return qb.queryTags(query, args)
 }
 
+func (qb *tagQueryBuilder) FindByParentTagID(parentID int) ([]*models.Tag, error) {
+	query := `
+		SELECT tags.* FROM tags
+		INNER JOIN tags_relations ON tags_relations.child_id = tags.id
+		WHERE tags_relations.parent_id = ?
+	`
+	query += qb.getDefaultTagSort()
+	args := []interface{}{parentID}
+	return qb.queryTags(query, args)
+}
+
+func (qb *tagQueryBuilder) FindByChildTagID(childID int) ([]*models.Tag, error) {
+	query := `
+		SELECT tags.* FROM tags
+		INNER JOIN tags_relations ON tags_relations.parent_id = tags.id
+		WHERE tags_relations.child_id = ?
+	`
+	query += qb.getDefaultTagSort()
+	args := []interface{}{childID}
+	return qb.queryTags(query, args)
+}
+
 func (qb *tagQueryBuilder) FindByName(name string, nocase bool) (*models.Tag, error) {
 	query := "SELECT * FROM tags WHERE name = ?"
 	if nocase {
@@ -171,6 +289,19 @@ func (qb *tagQueryBuilder) FindByName(name string, nocase bool) (*models.Tag, er
 	return qb.queryTag(query, args)
 }
 
+func (qb *tagQueryBuilder) FindByAlias(alias string, nocase bool) (*models.Tag, error) {
+	query := `
+		SELECT tags.* FROM tags
+		INNER JOIN tag_aliases ON tag_aliases.tag_id = tags.id
+		WHERE tag_aliases.alias = ?`
+	if nocase {
+		query += " COLLATE NOCASE"
+	}
+	query += " LIMIT 1"
+	args := []interface{}{alias}
+	return qb.queryTag(query, args)
+}
+
 func (qb *tagQueryBuilder) FindByNames(names []string, nocase bool) ([]*models.Tag, error) {
 	query := "SELECT * FROM tags WHERE name"
 	if nocase {
@@ -249,6 +380,8 @@ func (qb *tagQueryBuilder) makeFilter(tagFilter *models.TagFilterType) *filterBu
 	query.handleCriterionFunc(tagImageCountCriterionHandler(qb, tagFilter.ImageCount))
 	query.handleCriterionFunc(tagGalleryCountCriterionHandler(qb, tagFilter.GalleryCount))
 	query.handleCriterionFunc(tagPerformerCountCriterionHandler(qb, tagFilter.PerformerCount))
+	query.handleCriterionFunc(tagParentsCriterionHandler(qb, tagFilter.Parents))
+	query.handleCriterionFunc(tagChildrenCriterionHandler(qb, tagFilter.Children))
 
 	return query
 }
@@ -279,7 +412,8 @@ func (qb *tagQueryBuilder) Query(tagFilter *models.TagFilterType, findFilter *mo
 	// Disabling querying/sorting on marker count for now.
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		searchColumns := []string{"tags.name"}
+		query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
+		searchColumns := []string{"tags.name", "tag_aliases.alias"}
 		clause, thisArgs := getSearchBinding(searchColumns, *q, false)
 		query.addWhere(clause)
 		query.addArg(thisArgs...)
@@ -324,6 +458,25 @@ func tagIsMissingCriterionHandler(qb *tagQueryBuilder, isMissing *string) criter
 	}
 }
 
+func tagParentsCriterionHandler(qb *tagQueryBuilder, parents *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
+	h := tagsCriterionHandlerBuilder(qb.tx, tagTable, tagsRelationsTable, tagChildIDColumn)
+	h.foreignFK = tagParentIDColumn
+
+	return h.handler(parents)
+}
+
+func tagChildrenCriterionHandler(qb *tagQueryBuilder, children *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
+	h := tagsCriterionHandlerBuilder(qb.tx, tagTable, tagsRelationsTable, tagParentIDColumn)
+	h.foreignFK = tagChildIDColumn
+
+	// a tag has the value as a descendant if one of its children is the
+	// value or one of its ancestors, so the depth walks up the hierarchy
+	h.parentFK = tagChildIDColumn
+	h.childFK = tagParentIDColumn
+
+	return h.handler(children)
+}
+
 func tagSceneCountCriterionHandler(qb *tagQueryBuilder, sceneCount *models.IntCriterionInput) criterionHandlerFunc {
 	return func(f *filterBuilder) {
 		if sceneCount != nil {
@@ -466,3 +619,75 @@ func (qb *tagQueryBuilder) UpdateImage(tagID int, image []byte) error {
 func (qb *tagQueryBuilder) DestroyImage(tagID int) error {
 	return qb.imageRepository().destroy([]int{tagID})
 }
+
+func (qb *tagQueryBuilder) aliasRepository() *stringRepository {
+	return &stringRepository{
+		repository: repository{
+			tx:        qb.tx,
+			tableName: tagAliasesTable,
+			idColumn:  tagIDColumn,
+		},
+		stringColumn: tagAliasColumn,
+	}
+}
+
+func (qb *tagQueryBuilder) GetAliases(tagID int) ([]string, error) {
+	return qb.aliasRepository().get(tagID)
+}
+
+func (qb *tagQueryBuilder) UpdateAliases(tagID int, aliases []string) error {
+	return qb.aliasRepository().replace(tagID, aliases)
+}
+
+func (qb *tagQueryBuilder) parentsRepository() *joinRepository {
+	return &joinRepository{
+		repository: repository{
+			tx:        qb.tx,
+			tableName: tagsRelationsTable,
+			idColumn:  tagChildIDColumn,
+		},
+		fkColumn: tagParentIDColumn,
+	}
+}
+
+func (qb *tagQueryBuilder) childrenRepository() *joinRepository {
+	return &joinRepository{
+		repository: repository{
+			tx:        qb.tx,
+			tableName: tagsRelationsTable,
+			idColumn:  tagParentIDColumn,
+		},
+		fkColumn: tagChildIDColumn,
+	}
+}
+
+func (qb *tagQueryBuilder) GetParentIDs(tagID int) ([]int, error) {
+	return qb.parentsRepository().getIDs(tagID)
+}
+
+func (qb *tagQueryBuilder) GetChildIDs(tagID int) ([]int, error) {
+	return qb.childrenRepository().getIDs(tagID)
+}
+
+func (qb *tagQueryBuilder) UpdateParentTags(tagID int, parentIDs []int) error {
+	return qb.parentsRepository().replace(tagID, parentIDs)
+}
+
+func (qb *tagQueryBuilder) UpdateChildTags(tagID int, childIDs []int) error {
+	return qb.childrenRepository().replace(tagID, childIDs)
+}
+
+// tagsCriterionHandlerBuilder returns a builder for hierarchical tags criteria
+// on the primary table, where the join table joins the primary table to tags.
+func tagsCriterionHandlerBuilder(tx dbi, primaryTable, joinTable, primaryFK string) *hierarchicalMultiCriterionHandlerBuilder {
+	return &hierarchicalMultiCriterionHandlerBuilder{
+		tx:             tx,
+		primaryTable:   primaryTable,
+		joinTable:      joinTable,
+		primaryFK:      primaryFK,
+		foreignFK:      tagIDColumn,
+		relationsTable: tagsRelationsTable,
+		parentFK:       tagParentIDColumn,
+		childFK:        tagChildIDColumn,
+	}
+}
@@ -5,6 +5,7 @@ package sqlite_test
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -441,6 +442,389 @@ func TestTagDestroyTagImage(t *testing.T) {
 	}
 }
 
+func TestTagUpdateAliases(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Tag()
+
+		// create tag to test against
+		const name = "TestTagUpdateAliases"
+		tag := models.Tag{
+			Name: name,
+		}
+		created, err := qb.Create(tag)
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+
+		aliases := []string{"TestTagUpdateAliases1", "TestTagUpdateAliases2"}
+		if err := qb.UpdateAliases(created.ID, aliases); err != nil {
+			return fmt.Errorf("Error updating tag aliases: %s", err.Error())
+		}
+
+		// ensure aliases set
+		storedAliases, err := qb.GetAliases(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting aliases: %s", err.Error())
+		}
+		assert.Equal(t, aliases, storedAliases)
+
+		// find by alias nocase
+		found, err := qb.FindByAlias(strings.ToLower(aliases[1]), true)
+		if err != nil {
+			return fmt.Errorf("Error finding tag by alias: %s", err.Error())
+		}
+		assert.Equal(t, created.ID, found.ID)
+
+		found, err = qb.FindByAlias(strings.ToLower(aliases[1]), false)
+		if err != nil {
+			return fmt.Errorf("Error finding tag by alias: %s", err.Error())
+		}
+		assert.Nil(t, found)
+
+		// query matches aliases
+		q := aliases[0]
+		tags, _, err := qb.Query(nil, &models.FindFilterType{
+			Q: &q,
+		})
+		if err != nil {
+			return fmt.Errorf("Error querying tags: %s", err.Error())
+		}
+		assert.Len(t, tags, 1)
+		assert.Equal(t, created.ID, tags[0].ID)
+
+		// clear aliases
+		if err := qb.UpdateAliases(created.ID, nil); err != nil {
+			return fmt.Errorf("Error updating tag aliases: %s", err.Error())
+		}
+
+		storedAliases, err = qb.GetAliases(created.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting aliases: %s", err.Error())
+		}
+		assert.Len(t, storedAliases, 0)
+
+		// remove the tag so that other tests are not affected
+		return qb.Destroy(created.ID)
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestTagUpdateHierarchy(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Tag()
+
+		var ids []int
+		for _, name := range []string{"TestTagUpdateHierarchyParent", "TestTagUpdateHierarchyChild1", "TestTagUpdateHierarchyChild2"} {
+			created, err := qb.Create(models.Tag{Name: name})
+			if err != nil {
+				return fmt.Errorf("Error creating tag: %s", err.Error())
+			}
+			ids = append(ids, created.ID)
+		}
+
+		parentID := ids[0]
+		childIDs := ids[1:]
+
+		if err := qb.UpdateChildTags(parentID, childIDs); err != nil {
+			return fmt.Errorf("Error updating child tags: %s", err.Error())
+		}
+
+		children, err := qb.FindByParentTagID(parentID)
+		if err != nil {
+			return fmt.Errorf("Error finding child tags: %s", err.Error())
+		}
+		assert.Len(t, children, 2)
+
+		parents, err := qb.FindByChildTagID(childIDs[0])
+		if err != nil {
+			return fmt.Errorf("Error finding parent tags: %s", err.Error())
+		}
+		assert.Len(t, parents, 1)
+		assert.Equal(t, parentID, parents[0].ID)
+
+		// replace the parents of a child
+		if err := qb.UpdateParentTags(childIDs[1], nil); err != nil {
+			return fmt.Errorf("Error updating parent tags: %s", err.Error())
+		}
+
+		storedIDs, err := qb.GetChildIDs(parentID)
+		if err != nil {
+			return fmt.Errorf("Error getting child ids: %s", err.Error())
+		}
+		assert.Equal(t, []int{childIDs[0]}, storedIDs)
+
+		// relationships are removed when a tag is destroyed
+		if err := qb.Destroy(childIDs[0]); err != nil {
+			return fmt.Errorf("Error destroying tag: %s", err.Error())
+		}
+
+		storedIDs, err = qb.GetChildIDs(parentID)
+		if err != nil {
+			return fmt.Errorf("Error getting child ids: %s", err.Error())
+		}
+		assert.Len(t, storedIDs, 0)
+
+		// remove the remaining tags so that other tests are not affected
+		for _, id := range []int{parentID, childIDs[1]} {
+			if err := qb.Destroy(id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestTagQueryHierarchy(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Tag()
+
+		parent, err := qb.Create(models.Tag{Name: "TestTagQueryHierarchyParent"})
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+		child, err := qb.Create(models.Tag{Name: "TestTagQueryHierarchyChild"})
+		if err != nil {
+			return fmt.Errorf("Error creating tag: %s", err.Error())
+		}
+
+		if err := qb.UpdateChildTags(parent.ID, []int{child.ID}); err != nil {
+			return fmt.Errorf("Error updating child tags: %s", err.Error())
+		}
+
+		q := "TestTagQueryHierarchy"
+		findFilter := models.FindFilterType{
+			Q: &q,
+		}
+
+		queryIDs := func(tagFilter models.TagFilterType) []int {
+			tags, _, err := qb.Query(&tagFilter, &findFilter)
+			if err != nil {
+				t.Errorf("Error querying tags: %s", err.Error())
+			}
+
+			var ret []int
+			for _, tag := range tags {
+				ret = append(ret, tag.ID)
+			}
+			return ret
+		}
+
+		// tags with no parents or children
+		assert.Equal(t, []int{parent.ID}, queryIDs(models.TagFilterType{
+			Parents: &models.HierarchicalMultiCriterionInput{
+				Modifier: models.CriterionModifierIsNull,
+			},
+		}))
+		assert.Equal(t, []int{child.ID}, queryIDs(models.TagFilterType{
+			Children: &models.HierarchicalMultiCriterionInput{
+				Modifier: models.CriterionModifierIsNull,
+			},
+		}))
+
+		// tags with some parents or children
+		assert.Equal(t, []int{child.ID}, queryIDs(models.TagFilterType{
+			Parents: &models.HierarchicalMultiCriterionInput{
+				Modifier: models.CriterionModifierNotNull,
+			},
+		}))
+		assert.Equal(t, []int{parent.ID}, queryIDs(models.TagFilterType{
+			Children: &models.HierarchicalMultiCriterionInput{
+				Modifier: models.CriterionModifierNotNull,
+			},
+		}))
+
+		assert.Equal(t, []int{child.ID}, queryIDs(models.TagFilterType{
+			Parents: &models.HierarchicalMultiCriterionInput{
+				Value:    []string{strconv.Itoa(parent.ID)},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}))
+
+		// remove the tags so that other tests are not affected
+		for _, id := range []int{parent.ID, child.ID} {
+			if err := qb.Destroy(id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestTagQueryHierarchyDepth(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Tag()
+
+		var ids []int
+		for _, name := range []string{"TestTagQueryHierarchyDepthGrandparent", "TestTagQueryHierarchyDepthParent", "TestTagQueryHierarchyDepthChild"} {
+			created, err := qb.Create(models.Tag{Name: name})
+			if err != nil {
+				return fmt.Errorf("Error creating tag: %s", err.Error())
+			}
+			ids = append(ids, created.ID)
+		}
+
+		grandparentID := ids[0]
+		parentID := ids[1]
+		childID := ids[2]
+
+		if err := qb.UpdateChildTags(grandparentID, []int{parentID}); err != nil {
+			return fmt.Errorf("Error updating child tags: %s", err.Error())
+		}
+		if err := qb.UpdateChildTags(parentID, []int{childID}); err != nil {
+			return fmt.Errorf("Error updating child tags: %s", err.Error())
+		}
+
+		q := "TestTagQueryHierarchyDepth"
+		findFilter := models.FindFilterType{
+			Q: &q,
+		}
+
+		queryIDs := func(tagFilter models.TagFilterType) []int {
+			tags, _, err := qb.Query(&tagFilter, &findFilter)
+			if err != nil {
+				t.Errorf("Error querying tags: %s", err.Error())
+			}
+
+			var ret []int
+			for _, tag := range tags {
+				ret = append(ret, tag.ID)
+			}
+			return ret
+		}
+
+		depth := 0
+		parents := &models.HierarchicalMultiCriterionInput{
+			Value:    []string{strconv.Itoa(grandparentID)},
+			Modifier: models.CriterionModifierIncludes,
+			Depth:    &depth,
+		}
+		children := &models.HierarchicalMultiCriterionInput{
+			Value:    []string{strconv.Itoa(childID)},
+			Modifier: models.CriterionModifierIncludes,
+			Depth:    &depth,
+		}
+
+		// tags with the grandparent as a parent, and the child as a child
+		assert.ElementsMatch(t, []int{parentID}, queryIDs(models.TagFilterType{Parents: parents}))
+		assert.ElementsMatch(t, []int{parentID}, queryIDs(models.TagFilterType{Children: children}))
+
+		// tags with the grandparent as an ancestor, and the child as a
+		// descendant
+		depth = 1
+		assert.ElementsMatch(t, []int{parentID, childID}, queryIDs(models.TagFilterType{Parents: parents}))
+		assert.ElementsMatch(t, []int{grandparentID, parentID}, queryIDs(models.TagFilterType{Children: children}))
+
+		depth = -1
+		assert.ElementsMatch(t, []int{parentID, childID}, queryIDs(models.TagFilterType{Parents: parents}))
+		assert.ElementsMatch(t, []int{grandparentID, parentID}, queryIDs(models.TagFilterType{Children: children}))
+
+		// tags without the child as a descendant
+		children.Modifier = models.CriterionModifierExcludes
+		assert.ElementsMatch(t, []int{childID}, queryIDs(models.TagFilterType{Children: children}))
+
+		// remove the tags so that other tests are not affected
+		for _, id := range ids {
+			if err := qb.Destroy(id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestTagsMerge(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.Tag()
+		sqb := r.Scene()
+
+		var ids []int
+		for _, name := range []string{"TestTagsMergeDestination", "TestTagsMergeSource1", "TestTagsMergeSource2"} {
+			created, err := qb.Create(models.Tag{Name: name})
+			if err != nil {
+				return fmt.Errorf("Error creating tag: %s", err.Error())
+			}
+			ids = append(ids, created.ID)
+		}
+
+		destinationID := ids[0]
+		sourceIDs := ids[1:]
+
+		scene, err := sqb.Create(models.Scene{
+			Path:     "TestTagsMerge",
+			Checksum: sql.NullString{String: "TestTagsMerge", Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating scene: %s", err.Error())
+		}
+
+		// the scene already has the destination tag
+		if err := sqb.UpdateTags(scene.ID, ids); err != nil {
+			return fmt.Errorf("Error updating scene tags: %s", err.Error())
+		}
+
+		marker, err := r.SceneMarker().Create(models.SceneMarker{
+			Title:        "TestTagsMerge",
+			PrimaryTagID: sourceIDs[0],
+			SceneID:      sql.NullInt64{Int64: int64(scene.ID), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("Error creating scene marker: %s", err.Error())
+		}
+
+		counts, err := qb.Merge(sourceIDs, destinationID)
+		if err != nil {
+			return fmt.Errorf("Error merging tags: %s", err.Error())
+		}
+
+		moved := make(map[string]int)
+		for _, c := range counts {
+			moved[c.Table] = c.Count
+		}
+		assert.Equal(t, 0, moved["scenes_tags"])
+		assert.Equal(t, 1, moved["scene_markers"])
+
+		sceneTagIDs, err := sqb.GetTagIDs(scene.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting scene tags: %s", err.Error())
+		}
+		assert.Equal(t, []int{destinationID}, sceneTagIDs)
+
+		for _, id := range sourceIDs {
+			tag, err := qb.Find(id)
+			if err != nil {
+				return fmt.Errorf("Error finding tag: %s", err.Error())
+			}
+			assert.Nil(t, tag)
+		}
+
+		if _, err := qb.Merge([]int{destinationID}, destinationID); err == nil {
+			return fmt.Errorf("Expected error merging tag into itself")
+		}
+
+		// remove the created rows so that other tests are not affected
+		if err := r.SceneMarker().Destroy(marker.ID); err != nil {
+			return err
+		}
+		if err := sqb.Destroy(scene.ID); err != nil {
+			return err
+		}
+
+		return qb.Destroy(destinationID)
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TODO Create
 // TODO Update
 // TODO Destroy
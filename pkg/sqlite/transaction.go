@@ -76,6 +76,11 @@ func (t *transaction) ensureTx() {
 	}
 }
 
+func (t *transaction) File() models.FileReaderWriter {
+	t.ensureTx()
+	return NewFileReaderWriter(t.tx)
+}
+
 func (t *transaction) Gallery() models.GalleryReaderWriter {
 	t.ensureTx()
 	return NewGalleryReaderWriter(t.tx)
@@ -96,6 +101,11 @@ func (t *transaction) Performer() models.PerformerReaderWriter {
 	return NewPerformerReaderWriter(t.tx)
 }
 
+func (t *transaction) SavedFilter() models.SavedFilterReaderWriter {
+	t.ensureTx()
+	return NewSavedFilterReaderWriter(t.tx)
+}
+
 func (t *transaction) SceneMarker() models.SceneMarkerReaderWriter {
 	t.ensureTx()
 	return NewSceneMarkerReaderWriter(t.tx)
@@ -103,7 +113,9 @@ func (t *transaction) SceneMarker() models.SceneMarkerReaderWriter {
 
 func (t *transaction) Scene() models.SceneReaderWriter {
 	t.ensureTx()
-	return NewSceneReaderWriter(t.tx)
+	qb := NewSceneReaderWriter(t.tx)
+	qb.userID = models.CurrentUserID(t.Ctx)
+	return qb
 }
 
 func (t *transaction) ScrapedItem() models.ScrapedItemReaderWriter {
@@ -121,7 +133,14 @@ func (t *transaction) Tag() models.TagReaderWriter {
 	return NewTagReaderWriter(t.tx)
 }
 
-type ReadTransaction struct{}
+func (t *transaction) User() models.UserReaderWriter {
+	t.ensureTx()
+	return NewUserReaderWriter(t.tx)
+}
+
+type ReadTransaction struct {
+	Ctx context.Context
+}
 
 func (t *ReadTransaction) Begin() error {
 	return nil
@@ -139,6 +158,10 @@ func (t *ReadTransaction) Repository() models.ReaderRepository {
 	return t
 }
 
+func (t *ReadTransaction) File() models.FileReader {
+	return NewFileReaderWriter(database.DB)
+}
+
 func (t *ReadTransaction) Gallery() models.GalleryReader {
 	return NewGalleryReaderWriter(database.DB)
 }
@@ -155,12 +178,18 @@ func (t *ReadTransaction) Performer() models.PerformerReader {
 	return NewPerformerReaderWriter(database.DB)
 }
 
+func (t *ReadTransaction) SavedFilter() models.SavedFilterReader {
+	return NewSavedFilterReaderWriter(database.DB)
+}
+
 func (t *ReadTransaction) SceneMarker() models.SceneMarkerReader {
 	return NewSceneMarkerReaderWriter(database.DB)
 }
 
 func (t *ReadTransaction) Scene() models.SceneReader {
-	return NewSceneReaderWriter(database.DB)
+	qb := NewSceneReaderWriter(database.DB)
+	qb.userID = models.CurrentUserID(t.Ctx)
+	return qb
 }
 
 func (t *ReadTransaction) ScrapedItem() models.ScrapedItemReader {
@@ -175,6 +204,10 @@ func (t *ReadTransaction) Tag() models.TagReader {
 	return NewTagReaderWriter(database.DB)
 }
 
+func (t *ReadTransaction) User() models.UserReader {
+	return NewUserReaderWriter(database.DB)
+}
+
 type TransactionManager struct {
 }
 
@@ -189,5 +222,5 @@ func (t *TransactionManager) WithTxn(ctx context.Context, fn func(r models.Repos
 }
 
 func (t *TransactionManager) WithReadTxn(ctx context.Context, fn func(r models.ReaderRepository) error) error {
-	return models.WithROTxn(&ReadTransaction{}, fn)
+	return models.WithROTxn(&ReadTransaction{Ctx: ctx}, fn)
 }
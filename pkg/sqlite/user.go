@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"database/sql"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const userTable = "users"
+
+type userQueryBuilder struct {
+	repository
+}
+
+func NewUserReaderWriter(tx dbi) *userQueryBuilder {
+	return &userQueryBuilder{
+		repository{
+			tx:        tx,
+			tableName: userTable,
+			idColumn:  idColumn,
+		},
+	}
+}
+
+func (qb *userQueryBuilder) Create(newObject models.User) (*models.User, error) {
+	var ret models.User
+	if err := qb.insertObject(newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *userQueryBuilder) Update(updatedObject models.User) (*models.User, error) {
+	const partial = false
+	if err := qb.update(updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	return qb.find(updatedObject.ID)
+}
+
+func (qb *userQueryBuilder) Destroy(id int) error {
+	return qb.destroyExisting([]int{id})
+}
+
+func (qb *userQueryBuilder) Find(id int) (*models.User, error) {
+	return qb.find(id)
+}
+
+func (qb *userQueryBuilder) find(id int) (*models.User, error) {
+	var ret models.User
+	if err := qb.get(id, &ret); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *userQueryBuilder) FindByUsername(username string) (*models.User, error) {
+	query := selectAll(userTable) + "WHERE username = ? LIMIT 1"
+	args := []interface{}{username}
+	return qb.queryUser(query, args)
+}
+
+// FindConfigUser returns the user added from the credentials in the
+// configuration.
+func (qb *userQueryBuilder) FindConfigUser() (*models.User, error) {
+	query := selectAll(userTable) + "WHERE is_config = 1 LIMIT 1"
+	return qb.queryUser(query, nil)
+}
+
+func (qb *userQueryBuilder) Count() (int, error) {
+	return qb.runCountQuery(qb.buildCountQuery("SELECT users.id FROM users"), nil)
+}
+
+func (qb *userQueryBuilder) All() ([]*models.User, error) {
+	return qb.queryUsers(selectAll(userTable)+"ORDER BY username ASC", nil)
+}
+
+func (qb *userQueryBuilder) queryUser(query string, args []interface{}) (*models.User, error) {
+	results, err := qb.queryUsers(query, args)
+	if err != nil || len(results) < 1 {
+		return nil, err
+	}
+	return results[0], nil
+}
+
+func (qb *userQueryBuilder) queryUsers(query string, args []interface{}) ([]*models.User, error) {
+	var ret models.Users
+	if err := qb.query(query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.User(ret), nil
+}
@@ -0,0 +1,111 @@
+// +build integration
+
+package sqlite_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestUserCreateUpdateDestroy(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.User()
+
+		created, err := qb.Create(*models.NewUser("userTest", "hash", models.UserRoleReadOnly))
+		if err != nil {
+			return err
+		}
+
+		found, err := qb.FindByUsername("userTest")
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, created.ID, found.ID)
+		assert.Equal(t, models.UserRoleReadOnly, found.Role)
+		assert.False(t, found.APIKey.Valid)
+
+		found.Role = models.UserRoleEditor
+		found.APIKey = sql.NullString{String: "key", Valid: true}
+		updated, err := qb.Update(*found)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, models.UserRoleEditor, updated.Role)
+		assert.Equal(t, "key", updated.APIKey.String)
+
+		configUser, err := qb.FindConfigUser()
+		if err != nil {
+			return err
+		}
+
+		assert.Nil(t, configUser)
+
+		// usernames are unique
+		_, err = qb.Create(*models.NewUser("userTest", "hash", models.UserRoleReadOnly))
+		assert.NotNil(t, err)
+
+		if err := qb.Destroy(created.ID); err != nil {
+			return err
+		}
+
+		found, err = qb.Find(created.ID)
+		if err != nil {
+			return err
+		}
+
+		assert.Nil(t, found)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestSceneAssignPlayHistory(t *testing.T) {
+	if err := withTxn(func(r models.Repository) error {
+		qb := r.User()
+
+		// play history recorded while authentication is disabled has no user
+		sceneID := sceneIDs[sceneIdxWithGallery]
+		if _, err := r.Scene().IncrementPlayCount(sceneID); err != nil {
+			return err
+		}
+
+		created, err := qb.Create(*models.NewUser("userAssignTest", "hash", models.UserRoleAdmin))
+		if err != nil {
+			return err
+		}
+
+		if err := r.Scene().AssignPlayHistory(created.ID); err != nil {
+			return err
+		}
+
+		history, err := r.Scene().GetAllPlayHistory(sceneID)
+		if err != nil {
+			return err
+		}
+
+		var assigned *models.ScenePlayHistory
+		for _, h := range history {
+			assert.True(t, h.UserID.Valid)
+			if h.UserID.Int64 == int64(created.ID) {
+				assigned = h
+			}
+		}
+
+		if assert.NotNil(t, assigned) {
+			assert.NotZero(t, assigned.PlayCount)
+		}
+
+		// the play history of the user is removed with the user
+		return qb.Destroy(created.ID)
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -35,6 +35,10 @@ func (i *Importer) PreImport() error {
 }
 
 func (i *Importer) PostImport(id int) error {
+	if err := i.ReaderWriter.UpdateAliases(id, i.Input.Aliases); err != nil {
+		return fmt.Errorf("error setting tag aliases: %s", err.Error())
+	}
+
 	if len(i.imageData) > 0 {
 		if err := i.ReaderWriter.UpdateImage(id, i.imageData); err != nil {
 			return fmt.Errorf("error setting tag image: %s", err.Error())
@@ -56,11 +56,18 @@ func TestImporterPostImport(t *testing.T) {
 
 	i := Importer{
 		ReaderWriter: readerWriter,
-		imageData:    imageBytes,
+		Input: jsonschema.Tag{
+			Aliases: tagAliases,
+		},
+		imageData: imageBytes,
 	}
 
 	updateTagImageErr := errors.New("UpdateImage error")
+	updateTagAliasesErr := errors.New("UpdateAliases error")
 
+	readerWriter.On("UpdateAliases", tagID, tagAliases).Return(nil).Once()
+	readerWriter.On("UpdateAliases", errImageID, tagAliases).Return(nil).Once()
+	readerWriter.On("UpdateAliases", errAliasID, tagAliases).Return(updateTagAliasesErr).Once()
 	readerWriter.On("UpdateImage", tagID, imageBytes).Return(nil).Once()
 	readerWriter.On("UpdateImage", errImageID, imageBytes).Return(updateTagImageErr).Once()
 
@@ -70,6 +77,9 @@ func TestImporterPostImport(t *testing.T) {
 	err = i.PostImport(errImageID)
 	assert.NotNil(t, err)
 
+	err = i.PostImport(errAliasID)
+	assert.NotNil(t, err)
+
 	readerWriter.AssertExpectations(t)
 }
 
@@ -0,0 +1,83 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ErrNoAdmin is returned when a change would leave the users without a user
+// with the admin role.
+var ErrNoAdmin = errors.New("at least one user must have the ADMIN role")
+
+// HashPassword returns the hash of the password to be stored for a user.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// ValidatePassword returns true if the password matches the stored password
+// of the user.
+func ValidatePassword(u *models.User, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)) == nil
+}
+
+// ValidateUsername returns an error if the username is empty, or is used by
+// a user other than the user with the provided id. id should be 0 for new
+// users.
+func ValidateUsername(qb models.UserReader, username string, id int) error {
+	if username == "" {
+		return errors.New("username must not be empty")
+	}
+
+	existing, err := qb.FindByUsername(username)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("user with username '%s' already exists", username)
+	}
+
+	return nil
+}
+
+// ValidateAdminRemains returns ErrNoAdmin if changing the role of the user
+// with the provided id to newRole would leave the other users without an
+// admin. newRole should be nil if the user is to be deleted.
+func ValidateAdminRemains(qb models.UserReader, id int, newRole *models.UserRole) error {
+	if newRole != nil && *newRole == models.UserRoleAdmin {
+		return nil
+	}
+
+	users, err := qb.All()
+	if err != nil {
+		return err
+	}
+
+	others := 0
+	for _, u := range users {
+		if u.ID == id {
+			continue
+		}
+
+		if u.Role == models.UserRoleAdmin {
+			return nil
+		}
+		others++
+	}
+
+	// removing the last user disables authentication, which is allowed
+	if others == 0 && newRole == nil {
+		return nil
+	}
+
+	return ErrNoAdmin
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	adminID  = 1
+	editorID = 2
+
+	existingUsername = "existing"
+	errUsername      = "error"
+)
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("password")
+	assert.Nil(t, err)
+
+	u := models.NewUser("user", hash, models.UserRoleEditor)
+	assert.True(t, ValidatePassword(u, "password"))
+	assert.False(t, ValidatePassword(u, "wrong"))
+}
+
+func TestValidateUsername(t *testing.T) {
+	readerWriter := &mocks.UserReaderWriter{}
+
+	readerWriter.On("FindByUsername", existingUsername).Return(&models.User{
+		ID:       editorID,
+		Username: existingUsername,
+	}, nil)
+	readerWriter.On("FindByUsername", "new").Return(nil, nil)
+	readerWriter.On("FindByUsername", errUsername).Return(nil, errors.New("FindByUsername error"))
+
+	assert.NotNil(t, ValidateUsername(readerWriter, "", 0))
+	assert.Nil(t, ValidateUsername(readerWriter, "new", 0))
+	assert.NotNil(t, ValidateUsername(readerWriter, existingUsername, 0))
+	assert.Nil(t, ValidateUsername(readerWriter, existingUsername, editorID))
+	assert.NotNil(t, ValidateUsername(readerWriter, errUsername, 0))
+}
+
+func TestValidateAdminRemains(t *testing.T) {
+	admin := &models.User{ID: adminID, Role: models.UserRoleAdmin}
+	editor := &models.User{ID: editorID, Role: models.UserRoleEditor}
+
+	readerWriter := &mocks.UserReaderWriter{}
+	readerWriter.On("All").Return([]*models.User{admin, editor}, nil).Once()
+	readerWriter.On("All").Return([]*models.User{admin}, nil)
+
+	editorRole := models.UserRoleEditor
+	adminRole := models.UserRoleAdmin
+
+	// the other user is not an admin
+	assert.Equal(t, ErrNoAdmin, ValidateAdminRemains(readerWriter, adminID, &editorRole))
+
+	// the last user may be deleted, but must remain an admin
+	assert.Nil(t, ValidateAdminRemains(readerWriter, adminID, nil))
+	assert.Equal(t, ErrNoAdmin, ValidateAdminRemains(readerWriter, adminID, &editorRole))
+	assert.Nil(t, ValidateAdminRemains(readerWriter, adminID, &adminRole))
+
+	readerWriter.AssertExpectations(t)
+}
@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+const (
+	phashSampleSize = 64
+	phashHashSize   = 8
+)
+
+// PerceptionHash calculates a 64-bit perceptual hash of the provided image.
+// The image is reduced to a 64x64 greyscale image, and a discrete cosine
+// transform is performed on it. Each bit of the resulting hash is set if the
+// corresponding low frequency coefficient is greater than the median of the
+// coefficients.
+func PerceptionHash(img image.Image) uint64 {
+	resized := imaging.Resize(img, phashSampleSize, phashSampleSize, imaging.Linear)
+	pixels := greyscalePixels(resized)
+
+	// perform DCT on rows and then on columns
+	for y := range pixels {
+		pixels[y] = dct1D(pixels[y])
+	}
+
+	column := make([]float64, phashSampleSize)
+	for x := 0; x < phashSampleSize; x++ {
+		for y := 0; y < phashSampleSize; y++ {
+			column[y] = pixels[y][x]
+		}
+		column = dct1D(column)
+		for y := 0; y < phashSampleSize; y++ {
+			pixels[y][x] = column[y]
+		}
+	}
+
+	// only use the top-left (low frequency) coefficients
+	var flattened []float64
+	for y := 0; y < phashHashSize; y++ {
+		flattened = append(flattened, pixels[y][:phashHashSize]...)
+	}
+
+	median := medianOf(flattened)
+
+	var hash uint64
+	for i, v := range flattened {
+		if v > median {
+			hash |= 1 << uint(len(flattened)-i-1)
+		}
+	}
+
+	return hash
+}
+
+// PhashToString returns the hexadecimal string representation of the
+// provided perceptual hash.
+func PhashToString(phash int64) string {
+	return strconv.FormatUint(uint64(phash), 16)
+}
+
+// StringToPhash parses a perceptual hash from its hexadecimal string
+// representation.
+func StringToPhash(s string) (int64, error) {
+	ret, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(ret), nil
+}
+
+// PhashDistance returns the Hamming distance between two perceptual hashes.
+func PhashDistance(a, b int64) int {
+	return bits.OnesCount64(uint64(a ^ b))
+}
+
+func greyscalePixels(img *image.NRGBA) [][]float64 {
+	bounds := img.Bounds()
+	w := bounds.Dx()
+	h := bounds.Dy()
+
+	ret := make([][]float64, h)
+	for y := 0; y < h; y++ {
+		ret[y] = make([]float64, w)
+		for x := 0; x < w; x++ {
+			c := img.NRGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			ret[y][x] = 0.299*float64(c.R) + 0.587*float64(c.G) + 0.114*float64(c.B)
+		}
+	}
+
+	return ret
+}
+
+func dct1D(input []float64) []float64 {
+	n := len(input)
+	ret := make([]float64, n)
+	for k := 0; k < n; k++ {
+		sum := 0.0
+		for i, v := range input {
+			sum += v * math.Cos(math.Pi*(float64(i)+0.5)*float64(k)/float64(n))
+		}
+
+		if k == 0 {
+			sum *= math.Sqrt(0.5)
+		}
+		ret[k] = sum * math.Sqrt(2/float64(n))
+	}
+
+	return ret
+}
+
+func medianOf(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	return sorted[len(sorted)/2]
+}
+
+// Phash associates a perceptual hash with the ID of the object it was
+// generated for.
+type Phash struct {
+	ID   int   `db:"id"`
+	Hash int64 `db:"phash"`
+}
+
+// FindDuplicates groups the provided hashes into sets of IDs, where each hash
+// in a set is within the provided Hamming distance of at least one other hash
+// in the same set. Hashes without any duplicates are not returned. Groups are
+// returned in the order in which their first member appears in hashes.
+func FindDuplicates(hashes []*Phash, distance int) [][]int {
+	// union-find over the indexes of the hashes
+	parent := make([]int, len(hashes))
+	for i := range parent {
+		parent[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i, a := range hashes {
+		for j := i + 1; j < len(hashes); j++ {
+			if PhashDistance(a.Hash, hashes[j].Hash) <= distance {
+				ri := find(i)
+				rj := find(j)
+				if ri != rj {
+					if ri < rj {
+						parent[rj] = ri
+					} else {
+						parent[ri] = rj
+					}
+				}
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i, h := range hashes {
+		root := find(i)
+		if _, found := groups[root]; !found {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], h.ID)
+	}
+
+	var ret [][]int
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			ret = append(ret, groups[root])
+		}
+	}
+
+	return ret
+}
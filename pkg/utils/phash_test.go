@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeBlocks returns an image made up of 8x8 blocks of random intensity
+func makeBlocks(w, h int, seed int64) image.Image {
+	r := rand.New(rand.NewSource(seed))
+	var blocks [8][8]uint8
+	for y := range blocks {
+		for x := range blocks[y] {
+			blocks[y][x] = uint8(r.Intn(256))
+		}
+	}
+
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetGray(x, y, color.Gray{Y: blocks[y*8/h][x*8/w]})
+		}
+	}
+
+	return img
+}
+
+func TestPerceptionHash(t *testing.T) {
+	original := int64(PerceptionHash(makeBlocks(320, 180, 1)))
+	scaled := int64(PerceptionHash(makeBlocks(160, 90, 1)))
+	different := int64(PerceptionHash(makeBlocks(320, 180, 2)))
+
+	assert.LessOrEqual(t, PhashDistance(original, scaled), 4, "scaled image should have similar hash")
+	assert.Greater(t, PhashDistance(original, different), 10, "different images should have different hashes")
+}
+
+func TestPhashString(t *testing.T) {
+	values := []int64{0, 1, 0x1234abcd, -1, -0x7edcba9876543210}
+
+	for _, v := range values {
+		s := PhashToString(v)
+		got, err := StringToPhash(s)
+		assert.Nil(t, err)
+		assert.Equal(t, v, got, "round trip of %s", s)
+	}
+
+	_, err := StringToPhash("not a hash")
+	assert.NotNil(t, err)
+}
+
+func TestPhashDistance(t *testing.T) {
+	assert.Equal(t, 0, PhashDistance(0x0f, 0x0f))
+	assert.Equal(t, 4, PhashDistance(0x0f, 0x00))
+	assert.Equal(t, 64, PhashDistance(0, -1))
+}
+
+func TestFindDuplicates(t *testing.T) {
+	hashes := []*Phash{
+		{ID: 1, Hash: 0x00},
+		{ID: 2, Hash: 0xff00},
+		{ID: 3, Hash: 0x01},
+		{ID: 4, Hash: 0xff01},
+		{ID: 5, Hash: 0x03},
+		{ID: 6, Hash: 0x0ff0f0},
+	}
+
+	assert.Equal(t, [][]int{{1, 3, 5}, {2, 4}}, FindDuplicates(hashes, 1))
+	assert.Equal(t, [][]int{{1, 3}, {2, 4}}, FindDuplicates(hashes[:4], 1))
+	assert.Nil(t, FindDuplicates(hashes, 0))
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5, 6}}, FindDuplicates(hashes, 16))
+}
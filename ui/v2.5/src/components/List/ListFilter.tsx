@@ -21,6 +21,7 @@ import { ListFilterModel } from "src/models/list-filter/filter";
 import { DisplayMode } from "src/models/list-filter/types";
 import { useFocus } from "src/utils";
 import { AddFilter } from "./AddFilter";
+import { SavedFilterList } from "./SavedFilterList";
 
 interface IListFilterOperation {
   text: string;
@@ -473,6 +474,11 @@ export const ListFilter: React.FC<IListFilterProps> = (
               </InputGroup.Append>
             </InputGroup>
 
+            <SavedFilterList
+              filter={props.filter}
+              onSetFilter={props.onFilterUpdate}
+            />
+
             <Dropdown as={ButtonGroup} className="mr-2">
               <Dropdown.Toggle split variant="secondary" id="more-menu">
                 {props.filter.sortBy}
@@ -0,0 +1,175 @@
+import _ from "lodash";
+import React, { useState } from "react";
+import {
+  Button,
+  ButtonGroup,
+  Dropdown,
+  FormControl,
+  InputGroup,
+  OverlayTrigger,
+  Tooltip,
+} from "react-bootstrap";
+import {
+  useFindSavedFilters,
+  useSavedFilterDestroy,
+  useSaveFilter,
+  useSetDefaultFilter,
+} from "src/core/StashService";
+import { useToast } from "src/hooks";
+import { Icon, LoadingIndicator } from "src/components/Shared";
+import { ListFilterModel } from "src/models/list-filter/filter";
+import { GQLFilterMode } from "src/models/list-filter/types";
+
+interface ISavedFilterListProps {
+  filter: ListFilterModel;
+  onSetFilter: (f: ListFilterModel) => void;
+}
+
+export const SavedFilterList: React.FC<ISavedFilterListProps> = ({
+  filter,
+  onSetFilter,
+}) => {
+  const Toast = useToast();
+  const mode = GQLFilterMode[filter.filterMode];
+  const { data, loading } = useFindSavedFilters(mode);
+  const [saveFilter] = useSaveFilter();
+  const [destroyFilter] = useSavedFilterDestroy();
+  const [setDefaultFilter] = useSetDefaultFilter();
+
+  const [filterName, setFilterName] = useState("");
+  const savedFilters = data?.findSavedFilters ?? [];
+
+  async function onSaveFilter() {
+    const name = filterName.trim();
+    // overwrite the existing filter with the same name
+    const existing = savedFilters.find((f) => f.name === name);
+
+    try {
+      await saveFilter({
+        variables: {
+          input: {
+            id: existing?.id,
+            mode,
+            name,
+            filter: filter.makeSavedFilter(),
+          },
+        },
+      });
+      Toast.success({ content: `Saved filter "${name}"` });
+      setFilterName("");
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function onDeleteFilter(id: string) {
+    try {
+      await destroyFilter({
+        variables: {
+          input: { id },
+        },
+      });
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  async function onSetDefaultFilter(clear?: boolean) {
+    try {
+      await setDefaultFilter({
+        variables: {
+          input: {
+            mode,
+            filter: clear ? undefined : filter.makeSavedFilter(),
+          },
+        },
+      });
+      Toast.success({
+        content: clear ? "Cleared default filter" : "Set default filter",
+      });
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
+  function onLoadFilter(savedFilter: string) {
+    const newFilter = _.cloneDeep(filter);
+    newFilter.configureFromSavedFilter(savedFilter);
+    onSetFilter(newFilter);
+  }
+
+  function renderSavedFilters() {
+    if (loading) {
+      return <LoadingIndicator inline small />;
+    }
+
+    return savedFilters.map((f) => (
+      <Dropdown.Item
+        as="div"
+        key={f.id}
+        className="bg-secondary text-white d-flex justify-content-between align-items-center saved-filter-item"
+        onClick={() => onLoadFilter(f.filter)}
+      >
+        <span>{f.name}</span>
+        <Button
+          size="sm"
+          className="minimal ml-2"
+          title="Delete"
+          onClick={(e: React.MouseEvent) => {
+            e.stopPropagation();
+            onDeleteFilter(f.id);
+          }}
+        >
+          <Icon icon="times" />
+        </Button>
+      </Dropdown.Item>
+    ));
+  }
+
+  return (
+    <Dropdown as={ButtonGroup} className="mr-2">
+      <OverlayTrigger
+        overlay={<Tooltip id="saved-filters-tooltip">Saved filters</Tooltip>}
+      >
+        <Dropdown.Toggle variant="secondary" id="saved-filters-menu">
+          <Icon icon="bookmark" />
+        </Dropdown.Toggle>
+      </OverlayTrigger>
+      <Dropdown.Menu className="bg-secondary text-white saved-filter-menu">
+        <InputGroup className="px-2 pb-2">
+          <FormControl
+            className="bg-secondary text-white border-secondary"
+            placeholder="Filter name..."
+            value={filterName}
+            onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+              setFilterName(e.currentTarget.value)
+            }
+          />
+          <InputGroup.Append>
+            <Button
+              variant="primary"
+              disabled={!filterName.trim()}
+              onClick={() => onSaveFilter()}
+            >
+              Save
+            </Button>
+          </InputGroup.Append>
+        </InputGroup>
+        {renderSavedFilters()}
+        <Dropdown.Divider />
+        <Dropdown.Item
+          className="bg-secondary text-white"
+          onClick={() => onSetDefaultFilter()}
+        >
+          Set as default
+        </Dropdown.Item>
+        <Dropdown.Item
+          className="bg-secondary text-white"
+          onClick={() => onSetDefaultFilter(true)}
+        >
+          Clear default
+        </Dropdown.Item>
+      </Dropdown.Menu>
+    </Dropdown>
+  );
+};
@@ -2,7 +2,11 @@
 import React from "react";
 import ReactJWPlayer from "react-jw-player";
 import * as GQL from "src/core/generated-graphql";
-import { useConfiguration } from "src/core/StashService";
+import {
+  mutateSceneIncrementPlayCount,
+  mutateSceneSaveActivity,
+  useConfiguration,
+} from "src/core/StashService";
 import { JWUtils } from "src/utils";
 import { ScenePlayerScrubber } from "./ScenePlayerScrubber";
 
@@ -18,6 +22,9 @@ interface IScenePlayerProps {
   onComplete?: () => void;
   config?: GQL.ConfigInterfaceDataFragment;
 }
+// interval in milliseconds at which the playback activity is saved
+const SAVE_ACTIVITY_INTERVAL = 10000;
+
 interface IScenePlayerState {
   scrubberPosition: number;
   // eslint-disable-next-line @typescript-eslint/no-explicit-any
@@ -42,6 +49,12 @@ export class ScenePlayerImpl extends React.Component<
   private playlist: any;
   private lastTime = 0;
 
+  // playback activity of the scene that has not been saved
+  private playCountIncremented = false;
+  private activityPosition = 0;
+  private unsavedPlayDuration = 0;
+  private saveActivityInterval?: number;
+
   constructor(props: IScenePlayerProps) {
     super(props);
     this.onReady = this.onReady.bind(this);
@@ -73,6 +86,16 @@ export class ScenePlayerImpl extends React.Component<
     if (prevProps.timestamp !== this.props.timestamp) {
       this.player.seek(this.props.timestamp);
     }
+
+    if (prevProps.scene.id !== this.props.scene.id) {
+      this.saveActivity(prevProps.scene.id);
+      this.playCountIncremented = false;
+    }
+  }
+
+  public componentWillUnmount() {
+    window.clearInterval(this.saveActivityInterval);
+    this.saveActivity(this.props.scene.id);
   }
 
   onIncrease() {
@@ -124,8 +147,44 @@ export class ScenePlayerImpl extends React.Component<
     this.player.on("firstFrame", () => {
       if (this.props.timestamp > 0) {
         this.player.seek(this.props.timestamp);
+      } else if (this.props.scene.resume_time) {
+        this.player.seek(this.props.scene.resume_time);
       }
     });
+
+    this.player.on("play", () => {
+      this.activityPosition = this.player.getPosition();
+      if (!this.playCountIncremented) {
+        this.playCountIncremented = true;
+        mutateSceneIncrementPlayCount(this.props.scene.id);
+      }
+
+      window.clearInterval(this.saveActivityInterval);
+      this.saveActivityInterval = window.setInterval(
+        () => this.saveActivity(this.props.scene.id),
+        SAVE_ACTIVITY_INTERVAL
+      );
+    });
+
+    this.player.on("pause", () => {
+      window.clearInterval(this.saveActivityInterval);
+      this.saveActivity(this.props.scene.id);
+    });
+  }
+
+  private saveActivity(sceneID: string, resumeTime?: number) {
+    if (!this.player || !this.playCountIncremented) {
+      return;
+    }
+
+    const playDuration = this.unsavedPlayDuration;
+    this.unsavedPlayDuration = 0;
+
+    mutateSceneSaveActivity(
+      sceneID,
+      resumeTime ?? this.player.getPosition(),
+      playDuration
+    );
   }
 
   private onSeeked() {
@@ -136,6 +195,14 @@ export class ScenePlayerImpl extends React.Component<
 
   private onTime() {
     const position = this.player.getPosition();
+
+    // only count continuous playback towards the play duration
+    const played = position - this.activityPosition;
+    if (played > 0 && played < 1) {
+      this.unsavedPlayDuration += played;
+    }
+    this.activityPosition = position;
+
     const difference = Math.abs(position - this.lastTime);
     if (difference > 1) {
       this.lastTime = position;
@@ -144,6 +211,13 @@ export class ScenePlayerImpl extends React.Component<
   }
 
   private onComplete() {
+    // the scene was played to the end, so it should be played from the start
+    // next time
+    window.clearInterval(this.saveActivityInterval);
+    this.saveActivity(this.props.scene.id, 0);
+    // playing the scene again counts as another play
+    this.playCountIncremented = false;
+
     if (this.props?.onComplete) {
       this.props.onComplete();
     }
@@ -230,7 +304,11 @@ export class ScenePlayerImpl extends React.Component<
     };
 
     const seekHook = (seekToPosition: number, _videoTag: HTMLVideoElement) => {
-      if (!_videoTag.src || _videoTag.src.endsWith(".m3u8")) {
+      if (
+        !_videoTag.src ||
+        _videoTag.src.endsWith(".m3u8") ||
+        _videoTag.src.endsWith(".mpd")
+      ) {
         return false;
       }
 
@@ -88,6 +88,24 @@ export const SceneDetailPanel: React.FC<ISceneDetailProps> = (props) => {
           ) : (
             ""
           )}
+          {props.scene.play_count ? (
+            <h6>
+              Play Count: {props.scene.play_count}
+              {props.scene.last_played_at && (
+                <>
+                  {" "}
+                  (last played{" "}
+                  <FormattedDate
+                    value={props.scene.last_played_at}
+                    format="long"
+                  />
+                  )
+                </>
+              )}
+            </h6>
+          ) : (
+            ""
+          )}
           {props.scene.file.width && props.scene.file.height && (
             <h6>
               Resolution:{" "}
@@ -1,6 +1,9 @@
 import React from "react";
+import { Button } from "react-bootstrap";
 import { FormattedNumber } from "react-intl";
 import * as GQL from "src/core/generated-graphql";
+import { useSceneSetPrimaryFile } from "src/core/StashService";
+import { useToast } from "src/hooks";
 import { TextUtils } from "src/utils";
 import { TruncatedText } from "src/components/Shared";
 
@@ -11,6 +14,19 @@ interface ISceneFileInfoPanelProps {
 export const SceneFileInfoPanel: React.FC<ISceneFileInfoPanelProps> = (
   props: ISceneFileInfoPanelProps
 ) => {
+  const Toast = useToast();
+  const [setPrimaryFile] = useSceneSetPrimaryFile();
+
+  async function onSetPrimaryFile(fileID: string) {
+    try {
+      await setPrimaryFile({
+        variables: { id: props.scene.id, file_id: fileID },
+      });
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   function renderOSHash() {
     if (props.scene.oshash) {
       return (
@@ -186,6 +202,38 @@ export const SceneFileInfoPanel: React.FC<ISceneFileInfoPanelProps> = (
     );
   }
 
+  function renderOtherFiles() {
+    const otherFiles = props.scene.files.filter((f) => !f.primary);
+    if (!otherFiles.length) {
+      return;
+    }
+
+    return (
+      <div className="row">
+        <span className="col-4">Other Files</span>
+        <ul className="col-8">
+          {otherFiles.map((file) => (
+            <li key={file.id} className="row no-gutters">
+              <a href={`file://${file.path}`}>
+                <TruncatedText text={`file://${file.path}`} />
+              </a>
+              <span className="mx-2">
+                {file.width} x {file.height}
+              </span>
+              <Button
+                size="sm"
+                variant="secondary"
+                onClick={() => onSetPrimaryFile(file.id)}
+              >
+                Make primary
+              </Button>
+            </li>
+          ))}
+        </ul>
+      </div>
+    );
+  }
+
   function renderStashIDs() {
     if (!props.scene.stash_ids.length) {
       return;
@@ -232,6 +280,7 @@ export const SceneFileInfoPanel: React.FC<ISceneFileInfoPanelProps> = (
       {renderbitrate()}
       {renderVideoCodec()}
       {renderAudioCodec()}
+      {renderOtherFiles()}
       {renderUrl()}
       {renderStashIDs()}
     </div>
@@ -103,6 +103,11 @@ export const SettingsConfigurationPanel: React.FC = () => {
   const [maxStreamingTranscodeSize, setMaxStreamingTranscodeSize] = useState<
     GQL.StreamingResolutionEnum | undefined
   >(undefined);
+  const [maxTranscodeSessions, setMaxTranscodeSessions] = useState<number>(0);
+  const [transcodeIdleTimeout, setTranscodeIdleTimeout] = useState<number>(0);
+  const [streamSegmentCacheSize, setStreamSegmentCacheSize] = useState<number>(
+    0
+  );
   const [username, setUsername] = useState<string | undefined>(undefined);
   const [password, setPassword] = useState<string | undefined>(undefined);
   const [maxSessionAge, setMaxSessionAge] = useState<number>(0);
@@ -120,6 +125,7 @@ export const SettingsConfigurationPanel: React.FC = () => {
     createGalleriesFromFolders,
     setCreateGalleriesFromFolders,
   ] = useState<boolean>(false);
+  const [watchStashPaths, setWatchStashPaths] = useState<boolean>(false);
 
   const [excludes, setExcludes] = useState<string[]>([]);
   const [imageExcludes, setImageExcludes] = useState<string[]>([]);
@@ -156,6 +162,9 @@ export const SettingsConfigurationPanel: React.FC = () => {
     previewPreset: (previewPreset as GQL.PreviewPreset) ?? undefined,
     maxTranscodeSize,
     maxStreamingTranscodeSize,
+    maxTranscodeSessions,
+    transcodeIdleTimeout,
+    streamSegmentCacheSize,
     username,
     password,
     maxSessionAge,
@@ -164,6 +173,7 @@ export const SettingsConfigurationPanel: React.FC = () => {
     logLevel,
     logAccess,
     createGalleriesFromFolders,
+    watchStashPaths,
     videoExtensions: commaDelimitedToList(videoExtensions),
     imageExtensions: commaDelimitedToList(imageExtensions),
     galleryExtensions: commaDelimitedToList(galleryExtensions),
@@ -203,6 +213,9 @@ export const SettingsConfigurationPanel: React.FC = () => {
       setMaxStreamingTranscodeSize(
         conf.general.maxStreamingTranscodeSize ?? undefined
       );
+      setMaxTranscodeSessions(conf.general.maxTranscodeSessions);
+      setTranscodeIdleTimeout(conf.general.transcodeIdleTimeout);
+      setStreamSegmentCacheSize(conf.general.streamSegmentCacheSize);
       setUsername(conf.general.username);
       setPassword(conf.general.password);
       setMaxSessionAge(conf.general.maxSessionAge);
@@ -211,6 +224,7 @@ export const SettingsConfigurationPanel: React.FC = () => {
       setLogLevel(conf.general.logLevel);
       setLogAccess(conf.general.logAccess);
       setCreateGalleriesFromFolders(conf.general.createGalleriesFromFolders);
+      setWatchStashPaths(conf.general.watchStashPaths);
       setVideoExtensions(listToCommaDelimited(conf.general.videoExtensions));
       setImageExtensions(listToCommaDelimited(conf.general.imageExtensions));
       setGalleryExtensions(
@@ -508,6 +522,19 @@ export const SettingsConfigurationPanel: React.FC = () => {
             If true, creates galleries from folders containing images.
           </Form.Text>
         </Form.Group>
+
+        <Form.Group>
+          <Form.Check
+            id="watch-stash-paths"
+            checked={watchStashPaths}
+            label="Watch stash paths for changes"
+            onChange={() => setWatchStashPaths(!watchStashPaths)}
+          />
+          <Form.Text className="text-muted">
+            If true, new, changed and removed files in the stash paths are
+            scanned or cleaned automatically.
+          </Form.Text>
+        </Form.Group>
       </Form.Group>
 
       <hr />
@@ -602,6 +629,58 @@ export const SettingsConfigurationPanel: React.FC = () => {
             Maximum size for transcoded streams
           </Form.Text>
         </Form.Group>
+        <Form.Group id="max-transcode-sessions">
+          <h6>Maximum concurrent streaming transcodes</h6>
+          <Form.Control
+            className="col col-sm-6 text-input"
+            type="number"
+            value={maxTranscodeSessions}
+            onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+              setMaxTranscodeSessions(
+                Number.parseInt(e.currentTarget.value || "0", 10)
+              )
+            }
+          />
+          <Form.Text className="text-muted">
+            Maximum number of streams transcoded at the same time. Set to 0
+            for no limit.
+          </Form.Text>
+        </Form.Group>
+        <Form.Group id="transcode-idle-timeout">
+          <h6>Streaming transcode idle timeout (seconds)</h6>
+          <Form.Control
+            className="col col-sm-6 text-input"
+            type="number"
+            value={transcodeIdleTimeout}
+            onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+              setTranscodeIdleTimeout(
+                Number.parseInt(e.currentTarget.value || "0", 10)
+              )
+            }
+          />
+          <Form.Text className="text-muted">
+            Streaming transcodes that have not been accessed for this long are
+            stopped.
+          </Form.Text>
+        </Form.Group>
+        <Form.Group id="stream-segment-cache-size">
+          <h6>Stream segment cache size (MB)</h6>
+          <Form.Control
+            className="col col-sm-6 text-input"
+            type="number"
+            value={streamSegmentCacheSize}
+            onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+              setStreamSegmentCacheSize(
+                Number.parseInt(e.currentTarget.value || "0", 10)
+              )
+            }
+          />
+          <Form.Text className="text-muted">
+            Maximum size of the cached HLS/DASH stream segments. The least
+            recently watched segments are deleted when the cache is larger
+            than this. Set to 0 for no limit.
+          </Form.Text>
+        </Form.Group>
       </Form.Group>
 
       <hr />
@@ -10,7 +10,7 @@ import {
   mutateMetadataAutoTag,
   mutateMetadataExport,
   mutateMigrateHashNaming,
-  mutateStopJob,
+  mutateStopAllJobs,
   usePlugins,
   mutateRunPluginTask,
   mutateBackupDatabase,
@@ -83,7 +83,8 @@ export const SettingsTasksPanel: React.FC = () => {
       case "Migrate":
         return "Migrating";
       default:
-        return "Idle";
+        // jobs report their own description
+        return s || "Idle";
     }
   }
 
@@ -260,7 +261,7 @@ export const SettingsTasksPanel: React.FC = () => {
         <Button
           id="stop"
           variant="danger"
-          onClick={() => mutateStopJob().then(() => jobStatus.refetch())}
+          onClick={() => mutateStopAllJobs().then(() => jobStatus.refetch())}
         >
           Stop
         </Button>
@@ -17,6 +17,7 @@ import {
   DetailsEditNavbar,
   Modal,
   LoadingIndicator,
+  TagSelect,
 } from "src/components/Shared";
 import { useToast } from "src/hooks";
 import { TagScenesPanel } from "./TagScenesPanel";
@@ -43,6 +44,9 @@ export const Tag: React.FC = () => {
   // Editing tag state
   const [image, setImage] = useState<string | null>();
   const [name, setName] = useState<string>();
+  const [aliases, setAliases] = useState<string>();
+  const [parentIds, setParentIds] = useState<string[]>([]);
+  const [childIds, setChildIds] = useState<string[]>([]);
 
   // Tag state
   const [tag, setTag] = useState<GQL.TagDataFragment | undefined>();
@@ -88,6 +92,9 @@ export const Tag: React.FC = () => {
 
   function updateTagEditState(state: GQL.TagDataFragment) {
     setName(state.name);
+    setAliases(state.aliases.join(", "));
+    setParentIds(state.parents.map((t) => t.id));
+    setChildIds(state.children.map((t) => t.id));
   }
 
   function updateTagData(tagData: GQL.TagDataFragment) {
@@ -119,16 +126,27 @@ export const Tag: React.FC = () => {
   }
 
   function getTagInput() {
+    const aliasList = (aliases ?? "")
+      .split(",")
+      .map((a) => a.trim())
+      .filter((a) => a !== "");
+
     if (!isNew) {
       return {
         id,
         name,
+        aliases: aliasList,
         image,
+        parent_ids: parentIds,
+        child_ids: childIds,
       };
     }
     return {
       name,
+      aliases: aliasList,
       image,
+      parent_ids: parentIds,
+      child_ids: childIds,
     };
   }
 
@@ -201,6 +219,26 @@ export const Tag: React.FC = () => {
     }
   }
 
+  function renderTagsField(
+    title: string,
+    ids: string[],
+    setIds: (ids: string[]) => void
+  ) {
+    return (
+      <tr>
+        <td>{title}</td>
+        <td>
+          <TagSelect
+            isMulti
+            isDisabled={!isEditing}
+            onSelect={(items) => setIds(items.map((item) => item.id))}
+            ids={ids}
+          />
+        </td>
+      </tr>
+    );
+  }
+
   function onClearImage() {
     setImage(null);
     setImagePreview(
@@ -232,6 +270,14 @@ export const Tag: React.FC = () => {
               isEditing: !!isEditing,
               onChange: setName,
             })}
+            {TableUtils.renderInputGroup({
+              title: "Aliases",
+              value: aliases ?? "",
+              isEditing: !!isEditing,
+              onChange: setAliases,
+            })}
+            {renderTagsField("Parent Tags", parentIds, setParentIds)}
+            {renderTagsField("Sub-Tags", childIds, setChildIds)}
           </tbody>
         </Table>
         <DetailsEditNavbar
@@ -254,10 +254,48 @@ export const useScrapePerformer = (
   });
 
 export const useListSceneScrapers = () => GQL.useListSceneScrapersQuery();
+export const useScrapeSceneQuery = (scraperId: string, q: string) =>
+  GQL.useScrapeSceneQueryQuery({
+    variables: { scraper_id: scraperId, query: q },
+    skip: q === "",
+  });
+export const useScrapeSceneQueryFragment = (
+  scraperId: string,
+  scene: GQL.ScrapedSceneInput
+) =>
+  GQL.useScrapeSceneQueryFragmentQuery({
+    variables: { scraper_id: scraperId, scene },
+  });
 
 export const useListGalleryScrapers = () => GQL.useListGalleryScrapersQuery();
+export const useScrapeGalleryList = (scraperId: string, q: string) =>
+  GQL.useScrapeGalleryListQuery({
+    variables: { scraper_id: scraperId, query: q },
+    skip: q === "",
+  });
+export const useScrapeGalleryQueryFragment = (
+  scraperId: string,
+  gallery: GQL.ScrapedGalleryInput
+) =>
+  GQL.useScrapeGalleryQueryFragmentQuery({
+    variables: { scraper_id: scraperId, gallery },
+  });
 
 export const useListMovieScrapers = () => GQL.useListMovieScrapersQuery();
+export const useListScraperConfigErrors = () =>
+  GQL.useListScraperConfigErrorsQuery();
+export const useScrapeMovieList = (scraperId: string, q: string) =>
+  GQL.useScrapeMovieListQuery({
+    variables: { scraper_id: scraperId, query: q },
+    skip: q === "",
+  });
+export const useScrapeMovie = (
+  scraperId: string,
+  scrapedMovie: GQL.ScrapedMovieInput
+) =>
+  GQL.useScrapeMovieQuery({
+    variables: { scraper_id: scraperId, scraped_movie: scrapedMovie },
+  });
 
 export const useScrapeFreeonesPerformers = (q: string) =>
   GQL.useScrapeFreeonesPerformersQuery({ variables: { q } });
@@ -417,6 +455,26 @@ export const useSceneResetO = (id: string) =>
     update: (cache, data) => updateSceneO(id, cache, data.data?.sceneResetO),
   });
 
+export const mutateSceneIncrementPlayCount = (id: string) =>
+  client.mutate<GQL.SceneIncrementPlayCountMutation>({
+    mutation: GQL.SceneIncrementPlayCountDocument,
+    variables: { id },
+  });
+
+export const mutateSceneSaveActivity = (
+  id: string,
+  resumeTime?: number,
+  playDuration?: number
+) =>
+  client.mutate<GQL.SceneSaveActivityMutation>({
+    mutation: GQL.SceneSaveActivityDocument,
+    variables: {
+      id,
+      resume_time: resumeTime,
+      play_duration: playDuration,
+    },
+  });
+
 export const useSceneDestroy = (input: GQL.SceneDestroyInput) =>
   GQL.useSceneDestroyMutation({
     variables: input,
@@ -429,6 +487,13 @@ export const useScenesDestroy = (input: GQL.ScenesDestroyInput) =>
     update: deleteCache(sceneMutationImpactedQueries),
   });
 
+export const useSceneMerge = () =>
+  GQL.useSceneMergeMutation({
+    update: deleteCache(sceneMutationImpactedQueries),
+  });
+
+export const useSceneSetPrimaryFile = () => GQL.useSceneSetPrimaryFileMutation();
+
 export const useSceneGenerateScreenshot = () =>
   GQL.useSceneGenerateScreenshotMutation({
     update: deleteCache([GQL.FindScenesDocument]),
@@ -654,6 +719,12 @@ export const useTagUpdate = () =>
   GQL.useTagUpdateMutation({
     update: deleteCache(tagMutationImpactedQueries),
   });
+
+export const useTagsMerge = () =>
+  GQL.useTagsMergeMutation({
+    update: deleteCache(tagMutationImpactedQueries),
+  });
+
 export const useTagDestroy = (input: GQL.TagDestroyInput) =>
   GQL.useTagDestroyMutation({
     variables: input,
@@ -700,9 +771,112 @@ export const useJobStatus = () =>
     fetchPolicy: "no-cache",
   });
 
-export const mutateStopJob = () =>
+export const useJobQueue = () =>
+  GQL.useJobQueueQuery({
+    fetchPolicy: "no-cache",
+  });
+
+export const useJobsSubscribe = () => GQL.useJobsSubscribeSubscription();
+
+export const mutateStopJob = (jobID: string) =>
   client.mutate<GQL.StopJobMutation>({
     mutation: GQL.StopJobDocument,
+    variables: {
+      job_id: jobID,
+    },
+  });
+
+export const mutateStopAllJobs = () =>
+  client.mutate<GQL.StopAllJobsMutation>({
+    mutation: GQL.StopAllJobsDocument,
+  });
+
+export const useStreamSessions = () =>
+  GQL.useStreamSessionsQuery({
+    fetchPolicy: "no-cache",
+  });
+
+export const mutateKillStreamSession = (id: string) =>
+  client.mutate<GQL.KillStreamSessionMutation>({
+    mutation: GQL.KillStreamSessionDocument,
+    variables: {
+      id,
+    },
+  });
+
+export const useScheduledTasks = () => GQL.useScheduledTasksQuery();
+
+export const useScheduledTaskCreate = () =>
+  GQL.useScheduledTaskCreateMutation({
+    refetchQueries: getQueryNames([GQL.ScheduledTasksDocument]),
+    update: deleteCache([GQL.ScheduledTasksDocument]),
+  });
+
+export const useScheduledTaskUpdate = () =>
+  GQL.useScheduledTaskUpdateMutation({
+    refetchQueries: getQueryNames([GQL.ScheduledTasksDocument]),
+    update: deleteCache([GQL.ScheduledTasksDocument]),
+  });
+
+export const useScheduledTaskDestroy = () =>
+  GQL.useScheduledTaskDestroyMutation({
+    refetchQueries: getQueryNames([GQL.ScheduledTasksDocument]),
+    update: deleteCache([GQL.ScheduledTasksDocument]),
+  });
+
+export const useCurrentUser = () => GQL.useCurrentUserQuery();
+export const useUsers = () => GQL.useUsersQuery();
+
+export const useUserCreate = () =>
+  GQL.useUserCreateMutation({
+    refetchQueries: getQueryNames([GQL.UsersDocument]),
+    update: deleteCache([GQL.UsersDocument]),
+  });
+
+export const useUserUpdate = () =>
+  GQL.useUserUpdateMutation({
+    refetchQueries: getQueryNames([GQL.UsersDocument, GQL.CurrentUserDocument]),
+    update: deleteCache([GQL.UsersDocument, GQL.CurrentUserDocument]),
+  });
+
+export const useUserDestroy = () =>
+  GQL.useUserDestroyMutation({
+    refetchQueries: getQueryNames([GQL.UsersDocument]),
+    update: deleteCache([GQL.UsersDocument]),
+  });
+
+export const useUserGenerateAPIKey = () =>
+  GQL.useUserGenerateAPIKeyMutation({
+    refetchQueries: getQueryNames([GQL.UsersDocument, GQL.CurrentUserDocument]),
+    update: deleteCache([GQL.UsersDocument, GQL.CurrentUserDocument]),
+  });
+
+export const useFindSavedFilters = (mode: GQL.FilterMode) =>
+  GQL.useFindSavedFiltersQuery({
+    variables: { mode },
+  });
+
+export const useFindDefaultFilter = (mode: GQL.FilterMode) =>
+  GQL.useFindDefaultFilterQuery({
+    variables: { mode },
+  });
+
+export const useSaveFilter = () =>
+  GQL.useSaveFilterMutation({
+    refetchQueries: getQueryNames([GQL.FindSavedFiltersDocument]),
+    update: deleteCache([GQL.FindSavedFiltersDocument]),
+  });
+
+export const useSavedFilterDestroy = () =>
+  GQL.useDestroySavedFilterMutation({
+    refetchQueries: getQueryNames([GQL.FindSavedFiltersDocument]),
+    update: deleteCache([GQL.FindSavedFiltersDocument]),
+  });
+
+export const useSetDefaultFilter = () =>
+  GQL.useSetDefaultFilterMutation({
+    refetchQueries: getQueryNames([GQL.FindDefaultFilterDocument]),
+    update: deleteCache([GQL.FindDefaultFilterDocument]),
   });
 
 export const queryScrapeFreeones = (performerName: string) =>
@@ -807,9 +981,31 @@ export const mutateReloadScrapers = () =>
       GQL.refetchListMovieScrapersQuery(),
       GQL.refetchListPerformerScrapersQuery(),
       GQL.refetchListSceneScrapersQuery(),
+      GQL.refetchListScraperConfigErrorsQuery(),
     ],
   });
 
+export const queryTestScraper = (
+  scraperId: string,
+  type: GQL.ScrapeContentType,
+  input: GQL.ScraperTestInput
+) =>
+  client.query<GQL.TestScraperQuery>({
+    query: GQL.TestScraperDocument,
+    variables: {
+      scraper_id: scraperId,
+      type,
+      input,
+    },
+    fetchPolicy: "network-only",
+  });
+
+export const mutateClearScraperCache = (scraperID?: string) =>
+  client.mutate<GQL.ClearScraperCacheMutation>({
+    mutation: GQL.ClearScraperCacheDocument,
+    variables: { scraper_id: scraperID },
+  });
+
 export const mutateReloadPlugins = () =>
   client.mutate<GQL.ReloadPluginsMutation>({
     mutation: GQL.ReloadPluginsDocument,
@@ -838,6 +1034,12 @@ export const mutateMetadataAutoTag = (input: GQL.AutoTagMetadataInput) =>
     variables: { input },
   });
 
+export const mutateMetadataIdentify = (input: GQL.IdentifyMetadataInput) =>
+  client.mutate<GQL.MetadataIdentifyMutation>({
+    mutation: GQL.MetadataIdentifyDocument,
+    variables: { input },
+  });
+
 export const mutateMetadataGenerate = (input: GQL.GenerateMetadataInput) =>
   client.mutate<GQL.MetadataGenerateMutation>({
     mutation: GQL.MetadataGenerateDocument,
@@ -953,6 +1155,48 @@ export const stashBoxQuery = (searchVal: string, stashBoxIndex: number) =>
     variables: { input: { q: searchVal, stash_box_index: stashBoxIndex } },
   });
 
+export const stashBoxPerformerQuery = (
+  searchVal: string,
+  stashBoxIndex: number
+) =>
+  client?.query<
+    GQL.QueryStashBoxPerformerQuery,
+    GQL.QueryStashBoxPerformerQueryVariables
+  >({
+    query: GQL.QueryStashBoxPerformerDocument,
+    variables: { input: { q: searchVal, stash_box_index: stashBoxIndex } },
+  });
+
+export const stashBoxStudioQuery = (searchVal: string, stashBoxIndex: number) =>
+  client?.query<
+    GQL.QueryStashBoxStudioQuery,
+    GQL.QueryStashBoxStudioQueryVariables
+  >({
+    query: GQL.QueryStashBoxStudioDocument,
+    variables: { input: { q: searchVal, stash_box_index: stashBoxIndex } },
+  });
+
+export const mutateStashBoxRefreshPerformers = () =>
+  client.mutate<GQL.StashBoxRefreshPerformersMutation>({
+    mutation: GQL.StashBoxRefreshPerformersDocument,
+  });
+
+export const mutateSubmitStashBoxSceneDraft = (
+  input: GQL.StashBoxDraftSubmissionInput
+) =>
+  client.mutate<GQL.SubmitStashBoxSceneDraftMutation>({
+    mutation: GQL.SubmitStashBoxSceneDraftDocument,
+    variables: { input },
+  });
+
+export const mutateSubmitStashBoxPerformerDraft = (
+  input: GQL.StashBoxDraftSubmissionInput
+) =>
+  client.mutate<GQL.SubmitStashBoxPerformerDraftMutation>({
+    mutation: GQL.SubmitStashBoxPerformerDraftDocument,
+    variables: { input },
+  });
+
 export const stashBoxBatchQuery = (sceneIds: string[], stashBoxIndex: number) =>
   client?.query<
     GQL.QueryStashBoxSceneQuery,
@@ -35,9 +35,10 @@ import {
   useFindGalleries,
   useFindPerformers,
   useFindTags,
+  useFindDefaultFilter,
 } from "src/core/StashService";
 import { ListFilterModel } from "src/models/list-filter/filter";
-import { FilterMode } from "src/models/list-filter/types";
+import { FilterMode, GQLFilterMode } from "src/models/list-filter/types";
 
 const getSelectedData = <I extends IDataItem>(
   result: I[],
@@ -434,6 +435,11 @@ const useList = <QueryResult extends IQueryResult, QueryData extends IDataItem>(
     new ListFilterModel(options.filterMode, queryString.parse(location.search))
   );
 
+  const {
+    data: defaultFilterData,
+    loading: defaultFilterLoading,
+  } = useFindDefaultFilter(GQLFilterMode[options.filterMode]);
+
   const updateInterfaceConfig = useCallback(
     (updatedFilter: ListFilterModel, level: PersistanceLevel) => {
       setInterfaceState((prevState) => {
@@ -463,6 +469,7 @@ const useList = <QueryResult extends IQueryResult, QueryData extends IDataItem>(
   useEffect(() => {
     if (
       interfaceState.loading ||
+      defaultFilterLoading ||
       // Only update query params on page the hook was mounted on
       history.location.pathname !== originalPathName.current
     )
@@ -473,10 +480,31 @@ const useList = <QueryResult extends IQueryResult, QueryData extends IDataItem>(
     if (!options.persistState) return;
 
     const storedQuery = interfaceState.data?.[persistanceKey];
-    if (!storedQuery) return;
+    const defaultFilter = defaultFilterData?.findDefaultFilter?.filter;
+    if (!storedQuery && !defaultFilter) return;
 
     const queryFilter = queryString.parse(history.location.search);
-    const storedFilter = queryString.parse(storedQuery.filter);
+    const storedFilter = queryString.parse(storedQuery?.filter ?? "");
+
+    // The default filter is used instead of the stored filter when
+    // navigating to the list without query parameters.
+    if (!history.location.search && defaultFilter) {
+      const defaultListFilter = new ListFilterModel(options.filterMode, {
+        disp: storedFilter.disp,
+      });
+      defaultListFilter.configureFromSavedFilter(defaultFilter);
+      if (
+        defaultListFilter.makeQueryParameters() !==
+        filter.makeQueryParameters()
+      ) {
+        setFilter(defaultListFilter);
+      }
+      history.replace({
+        ...history.location,
+        search: defaultListFilter.makeQueryParameters(),
+      });
+      return;
+    }
 
     const activeFilter =
       options.persistState === PersistanceLevel.ALL
@@ -511,6 +539,8 @@ const useList = <QueryResult extends IQueryResult, QueryData extends IDataItem>(
     filter,
     interfaceState.data,
     interfaceState.loading,
+    defaultFilterData,
+    defaultFilterLoading,
     history,
     location.search,
     options.filterMode,
@@ -10,6 +10,9 @@ export type CriterionType =
   | "rating"
   | "organized"
   | "o_counter"
+  | "play_count"
+  | "resume_time"
+  | "play_duration"
   | "resolution"
   | "average_resolution"
   | "duration"
@@ -65,6 +68,12 @@ export abstract class Criterion {
         return "Organized";
       case "o_counter":
         return "O-Counter";
+      case "play_count":
+        return "Play Count";
+      case "resume_time":
+        return "Resume Time";
+      case "play_duration":
+        return "Play Duration";
       case "resolution":
         return "Resolution";
       case "average_resolution":
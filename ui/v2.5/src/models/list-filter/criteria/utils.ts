@@ -41,6 +41,7 @@ export function makeCriteria(type: CriterionType = "none") {
     case "organized":
       return new OrganizedCriterion();
     case "o_counter":
+    case "play_count":
     case "scene_count":
     case "marker_count":
     case "image_count":
@@ -52,6 +53,8 @@ export function makeCriteria(type: CriterionType = "none") {
     case "average_resolution":
       return new AverageResolutionCriterion();
     case "duration":
+    case "resume_time":
+    case "play_duration":
       return new DurationCriterion(type, type);
     case "favorite":
       return new FavoriteCriterion();
@@ -113,6 +113,7 @@ export class ListFilterModel {
 
   public constructor(filterMode: FilterMode, rawParms?: ParsedQuery<string>) {
     const params = rawParms as IQueryParameters;
+    this.filterMode = filterMode;
     switch (filterMode) {
       case FilterMode.Scenes:
         this.sortBy = "date";
@@ -122,6 +123,10 @@ export class ListFilterModel {
           "rating",
           "organized",
           "o_counter",
+          "play_count",
+          "last_played_at",
+          "resume_time",
+          "play_duration",
           "date",
           "filesize",
           "file_mod_time",
@@ -142,6 +147,9 @@ export class ListFilterModel {
           new RatingCriterionOption(),
           new OrganizedCriterionOption(),
           ListFilterModel.createCriterionOption("o_counter"),
+          ListFilterModel.createCriterionOption("play_count"),
+          ListFilterModel.createCriterionOption("resume_time"),
+          ListFilterModel.createCriterionOption("play_duration"),
           new ResolutionCriterionOption(),
           ListFilterModel.createCriterionOption("duration"),
           new HasMarkersCriterionOption(),
@@ -439,6 +447,52 @@ export class ListFilterModel {
     return queryString.stringify(this.getQueryParameters(), { encode: false });
   }
 
+  // makeSavedFilter returns the JSON-encoded filter of a saved filter. The
+  // current page is not saved.
+  public makeSavedFilter(): string {
+    return JSON.stringify({
+      find_filter: { ...this.makeFindFilter(), page: undefined },
+      object_filter: this.makeObjectFilter(),
+      ui_options: {
+        query: queryString.stringify(
+          { ...this.getQueryParameters(), p: undefined },
+          { encode: false }
+        ),
+      },
+    });
+  }
+
+  public configureFromSavedFilter(savedFilter: string) {
+    const parsed = JSON.parse(savedFilter);
+    const query = parsed?.ui_options?.query ?? "";
+
+    this.searchTerm = undefined;
+    this.currentPage = DEFAULT_PARAMS.currentPage;
+    this.criteria = [];
+    this.configureFromQueryParameters(queryString.parse(query));
+  }
+
+  public makeObjectFilter() {
+    switch (this.filterMode) {
+      case FilterMode.Scenes:
+        return this.makeSceneFilter();
+      case FilterMode.Performers:
+        return this.makePerformerFilter();
+      case FilterMode.Studios:
+        return this.makeStudioFilter();
+      case FilterMode.Galleries:
+        return this.makeGalleryFilter();
+      case FilterMode.SceneMarkers:
+        return this.makeSceneMarkerFilter();
+      case FilterMode.Movies:
+        return this.makeMovieFilter();
+      case FilterMode.Tags:
+        return this.makeTagFilter();
+      case FilterMode.Images:
+        return this.makeImageFilter();
+    }
+  }
+
   // TODO: These don't support multiple of the same criteria, only the last one set is used.
 
   public makeFindFilter(): FindFilterType {
@@ -536,6 +590,30 @@ export class ListFilterModel {
           };
           break;
         }
+        case "play_count": {
+          const playCountCrit = criterion as NumberCriterion;
+          result.play_count = {
+            value: playCountCrit.value,
+            modifier: playCountCrit.modifier,
+          };
+          break;
+        }
+        case "resume_time": {
+          const resumeTimeCrit = criterion as DurationCriterion;
+          result.resume_time = {
+            value: resumeTimeCrit.value,
+            modifier: resumeTimeCrit.modifier,
+          };
+          break;
+        }
+        case "play_duration": {
+          const playDurationCrit = criterion as DurationCriterion;
+          result.play_duration = {
+            value: playDurationCrit.value,
+            modifier: playDurationCrit.modifier,
+          };
+          break;
+        }
         case "hasMarkers":
           result.has_markers = (criterion as HasMarkersCriterion).value;
           break;
@@ -1,3 +1,5 @@
+import * as GQL from "src/core/generated-graphql";
+
 // NOTE: add new enum values to the end, to ensure existing data
 // is not impacted
 export enum DisplayMode {
@@ -18,6 +20,18 @@ export enum FilterMode {
   Images,
 }
 
+// GQLFilterMode maps the filter modes to the filter modes of saved filters
+export const GQLFilterMode: Record<FilterMode, GQL.FilterMode> = {
+  [FilterMode.Scenes]: GQL.FilterMode.Scenes,
+  [FilterMode.Performers]: GQL.FilterMode.Performers,
+  [FilterMode.Studios]: GQL.FilterMode.Studios,
+  [FilterMode.Galleries]: GQL.FilterMode.Galleries,
+  [FilterMode.SceneMarkers]: GQL.FilterMode.SceneMarkers,
+  [FilterMode.Movies]: GQL.FilterMode.Movies,
+  [FilterMode.Tags]: GQL.FilterMode.Tags,
+  [FilterMode.Images]: GQL.FilterMode.Images,
+};
+
 export interface ILabeledId {
   id: string;
   label: string;